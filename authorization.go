@@ -0,0 +1,73 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ServiceWeaver/weaver/internal/net/call"
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+	"github.com/ServiceWeaver/weaver/runtime/mtls"
+	"golang.org/x/exp/slices"
+)
+
+// authorize returns a PermissionDeniedError if the caller of the call being
+// handled with ctx may not make the provided call. allowed is the set of
+// components allowed to make the call, or nil if any component may make it.
+//
+// The caller is the component named in the call's header. If the caller
+// connected with mTLS, the caller must also be one of the components listed
+// in its certificate, so that a weavelet cannot impersonate a component it
+// doesn't host.
+func authorize(ctx context.Context, info RemoteCallInfo, allowed map[string]bool) error {
+	caller := call.CallerFromContext(ctx)
+	var err error
+	if cert := call.PeerCertificateFromContext(ctx); cert != nil && !slices.Contains(mtls.Components(cert), caller) {
+		err = fmt.Errorf("%w: caller %q is not hosted by weavelet %q", PermissionDeniedError, caller, cert.Subject.CommonName)
+	} else if allowed != nil && !allowed[caller] {
+		err = fmt.Errorf("%w: %q may not call %s.%s", PermissionDeniedError, caller, info.Component, info.Method)
+	}
+	if err != nil {
+		codegen.MethodDenials.Get(codegen.MethodLabels{
+			Caller:    caller,
+			Component: info.Component,
+			Method:    info.Method,
+		}).Add(1)
+	}
+	return err
+}
+
+// authorizeHandler returns a handler that runs handler if the caller is
+// authorized to make the call. See authorize.
+func authorizeHandler(info RemoteCallInfo, allowed map[string]bool, handler call.Handler) call.Handler {
+	return func(ctx context.Context, args []byte) ([]byte, error) {
+		if err := authorize(ctx, info, allowed); err != nil {
+			return nil, err
+		}
+		return handler(ctx, args)
+	}
+}
+
+// authorizeStreamHandler returns a stream handler that runs handler if the
+// caller is authorized to make the call. See authorize.
+func authorizeStreamHandler(info RemoteCallInfo, allowed map[string]bool, handler call.StreamHandler) call.StreamHandler {
+	return func(ctx context.Context, args []byte, stream call.ServerStream) ([]byte, error) {
+		if err := authorize(ctx, info, allowed); err != nil {
+			return nil, err
+		}
+		return handler(ctx, args, stream)
+	}
+}
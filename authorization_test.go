@@ -0,0 +1,135 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaver
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/internal/net/call"
+	"github.com/ServiceWeaver/weaver/runtime/logging"
+	"github.com/ServiceWeaver/weaver/runtime/metrics"
+	"github.com/ServiceWeaver/weaver/runtime/mtls"
+)
+
+// serveAuthorized serves a "test.Get" handler that only allows the provided
+// callers, and returns a client to the handler. If the TLS configs are not
+// nil, the client and server use mTLS with the provided configs.
+func serveAuthorized(t *testing.T, allowed map[string]bool, serverTLS, clientTLS *tls.Config) call.Connection {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	handlers := &call.HandlerMap{}
+	info := RemoteCallInfo{Component: "test", Method: "Get"}
+	handlers.Set("test", "Get", authorizeHandler(info, allowed, func(context.Context, []byte) ([]byte, error) {
+		return []byte("ok"), nil
+	}))
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go call.Serve(ctx, lis, handlers, call.ServerOptions{Logger: logging.NewTestLogger(t), TLSConfig: serverTLS})
+
+	client, err := call.Connect(ctx, call.NewConstantResolver(call.TCP(lis.Addr().String())), call.ClientOptions{
+		Logger:    logging.NewTestLogger(t),
+		TLSConfig: clientTLS,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+	return client
+}
+
+// checkAuthorized checks whether a call to client made by caller succeeds.
+func checkAuthorized(t *testing.T, client call.Connection, caller string, want bool) {
+	t.Helper()
+	before := denials(caller)
+	key := call.MakeMethodKey("test", "Get")
+	_, err := client.Call(context.Background(), key, nil, call.CallOptions{Caller: caller})
+	denied := denials(caller) - before
+	switch {
+	case want && err != nil:
+		t.Fatalf("caller %q: unexpected error: %v", caller, err)
+	case !want && !errors.Is(err, PermissionDeniedError):
+		t.Fatalf("caller %q: got error %v, want %v", caller, err, PermissionDeniedError)
+	case want && denied != 0:
+		t.Fatalf("caller %q: got %v denials, want 0", caller, denied)
+	case !want && denied != 1:
+		t.Fatalf("caller %q: got %v denials, want 1", caller, denied)
+	}
+}
+
+// denials returns the number of calls to test.Get by caller that were denied.
+func denials(caller string) float64 {
+	for _, m := range metrics.Snapshot() {
+		if m.Name == "serviceweaver_remote_method_denied_count" && m.Labels["caller"] == caller && m.Labels["component"] == "test" {
+			return m.Value
+		}
+	}
+	return 0
+}
+
+func TestAuthorizePolicy(t *testing.T) {
+	client := serveAuthorized(t, map[string]bool{"main": true}, nil, nil)
+	checkAuthorized(t, client, "main", true)
+	checkAuthorized(t, client, "other", false)
+	checkAuthorized(t, client, "", false)
+}
+
+func TestAuthorizeNoPolicy(t *testing.T) {
+	client := serveAuthorized(t, nil, nil, nil)
+	checkAuthorized(t, client, "main", true)
+	checkAuthorized(t, client, "", true)
+}
+
+func TestAuthorizeMTLS(t *testing.T) {
+	// The client's certificate says it hosts "main" and "a", so it can't
+	// claim to be "b", even though the policy allows "b".
+	ca, err := mtls.NewCA("dep")
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCreds, err := ca.Issue("client", []string{"main", "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverCreds, err := ca.Issue("server", []string{"test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientTLS, err := mtls.ClientConfig("dep", clientCreds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverTLS, err := mtls.ServerConfig("dep", serverCreds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := serveAuthorized(t, nil, serverTLS, clientTLS)
+	checkAuthorized(t, client, "main", true)
+	checkAuthorized(t, client, "a", true)
+	checkAuthorized(t, client, "b", false)
+
+	client = serveAuthorized(t, map[string]bool{"a": true, "b": true}, serverTLS, clientTLS)
+	checkAuthorized(t, client, "main", false)
+	checkAuthorized(t, client, "a", true)
+	checkAuthorized(t, client, "b", false)
+}
@@ -72,8 +72,11 @@ func main() {
 		generateFlags.Usage = func() {
 			fmt.Fprintln(os.Stderr, generate.Usage)
 		}
+		var opt generate.Options
+		generateFlags.StringVar(&opt.Descriptor, "descriptor", "", "Write an API descriptor to the provided file.")
+		generateFlags.StringVar(&opt.CheckCompat, "check-compat", "", "Check compatibility with the provided API descriptor.")
 		generateFlags.Parse(flag.Args()[1:]) //nolint:errcheck // does os.Exit on error
-		if err := generate.Generate(".", flag.Args()[1:]); err != nil {
+		if err := generate.Generate(".", generateFlags.Args(), opt); err != nil {
 			fmt.Fprint(os.Stderr, err)
 			os.Exit(1)
 		}
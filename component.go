@@ -103,6 +103,7 @@ type component struct {
 
 	local register.WriteOnce[bool] // routed locally?
 	load  *loadCollector           // non-nil for routed components
+	calls *callCounter             // counts the calls received by the component
 }
 
 var _ Instance = &componentImpl{}
@@ -295,6 +296,42 @@ type AutoMarshal struct{}
 func (AutoMarshal) WeaverMarshal(*codegen.Encoder)   {}
 func (AutoMarshal) WeaverUnmarshal(*codegen.Decoder) {}
 
+// RegisterImpl registers the AutoMarshal struct type T as an implementation
+// of the interface type I.
+//
+// Named interface types are serializable, but only values whose concrete type
+// is registered can be serialized. For example:
+//
+//	type Shape interface {
+//	    Area() float64
+//	}
+//
+//	type Circle struct {
+//	    weaver.AutoMarshal
+//	    Radius float64
+//	}
+//
+//	func (c Circle) Area() float64 { return math.Pi * c.Radius * c.Radius }
+//
+//	func init() {
+//	    weaver.RegisterImpl[Shape, Circle]()
+//	}
+//
+// With Circle registered, a component method can take or return a Shape. A
+// Circle or *Circle stored in a Shape is encoded along with a compact tag that
+// identifies its type, and is decoded back into a Circle or *Circle
+// respectively. Serializing a value whose type isn't registered fails.
+//
+// RegisterImpl should be called in an init function, so that every process
+// of the application registers the same types. RegisterImpl panics if neither
+// T nor *T implements I.
+func RegisterImpl[I any, T any, PT interface {
+	*T
+	codegen.AutoMarshal
+}]() {
+	codegen.RegisterImpl[I, T, PT]()
+}
+
 // WithConfig[T] is a type that can be embedded inside a component
 // implementation. Service Weaver runtime will take per-component configuration
 // information found in the application config file and use it
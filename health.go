@@ -0,0 +1,156 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"golang.org/x/exp/slices"
+)
+
+// healthCheckTimeout is the amount of time the Health methods of components
+// have to return. A component whose Health method doesn't return in time is
+// unhealthy.
+const healthCheckTimeout = 5 * time.Second
+
+// healthChecker is implemented by components that check their own health.
+type healthChecker interface {
+	Health(context.Context) error
+}
+
+// weavelets holds the weavelets running in this process, whose aggregate
+// health is served on /healthz.
+var weavelets = struct {
+	mu sync.Mutex
+	m  map[*weavelet]struct{}
+}{m: map[*weavelet]struct{}{}}
+
+// registerHealth registers w with the /healthz handler until w.ctx is done.
+func registerHealth(w *weavelet) {
+	weavelets.mu.Lock()
+	defer weavelets.mu.Unlock()
+	weavelets.m[w] = struct{}{}
+	go func() {
+		<-w.ctx.Done()
+		weavelets.mu.Lock()
+		defer weavelets.mu.Unlock()
+		delete(weavelets.m, w)
+	}()
+}
+
+// serveHealthz serves /healthz. It responds with "ok" if every component
+// hosted in this process is healthy, and with a 503 status code listing the
+// unhealthy components otherwise.
+func serveHealthz(w http.ResponseWriter, r *http.Request) {
+	weavelets.mu.Lock()
+	var wlets []*weavelet
+	for wlet := range weavelets.m {
+		wlets = append(wlets, wlet)
+	}
+	weavelets.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+	defer cancel()
+	var unhealthy []string
+	for _, wlet := range wlets {
+		for _, c := range wlet.health(ctx).Components {
+			if c.Status != protos.HealthStatus_HEALTHY {
+				unhealthy = append(unhealthy, fmt.Sprintf("%s: %s", c.Component, c.Error))
+			}
+		}
+	}
+	if len(unhealthy) > 0 {
+		http.Error(w, strings.Join(unhealthy, "\n"), http.StatusServiceUnavailable)
+		return
+	}
+	w.Write([]byte("ok")) //nolint:errcheck // response write error
+}
+
+// GetHealth implements the conn.WeaveletHandler interface.
+func (w *weavelet) GetHealth(*protos.GetHealthRequest) (*protos.GetHealthReply, error) {
+	ctx, cancel := context.WithTimeout(w.ctx, healthCheckTimeout)
+	defer cancel()
+	return w.health(ctx), nil
+}
+
+// health calls the Health method of every started component that has one,
+// and returns the health of the weavelet.
+func (w *weavelet) health(ctx context.Context) *protos.GetHealthReply {
+	w.mu.Lock()
+	started := slices.Clone(w.started)
+	w.mu.Unlock()
+
+	checks := map[string]healthChecker{}
+	for _, c := range started {
+		if h, ok := c.impl.impl.(healthChecker); ok {
+			checks[c.info.Name] = h
+		}
+	}
+	return checkHealth(ctx, checks)
+}
+
+// checkHealth runs the provided health checks, keyed by component name,
+// concurrently, and aggregates their results. Checks that don't return
+// before ctx is done fail.
+func checkHealth(ctx context.Context, checks map[string]healthChecker) *protos.GetHealthReply {
+	results := make(chan *protos.ComponentHealth, len(checks))
+	for name, h := range checks {
+		name, h := name, h
+		go func() {
+			result := &protos.ComponentHealth{Component: name, Status: protos.HealthStatus_HEALTHY}
+			if err := h.Health(ctx); err != nil {
+				result.Status = protos.HealthStatus_UNHEALTHY
+				result.Error = err.Error()
+			}
+			results <- result
+		}()
+	}
+
+	reply := &protos.GetHealthReply{Status: protos.HealthStatus_HEALTHY}
+	pending := len(checks)
+	for pending > 0 {
+		select {
+		case result := <-results:
+			reply.Components = append(reply.Components, result)
+			delete(checks, result.Component)
+			pending--
+		case <-ctx.Done():
+			// Checks that are still running fail.
+			for name := range checks {
+				reply.Components = append(reply.Components, &protos.ComponentHealth{
+					Component: name,
+					Status:    protos.HealthStatus_UNHEALTHY,
+					Error:     fmt.Sprintf("health check: %v", ctx.Err()),
+				})
+			}
+			pending = 0
+		}
+	}
+	for _, c := range reply.Components {
+		if c.Status != protos.HealthStatus_HEALTHY {
+			reply.Status = protos.HealthStatus_UNHEALTHY
+		}
+	}
+	slices.SortFunc(reply.Components, func(a, b *protos.ComponentHealth) bool {
+		return a.Component < b.Component
+	})
+	return reply
+}
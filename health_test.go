@@ -0,0 +1,65 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// healthFunc is a healthChecker that calls a function.
+type healthFunc func(context.Context) error
+
+func (f healthFunc) Health(ctx context.Context) error { return f(ctx) }
+
+func TestCheckHealth(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	block := make(chan struct{})
+	defer close(block)
+
+	got := checkHealth(ctx, map[string]healthChecker{
+		"healthy": healthFunc(func(context.Context) error { return nil }),
+		"failing": healthFunc(func(context.Context) error { return errors.New("disk full") }),
+		"stuck": healthFunc(func(context.Context) error {
+			// Ignore ctx, like a badly behaved health check.
+			<-block
+			return nil
+		}),
+	})
+	want := &protos.GetHealthReply{
+		Status: protos.HealthStatus_UNHEALTHY,
+		Components: []*protos.ComponentHealth{
+			{Component: "failing", Status: protos.HealthStatus_UNHEALTHY, Error: "disk full"},
+			{Component: "healthy", Status: protos.HealthStatus_HEALTHY},
+			{Component: "stuck", Status: protos.HealthStatus_UNHEALTHY, Error: "health check: context deadline exceeded"},
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Fatalf("checkHealth (-want +got):\n%s", diff)
+	}
+
+	// A weavelet without health checks is healthy.
+	got = checkHealth(context.Background(), map[string]healthChecker{})
+	if got.Status != protos.HealthStatus_HEALTHY {
+		t.Fatalf("checkHealth: got %v, want %v", got.Status, protos.HealthStatus_HEALTHY)
+	}
+}
@@ -0,0 +1,305 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+
+	"github.com/ServiceWeaver/weaver/internal/net/call"
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+)
+
+// RemoteCallInfo describes a remote component method call.
+type RemoteCallInfo struct {
+	Component string // full name of the called component (e.g., "github.com/example/app/Cache")
+	Method    string // name of the called method (e.g., "Get")
+}
+
+// A RemoteClientInterceptor intercepts the remote component method calls made
+// by a component client. The interceptor makes the call by calling invoke,
+// possibly with a different context, and returns the error returned by
+// invoke. It can also fail the call, without making it, by returning an error
+// without calling invoke. An error returned by a client interceptor is
+// returned to the caller of the method, wrapped in a RemoteCallError.
+//
+// The interceptor sees the names of the called component and method, but not
+// the method arguments or results. Likewise, invoke returns an error if the
+// call couldn't be made, but not the error returned by the method itself. For
+// a streaming method, invoke returns once the call has been started.
+type RemoteClientInterceptor func(ctx context.Context, call RemoteCallInfo, invoke func(context.Context) error) error
+
+// A RemoteServerInterceptor intercepts the remote component method calls
+// received by a component. The interceptor executes the call by calling
+// handle, possibly with a different context, and returns the error returned
+// by handle. It can also reject the call, without executing it, by returning
+// an error without calling handle. The error is returned to the caller of the
+// method, wrapped in a RemoteCallError.
+//
+// The interceptor sees the names of the called component and method, but not
+// the method arguments or results. Errors returned by the method itself are
+// not returned by handle: they are returned to the caller as the result of
+// the method.
+type RemoteServerInterceptor func(ctx context.Context, call RemoteCallInfo, handle func(context.Context) error) error
+
+// RemoteInterceptors is a pair of interceptors for remote component method
+// calls. Either interceptor may be nil.
+type RemoteInterceptors struct {
+	Client RemoteClientInterceptor // intercepts calls made by component clients
+	Server RemoteServerInterceptor // intercepts calls received by components
+}
+
+// interceptors holds the interceptors registered with InterceptRemoteCalls
+// and InterceptRemoteCallsTo.
+var interceptors struct {
+	mu          sync.Mutex
+	all         []RemoteInterceptors                  // registered with InterceptRemoteCalls
+	byComponent map[reflect.Type][]RemoteInterceptors // registered with InterceptRemoteCallsTo
+}
+
+// InterceptRemoteCalls registers interceptors for the remote method calls of
+// every component. Interceptors are useful for things like authorization
+// checks, request logging, custom metrics, and fault injection. For example:
+//
+//	weaver.InterceptRemoteCalls(weaver.RemoteInterceptors{
+//	    Server: func(ctx context.Context, call weaver.RemoteCallInfo, handle func(context.Context) error) error {
+//	        start := time.Now()
+//	        err := handle(ctx)
+//	        log.Printf("%s.%s took %v", call.Component, call.Method, time.Since(start))
+//	        return err
+//	    },
+//	})
+//
+// Method calls to a component running in the same process, including every
+// call made when the application runs in a single process and calls between
+// components that are co-located, are intercepted too. To do so, the
+// arguments and results of a call to a component with interceptors are
+// serialized, as if the call were remote, even if the component runs in the
+// same process. Interceptors must be registered before weaver.Init is called,
+// typically in an init function or at the start of main, so that they are
+// registered in every process of the application.
+//
+// Interceptors run in the order in which they are registered, with the
+// interceptors registered with InterceptRemoteCalls running before the
+// interceptors registered with InterceptRemoteCallsTo.
+func InterceptRemoteCalls(i RemoteInterceptors) {
+	interceptors.mu.Lock()
+	defer interceptors.mu.Unlock()
+	interceptors.all = append(interceptors.all, i)
+}
+
+// InterceptRemoteCallsTo registers interceptors for the remote method calls
+// of component T. See InterceptRemoteCalls.
+func InterceptRemoteCallsTo[T any](i RemoteInterceptors) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	interceptors.mu.Lock()
+	defer interceptors.mu.Unlock()
+	if interceptors.byComponent == nil {
+		interceptors.byComponent = map[reflect.Type][]RemoteInterceptors{}
+	}
+	interceptors.byComponent[t] = append(interceptors.byComponent[t], i)
+}
+
+// interceptorsFor returns the client and server interceptors for the
+// component with the provided interface type, or nil if there are none.
+func interceptorsFor(iface reflect.Type) (RemoteClientInterceptor, RemoteServerInterceptor) {
+	interceptors.mu.Lock()
+	defer interceptors.mu.Unlock()
+	var clients []RemoteClientInterceptor
+	var servers []RemoteServerInterceptor
+	all := append([]RemoteInterceptors{}, interceptors.all...)
+	all = append(all, interceptors.byComponent[iface]...)
+	for _, i := range all {
+		if i.Client != nil {
+			clients = append(clients, i.Client)
+		}
+		if i.Server != nil {
+			servers = append(servers, i.Server)
+		}
+	}
+	return chain(clients), chain(servers)
+}
+
+// chain returns an interceptor that runs the provided interceptors in order,
+// or nil if there are no interceptors. The returned interceptor fails if an
+// interceptor doesn't return an error, but doesn't call the next interceptor
+// either.
+func chain[I ~func(context.Context, RemoteCallInfo, func(context.Context) error) error](interceptors []I) I {
+	if len(interceptors) == 0 {
+		return nil
+	}
+	return func(ctx context.Context, call RemoteCallInfo, next func(context.Context) error) error {
+		var run func(ctx context.Context, i int) error
+		run = func(ctx context.Context, i int) error {
+			if i == len(interceptors) {
+				return next(ctx)
+			}
+			called := false
+			err := interceptors[i](ctx, call, func(ctx context.Context) error {
+				called = true
+				return run(ctx, i+1)
+			})
+			if err == nil && !called {
+				return fmt.Errorf("interceptor for %s.%s returned without making the call", call.Component, call.Method)
+			}
+			return err
+		}
+		return run(ctx, 0)
+	}
+}
+
+// localConnection is a call.Connection that executes calls on a component
+// running in the same process, through the component's server stub. Calls to
+// a local component with interceptors are made through a localConnection, so
+// that they are intercepted like remote calls.
+type localConnection struct {
+	server      codegen.Server
+	interceptor RemoteServerInterceptor           // if not nil, intercepts calls
+	calls       map[call.MethodKey]RemoteCallInfo // keyed by method key
+}
+
+var _ call.Connection = &localConnection{}
+
+// Call implements the call.Connection interface.
+func (l *localConnection) Call(ctx context.Context, h call.MethodKey, args []byte, _ call.CallOptions) ([]byte, error) {
+	info := l.calls[h]
+	fn := l.server.GetStubFn(info.Method)
+	if fn == nil {
+		return nil, fmt.Errorf("method %s.%s can only be called as a streaming method", info.Component, info.Method)
+	}
+	handler := call.Handler(fn)
+	if l.interceptor != nil {
+		handler = interceptHandler(l.interceptor, info, handler)
+	}
+	return handler(ctx, args)
+}
+
+// Stream implements the call.Connection interface.
+func (l *localConnection) Stream(ctx context.Context, h call.MethodKey, args []byte, _ call.CallOptions) (call.ClientStream, error) {
+	info := l.calls[h]
+	var fn func(context.Context, []byte, codegen.ServerStream) ([]byte, error)
+	if server, ok := l.server.(codegen.StreamServer); ok {
+		fn = server.GetStreamStubFn(info.Method)
+	}
+	if fn == nil {
+		return nil, fmt.Errorf("method %s.%s is not a streaming method", info.Component, info.Method)
+	}
+	handler := func(ctx context.Context, args []byte, stream call.ServerStream) ([]byte, error) {
+		return fn(ctx, args, stream)
+	}
+	if l.interceptor != nil {
+		handler = interceptStreamHandler(l.interceptor, info, handler)
+	}
+
+	s := &localStream{
+		ctx:      ctx,
+		toServer: make(chan []byte),
+		toClient: make(chan []byte),
+		done:     make(chan struct{}),
+	}
+	go func() {
+		s.result, s.err = handler(ctx, args, localServerStream{s})
+		close(s.toClient)
+		close(s.done)
+	}()
+	return s, nil
+}
+
+// Close implements the call.Connection interface.
+func (l *localConnection) Close() {}
+
+// localStream is the client side of a streaming call made through a
+// localConnection.
+type localStream struct {
+	ctx       context.Context
+	toServer  chan []byte   // values sent by the client
+	toClient  chan []byte   // values sent by the server; closed when the call ends
+	closeSend sync.Once     // closes toServer
+	done      chan struct{} // closed when the call ends
+	result    []byte        // result of the call, once done is closed
+	err       error         // error of the call, once done is closed
+}
+
+var _ call.ClientStream = &localStream{}
+
+// Send implements the call.ClientStream interface.
+func (s *localStream) Send(value []byte) error {
+	select {
+	case s.toServer <- value:
+		return nil
+	case <-s.done:
+		return io.EOF
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+// CloseSend implements the call.ClientStream interface.
+func (s *localStream) CloseSend() error {
+	s.closeSend.Do(func() { close(s.toServer) })
+	return nil
+}
+
+// Recv implements the call.ClientStream interface.
+func (s *localStream) Recv() ([]byte, error) {
+	select {
+	case value, ok := <-s.toClient:
+		if !ok {
+			return nil, io.EOF
+		}
+		return value, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+// Result implements the call.ClientStream interface.
+func (s *localStream) Result() ([]byte, error) {
+	<-s.done
+	return s.result, s.err
+}
+
+// localServerStream is the server side of a localStream.
+type localServerStream struct {
+	s *localStream
+}
+
+var _ call.ServerStream = localServerStream{}
+
+// Send implements the call.ServerStream interface.
+func (s localServerStream) Send(value []byte) error {
+	select {
+	case s.s.toClient <- value:
+		return nil
+	case <-s.s.ctx.Done():
+		return s.s.ctx.Err()
+	}
+}
+
+// Recv implements the call.ServerStream interface.
+func (s localServerStream) Recv() ([]byte, error) {
+	select {
+	case value, ok := <-s.s.toServer:
+		if !ok {
+			return nil, io.EOF
+		}
+		return value, nil
+	case <-s.s.ctx.Done():
+		return nil, s.s.ctx.Err()
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaver
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestChain(t *testing.T) {
+	type key struct{}
+	var trace []string
+	interceptor := func(name string) RemoteClientInterceptor {
+		return func(ctx context.Context, call RemoteCallInfo, invoke func(context.Context) error) error {
+			trace = append(trace, name+" before")
+			err := invoke(context.WithValue(ctx, key{}, name))
+			trace = append(trace, name+" after")
+			return err
+		}
+	}
+
+	chained := chain([]RemoteClientInterceptor{interceptor("a"), interceptor("b")})
+	want := errors.New("call failed")
+	err := chained(context.Background(), RemoteCallInfo{}, func(ctx context.Context) error {
+		trace = append(trace, "call with "+ctx.Value(key{}).(string))
+		return want
+	})
+	if !errors.Is(err, want) {
+		t.Fatalf("got error %v, want %v", err, want)
+	}
+	if diff := cmp.Diff([]string{"a before", "b before", "call with b", "b after", "a after"}, trace); diff != "" {
+		t.Fatalf("trace (-want +got):\n%s", diff)
+	}
+}
+
+func TestChainNoCall(t *testing.T) {
+	swallow := func(context.Context, RemoteCallInfo, func(context.Context) error) error { return nil }
+	chained := chain([]RemoteServerInterceptor{swallow})
+	called := false
+	err := chained(context.Background(), RemoteCallInfo{Component: "Foo", Method: "Bar"}, func(context.Context) error {
+		called = true
+		return nil
+	})
+	if called {
+		t.Fatal("unexpected call")
+	}
+	if err == nil || !strings.Contains(err.Error(), "without making the call") {
+		t.Fatalf("got error %v, want error about missing call", err)
+	}
+}
+
+func TestChainEmpty(t *testing.T) {
+	if chain([]RemoteClientInterceptor{}) != nil {
+		t.Fatal("expected nil interceptor")
+	}
+}
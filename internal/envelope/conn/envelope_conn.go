@@ -248,17 +248,17 @@ func (e *EnvelopeConn) GetMetricsRPC() ([]*metrics.MetricSnapshot, error) {
 	return e.metrics.Import(reply.GetMetricsReply.Update)
 }
 
-// GetHealthRPC gets a weavelet's health.
-func (e *EnvelopeConn) GetHealthRPC() (protos.HealthStatus, error) {
+// GetHealthRPC gets the health of a weavelet and of its components.
+func (e *EnvelopeConn) GetHealthRPC() (*protos.GetHealthReply, error) {
 	req := &protos.EnvelopeMsg{GetHealthRequest: &protos.GetHealthRequest{}}
 	reply, err := e.rpc(req)
 	if err != nil {
-		return protos.HealthStatus_UNHEALTHY, err
+		return nil, err
 	}
 	if reply.GetHealthReply == nil {
-		return protos.HealthStatus_UNHEALTHY, fmt.Errorf("nil HealthStatusReply received from weavelet")
+		return nil, fmt.Errorf("nil HealthStatusReply received from weavelet")
 	}
-	return reply.GetHealthReply.Status, nil
+	return reply.GetHealthReply, nil
 }
 
 // GetLoadRPC gets a load report from the weavelet.
@@ -323,6 +323,20 @@ func (e *EnvelopeConn) UpdateRoutingInfoRPC(routing *protos.RoutingInfo) error {
 	return nil
 }
 
+// ShutdownRPC asks the weavelet to shut down gracefully. ShutdownRPC blocks
+// until the weavelet has drained its in-progress method calls and shut down
+// its components.
+func (e *EnvelopeConn) ShutdownRPC(req *protos.ShutdownRequest) error {
+	reply, err := e.rpc(&protos.EnvelopeMsg{ShutdownRequest: req})
+	if err != nil {
+		return err
+	}
+	if reply.ShutdownReply == nil {
+		return fmt.Errorf("nil ShutdownReply received from weavelet")
+	}
+	return nil
+}
+
 func (e *EnvelopeConn) rpc(request *protos.EnvelopeMsg) (*protos.WeaveletMsg, error) {
 	response, err := e.conn.doBlockingRPC(request)
 	if err != nil {
@@ -16,11 +16,13 @@ package conn
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"os"
 	"runtime/pprof"
+	"sync"
 	"time"
 
 	"github.com/ServiceWeaver/weaver/runtime"
@@ -44,6 +46,16 @@ type WeaveletHandler interface {
 
 	// UpdateRoutingInfo updates a component's routing information.
 	UpdateRoutingInfo(*protos.UpdateRoutingInfoRequest) (*protos.UpdateRoutingInfoReply, error)
+
+	// GetHealth returns the health of the weavelet and of the components it
+	// hosts. Like Shutdown, GetHealth may block; it is not invoked on the
+	// goroutine that serves messages from the envelope.
+	GetHealth(*protos.GetHealthRequest) (*protos.GetHealthReply, error)
+
+	// Shutdown gracefully shuts down the weavelet. Unlike the other methods,
+	// Shutdown may block; it is not invoked on the goroutine that serves
+	// messages from the envelope.
+	Shutdown(*protos.ShutdownRequest) (*protos.ShutdownReply, error)
 }
 
 // WeaveletConn is the weavelet side of the connection between a weavelet and
@@ -54,7 +66,11 @@ type WeaveletConn struct {
 	conn    conn
 	info    *protos.EnvelopeInfo
 	lis     net.Listener // internal network listener for the weavelet
+	unixLis net.Listener // internal Unix socket listener, if any
 	metrics metrics.Exporter
+
+	stopOnce sync.Once
+	stopped  chan struct{} // closed after replying to a ShutdownRequest
 }
 
 // NewWeaveletConn returns a connection to an envelope. The connection sends
@@ -66,6 +82,7 @@ func NewWeaveletConn(r io.ReadCloser, w io.WriteCloser, h WeaveletHandler) (*Wea
 	d := &WeaveletConn{
 		handler: h,
 		conn:    conn{name: "weavelet", reader: r, writer: w},
+		stopped: make(chan struct{}),
 	}
 
 	// Perform the handshake. First, receive EnvelopeInfo.
@@ -93,6 +110,17 @@ func NewWeaveletConn(r io.ReadCloser, w io.WriteCloser, h WeaveletHandler) (*Wea
 	}
 	d.lis = lis
 	dialAddr := fmt.Sprintf("tcp://%s", lis.Addr().String())
+	var unixDialAddr string
+	if d.info.UnixSocket != "" {
+		unixLis, err := listenUnix(d.info.UnixSocket)
+		if err != nil {
+			lis.Close()
+			d.conn.cleanup(err)
+			return nil, err
+		}
+		d.unixLis = unixLis
+		unixDialAddr = fmt.Sprintf("unix://%s", d.info.UnixSocket)
+	}
 	info := &protos.WeaveletInfo{
 		DialAddr: dialAddr,
 		Pid:      int64(os.Getpid()),
@@ -101,6 +129,7 @@ func NewWeaveletConn(r io.ReadCloser, w io.WriteCloser, h WeaveletHandler) (*Wea
 			Minor: runtime.Minor,
 			Patch: runtime.Patch,
 		},
+		UnixDialAddr: unixDialAddr,
 	}
 	if err := d.conn.send(&protos.WeaveletMsg{WeaveletInfo: info}); err != nil {
 		return nil, err
@@ -109,12 +138,18 @@ func NewWeaveletConn(r io.ReadCloser, w io.WriteCloser, h WeaveletHandler) (*Wea
 }
 
 // Serve accepts RPC requests from the envelope. Requests are handled serially
-// in the order they are received.
+// in the order they are received. If the connection is closed after the
+// weavelet has been shut down, Serve returns nil.
 func (d *WeaveletConn) Serve() error {
 	msg := &protos.EnvelopeMsg{}
 	for {
 		if err := d.conn.recv(msg); err != nil {
-			return err
+			select {
+			case <-d.stopped:
+				return nil
+			default:
+				return err
+			}
 		}
 		if err := d.handleMessage(msg); err != nil {
 			return err
@@ -132,6 +167,19 @@ func (d *WeaveletConn) Listener() net.Listener {
 	return d.lis
 }
 
+// UnixListener returns the internal Unix socket listener for the weavelet, or
+// nil if the envelope didn't ask the weavelet to listen on a Unix socket.
+func (d *WeaveletConn) UnixListener() net.Listener {
+	return d.unixLis
+}
+
+// Stopped returns a channel that is closed once the weavelet has been shut
+// down and the envelope has been sent a reply to its ShutdownRequest. At that
+// point, it is safe for the weavelet process to exit.
+func (d *WeaveletConn) Stopped() <-chan struct{} {
+	return d.stopped
+}
+
 // handleMessage handles all RPC requests initiated by the envelope. Note that
 // this method doesn't handle RPC replies from the envelope.
 func (d *WeaveletConn) handleMessage(msg *protos.EnvelopeMsg) error {
@@ -159,10 +207,26 @@ func (d *WeaveletConn) handleMessage(msg *protos.EnvelopeMsg) error {
 			GetMetricsReply: &protos.GetMetricsReply{Update: update},
 		})
 	case msg.GetHealthRequest != nil:
-		return d.conn.send(&protos.WeaveletMsg{
-			Id:             -msg.Id,
-			GetHealthReply: &protos.GetHealthReply{Status: protos.HealthStatus_HEALTHY},
-		})
+		if d.handler == nil {
+			return d.conn.send(&protos.WeaveletMsg{
+				Id:             -msg.Id,
+				GetHealthReply: &protos.GetHealthReply{Status: protos.HealthStatus_HEALTHY},
+			})
+		}
+		// Health checks call the Health methods of components, which may
+		// block, so we process the request in a separate goroutine.
+		id := msg.Id
+		req := protomsg.Clone(msg.GetHealthRequest)
+		go func() {
+			reply, err := d.handler.GetHealth(req)
+			//nolint:errcheck //errMsg will be returned on next send
+			d.conn.send(&protos.WeaveletMsg{
+				Id:             -id,
+				Error:          errstring(err),
+				GetHealthReply: reply,
+			})
+		}()
+		return nil
 	case msg.GetLoadRequest != nil:
 		reply, err := d.handler.GetLoad(msg.GetLoadRequest)
 		return d.conn.send(&protos.WeaveletMsg{
@@ -201,6 +265,25 @@ func (d *WeaveletConn) handleMessage(msg *protos.EnvelopeMsg) error {
 			Error:                  errstring(err),
 			UpdateRoutingInfoReply: reply,
 		})
+	case msg.ShutdownRequest != nil:
+		// Shutting down drains in-progress method calls and shuts down
+		// components, which may block or log over the pipe. Thus, we process
+		// the request in a separate goroutine, like profiling requests.
+		id := msg.Id
+		req := protomsg.Clone(msg.ShutdownRequest)
+		go func() {
+			reply, err := d.handler.Shutdown(req)
+			//nolint:errcheck //errMsg will be returned on next send
+			d.conn.send(&protos.WeaveletMsg{
+				Id:            -id,
+				Error:         errstring(err),
+				ShutdownReply: reply,
+			})
+			// Note that the weavelet is considered stopped even if shutting
+			// down returned an error. The error is reported to the envelope.
+			d.stopOnce.Do(func() { close(d.stopped) })
+		}()
+		return nil
 	default:
 		err := fmt.Errorf("weavelet_conn: unexpected message %+v", msg)
 		d.conn.cleanup(err)
@@ -315,3 +398,12 @@ func listen(info *protos.EnvelopeInfo) (net.Listener, error) {
 	// Create the listener
 	return net.Listen("tcp", fmt.Sprintf("%s:0", host))
 }
+
+// listenUnix returns a listener on the Unix socket at the provided path. A
+// stale socket left at path by a previous weavelet is removed.
+func listenUnix(path string) (net.Listener, error) {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("error removing stale Unix socket: %w", err)
+	}
+	return net.Listen("unix", path)
+}
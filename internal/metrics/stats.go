@@ -164,6 +164,11 @@ func (s *StatsProcessor) getSnapshot(snapshot []*metrics.MetricSnapshot) {
 			// component and a method label set. E.g., http metrics.
 			continue
 		}
+		if m.Labels["compressed"] == "true" {
+			// Ignore the sizes of compressed payloads, which are also
+			// measured before they are compressed.
+			continue
+		}
 
 		if newStats[comp] == nil {
 			newStats[comp] = map[string]*statsBucket{}
@@ -20,11 +20,8 @@ import (
 )
 
 // A Balancer picks the endpoint to which which an RPC client performs a call. A
-// Balancer should only be used by a single goroutine.
-//
-// TODO(mwhittaker): Right now, balancers have no load information about
-// endpoints. In the short term, we can at least add information about the
-// number of pending requests for every endpoint.
+// Balancer should only be used by a single goroutine. A Balancer that picks
+// endpoints based on their load should implement LoadBalancer.
 //
 // TODO(mwhittaker): Right now, we pass a balancer the set of all endpoints. We
 // instead probably want to pass it only the endpoints for which we have a
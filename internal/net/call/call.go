@@ -65,10 +65,20 @@ package call
 // the resolver later returns a new set of endpoints that includes a draining
 // connection that hasn't closed itself, the connection is transitioned out of
 // the draining phase and is once again allowed to process new RPCs.
+//
+// # Server shutdown
+//
+// When the context passed to Serve is canceled, the server stops accepting
+// new connections. If ServerOptions.DrainTimeout is non-zero, the server then
+// drains: requests that arrive on existing connections are rejected with an
+// Unreachable error (so that clients can retry them on another server), and
+// Serve waits for in-progress requests to finish, up to DrainTimeout, before
+// closing all connections.
 
 import (
 	"bufio"
 	"context"
+	"crypto/tls"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -92,6 +102,15 @@ const (
 	// maxReconnectTries is the maximum number of times a reconnecting
 	// connection will try and create a connection before erroring out.
 	maxReconnectTries = 3
+
+	// handshakeTimeout is the maximum amount of time a client waits for a
+	// server to send its version when establishing a connection.
+	handshakeTimeout = 10 * time.Second
+
+	// minPoolBackoff and maxPoolBackoff bound the delay between failed
+	// attempts to add a connection to the pool of connections to an endpoint.
+	minPoolBackoff = 100 * time.Millisecond
+	maxPoolBackoff = 10 * time.Second
 )
 
 // TODO:
@@ -109,6 +128,10 @@ type Connection interface {
 	// Call makes an RPC over a Connection.
 	Call(context.Context, MethodKey, []byte, CallOptions) ([]byte, error)
 
+	// Stream starts a streaming RPC over a Connection. The call is canceled
+	// when the provided context is canceled.
+	Stream(context.Context, MethodKey, []byte, CallOptions) (ClientStream, error)
+
 	// Close closes a connection. Pending invocations of Call are cancelled and
 	// return an error. All future invocations of Call fail and return an error
 	// immediately. Close can be called more than once.
@@ -125,15 +148,32 @@ type reconnectingConnection struct {
 	// clientConnections inside connections and draining.
 	mu          sync.Mutex
 	endpoints   []Endpoint
-	connections map[string]*clientConnection // keys are endpoint addresses
-	draining    map[string]*clientConnection // keys are endpoint addresses
+	available   []Endpoint                     // endpoints that are not ejected
+	connections map[string][]*clientConnection // keys are endpoint addresses
+	draining    map[string][]*clientConnection // keys are endpoint addresses
+	dials       map[string]*pendingDial        // keys are endpoint addresses
+	poolRetries map[string]poolRetry           // keys are endpoint addresses
 	closed      bool
+	outliers    *outlierDetector // nil if outlier detection is disabled
 
 	resolver       Resolver
 	cancelResolver func()         // cancels the watchResolver goroutine
 	resolverDone   sync.WaitGroup // used to wait for watchResolver to finish
 }
 
+// pendingDial is a connection to an endpoint that is being established.
+type pendingDial struct {
+	done chan struct{} // closed when the dial finishes
+	err  error         // the error of a failed dial, set before done is closed
+}
+
+// poolRetry tracks failed attempts to add a connection to the pool of
+// connections to an endpoint.
+type poolRetry struct {
+	failures int       // number of consecutive failures
+	next     time.Time // time of the next attempt
+}
+
 // clientConnection manages one network connection on the client-side.
 type clientConnection struct {
 	logger         *slog.Logger
@@ -141,11 +181,14 @@ type clientConnection struct {
 	c              net.Conn
 	cbuf           *bufio.Reader    // Buffered reader wrapped around c
 	wlock          sync.Mutex       // Guards writes to c
+	sendq          sendQueue        // Orders the writes of requests to c
 	mu             *sync.Mutex      // Same as reconnectingConnection.mu
+	versions       versionRange     // Versions supported by the client
 	draining       bool             // is this clientConnection draining?
 	ended          bool             // has this clientConnection ended?
 	loggedShutdown bool             // Have we logged a shutdown error?
 	version        version          // Version number to use for connection
+	compression    compression      // Compression settings for connection
 	calls          map[uint64]*call // In-progress calls
 	lastID         uint64           // Last assigned request ID for a call
 }
@@ -160,29 +203,47 @@ type call struct {
 	err      error
 	response []byte
 
+	// Size of the response on the wire, if it was compressed, or zero.
+	compressedReply int
+
+	// Stream state for streaming calls, or nil for regular calls.
+	stream *stream
+
+	// The load balancer that picked the endpoint of the call, if it tracks
+	// load, along with the endpoint and the time the call started. These
+	// fields are guarded by clientConnection.mu.
+	balancer LoadBalancer
+	endpoint Endpoint
+	start    time.Time
+
 	// Is the call done?
 	// This field is accessed across goroutines using atomics.
 	done uint32 // is the call done?
-
 }
 
 // serverConnection manages one network connection on the server-side.
 type serverConnection struct {
 	opts        ServerOptions
+	ss          *serverState
 	c           net.Conn
 	cbuf        *bufio.Reader // Buffered reader wrapped around c
 	wlock       sync.Mutex    // Guards writes to c
 	mu          sync.Mutex
-	closed      bool              // has c been closed?
-	version     version           // Version number to use for connection
-	cancelFuncs map[uint64]func() // Cancellation functions for in-progress calls
+	closed      bool               // has c been closed?
+	version     version            // Version number to use for connection
+	compression compression        // Compression settings for connection
+	cancelFuncs map[uint64]func()  // Cancellation functions for in-progress calls
+	streams     map[uint64]*stream // Stream state for in-progress streaming calls
 }
 
 // serverState tracks all live server-side connections so we can clean things up when canceled.
 type serverState struct {
-	opts  ServerOptions
-	mu    sync.Mutex
-	conns map[*serverConnection]struct{} // Live connections
+	opts     ServerOptions
+	mu       sync.Mutex
+	conns    map[*serverConnection]struct{} // Live connections
+	draining bool                           // Are we rejecting new requests?
+	active   int                            // Number of running handlers
+	drained  chan struct{}                  // Closed when draining and active == 0
 }
 
 // Serve starts listening for connections and requests on l. Handlers to handle
@@ -194,6 +255,9 @@ func Serve(ctx context.Context, l net.Listener, hmap *HandlerMap, opts ServerOpt
 	ss := &serverState{opts: opts}
 	defer ss.stop()
 
+	if opts.TLSConfig != nil {
+		l = tls.NewListener(l, opts.TLSConfig)
+	}
 	l = &onceCloseListener{Listener: l}
 
 	// Arrange to close the listener when the context is canceled.
@@ -202,16 +266,22 @@ func Serve(ctx context.Context, l net.Listener, hmap *HandlerMap, opts ServerOpt
 		l.Close()
 	}()
 
+	// Connections are served using a context that outlives ctx, so that
+	// requests that are in progress when ctx is canceled can be drained.
+	connCtx, cancelConns := context.WithCancel(context.Background())
+	defer cancelConns()
+
 	for {
 		conn, err := l.Accept()
 		switch {
 		case ctx.Err() != nil:
+			ss.drain(opts.DrainTimeout)
 			return ctx.Err()
 		case err != nil:
 			l.Close()
 			return fmt.Errorf("call server error listening on %s: %w", l.Addr(), err)
 		}
-		ss.serveConnection(ctx, conn, hmap)
+		ss.serveConnection(connCtx, conn, hmap)
 	}
 }
 
@@ -235,16 +305,21 @@ func (oc *onceCloseListener) Close() error {
 // when using custom networking transports.
 func ServeOn(ctx context.Context, conn net.Conn, hmap *HandlerMap, opts ServerOptions) {
 	ss := &serverState{opts: opts.withDefaults()}
+	if opts.TLSConfig != nil {
+		conn = tls.Server(conn, opts.TLSConfig)
+	}
 	ss.serveConnection(ctx, conn, hmap)
 }
 
 func (ss *serverState) serveConnection(ctx context.Context, conn net.Conn, hmap *HandlerMap) {
 	c := &serverConnection{
 		opts:        ss.opts,
+		ss:          ss,
 		c:           conn,
 		cbuf:        bufio.NewReader(conn),
 		version:     initialVersion, // Updated when we hear from client
 		cancelFuncs: map[uint64]func(){},
+		streams:     map[uint64]*stream{},
 	}
 	ss.register(c)
 
@@ -259,6 +334,63 @@ func (ss *serverState) stop() {
 	}
 }
 
+// drain rejects all new requests and waits for in-progress requests to
+// finish, or for the provided timeout to elapse, whichever comes first. If
+// timeout is zero, drain returns immediately.
+func (ss *serverState) drain(timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+
+	ss.mu.Lock()
+	ss.draining = true
+	if ss.active == 0 {
+		ss.mu.Unlock()
+		return
+	}
+	drained := make(chan struct{})
+	ss.drained = drained
+	ss.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-drained:
+	case <-timer.C:
+		ss.opts.Logger.Error("drain", "err", fmt.Errorf("%d requests still running after %v", ss.numActive(), timeout))
+	}
+}
+
+// startHandler records the start of a request handler. It returns false if
+// the server is draining and the request should be rejected.
+func (ss *serverState) startHandler() bool {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.draining {
+		return false
+	}
+	ss.active++
+	return true
+}
+
+// endHandler records the end of a request handler started by startHandler.
+func (ss *serverState) endHandler() {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.active--
+	if ss.active == 0 && ss.drained != nil {
+		close(ss.drained)
+		ss.drained = nil
+	}
+}
+
+// numActive returns the number of running request handlers.
+func (ss *serverState) numActive() int {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.active
+}
+
 func (ss *serverState) register(c *serverConnection) {
 	ss.mu.Lock()
 	defer ss.mu.Unlock()
@@ -281,11 +413,14 @@ func Connect(ctx context.Context, resolver Resolver, opts ClientOptions) (Connec
 	conn := reconnectingConnection{
 		opts:           opts.withDefaults(),
 		endpoints:      []Endpoint{},
-		connections:    map[string]*clientConnection{},
-		draining:       map[string]*clientConnection{},
+		connections:    map[string][]*clientConnection{},
+		draining:       map[string][]*clientConnection{},
+		dials:          map[string]*pendingDial{},
+		poolRetries:    map[string]poolRetry{},
 		resolver:       resolver,
 		cancelResolver: func() {},
 	}
+	conn.outliers = newOutlierDetector(conn.opts.Outliers, conn.opts.Logger, conn.opts.OnEject)
 
 	// Compute the initial set of endpoints.
 	endpoints, version, err := resolver.Resolve(ctx, nil)
@@ -327,11 +462,15 @@ func (rc *reconnectingConnection) Close() {
 			return
 		}
 		rc.closed = true
-		for _, conn := range rc.connections {
-			conn.endCalls(fmt.Errorf("%w: %s", CommunicationError, "connection closed"))
+		for _, conns := range rc.connections {
+			for _, conn := range conns {
+				conn.endCalls(fmt.Errorf("%w: %s", CommunicationError, "connection closed"))
+			}
 		}
-		for _, conn := range rc.draining {
-			conn.endCalls(fmt.Errorf("%w: %s", CommunicationError, "connection closed"))
+		for _, conns := range rc.draining {
+			for _, conn := range conns {
+				conn.endCalls(fmt.Errorf("%w: %s", CommunicationError, "connection closed"))
+			}
 		}
 	}
 	closeWithLock()
@@ -344,12 +483,11 @@ func (rc *reconnectingConnection) Close() {
 	rc.resolverDone.Wait()
 }
 
-// Call makes an RPC over connection c.
-func (rc *reconnectingConnection) Call(ctx context.Context, h MethodKey, arg []byte, opts CallOptions) ([]byte, error) {
+// requestHeader returns the header of a request for method h.
+func requestHeader(ctx context.Context, h MethodKey) ([msgHeaderSize]byte, error) {
 	var hdr [msgHeaderSize]byte
 	copy(hdr[0:], h[:])
-	deadline, haveDeadline := ctx.Deadline()
-	if haveDeadline {
+	if deadline, haveDeadline := ctx.Deadline(); haveDeadline {
 		// Send the deadline in the header. We use the relative time instead
 		// of absolute in case there is significant clock skew. This does mean
 		// that we will not count transmission delay against the deadline.
@@ -358,67 +496,191 @@ func (rc *reconnectingConnection) Call(ctx context.Context, h MethodKey, arg []b
 			// Fail immediately without attempting to send a zero or negative
 			// deadline to the server which will be misinterpreted.
 			<-ctx.Done()
-			return nil, ctx.Err()
+			return hdr, ctx.Err()
 		}
 		binary.LittleEndian.PutUint64(hdr[16:], uint64(micros))
 	}
 
 	// Send trace information in the header.
 	writeTraceContext(ctx, hdr[24:])
+	return hdr, nil
+}
 
-	rpc := &call{}
-	rpc.doneSignal = make(chan struct{})
+// Call makes an RPC over connection c.
+func (rc *reconnectingConnection) Call(ctx context.Context, h MethodKey, arg []byte, opts CallOptions) ([]byte, error) {
+	if opts.HedgeDelay > 0 {
+		return rc.hedgedCall(ctx, h, arg, opts)
+	}
 
-	// TODO: Arrange to obey deadline in any reconnection done inside startCall.
-	//
 	// TODO(mwhittaker): Right now, every RPC call is tried on a single server
 	// connection. If the call fails, it is not retried. If a call fails on a
 	// connection, we may want to try it again on a different connection. We
 	// may also want to detect that certain connections are bad and avoid them
 	// outright.
-	conn, err := rc.startCall(ctx, rpc, opts)
+	a, err := rc.send(ctx, h, arg, opts, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := writeMessage(conn.c, &conn.wlock, requestMessage, rpc.id, hdr[:], arg, rc.opts.WriteFlattenLimit); err != nil {
-		conn.shutdown("client send request", err)
-		conn.endCall(rpc)
-		return nil, fmt.Errorf("%w: %s", CommunicationError, err)
-	}
-
 	if rc.opts.OptimisticSpinDuration > 0 {
 		// Optimistically spin, waiting for the results.
 		for start := time.Now(); time.Since(start) < rc.opts.OptimisticSpinDuration; {
-			if atomic.LoadUint32(&rpc.done) > 0 {
-				return rpc.response, rpc.err
+			if atomic.LoadUint32(&a.rpc.done) > 0 {
+				return rc.finish(a, opts)
 			}
 		}
 	}
 
 	if cdone := ctx.Done(); cdone != nil {
 		select {
-		case <-rpc.doneSignal:
+		case <-a.rpc.doneSignal:
 			// Regular return
 		case <-cdone:
 			// Canceled or deadline expired.
+			return rc.abandon(ctx, a)
+		}
+	} else {
+		<-a.rpc.doneSignal
+	}
+	return rc.finish(a, opts)
+}
+
+// attempt is a call whose request has been sent to a server.
+type attempt struct {
+	conn  *clientConnection
+	rpc   *call
+	start time.Time // when the request was sent
+}
+
+// send starts a call and sends its request to a server. If exclude is not
+// nil, the request is not sent to exclude.
+//
+// REQUIRES: rc.mu is not held.
+func (rc *reconnectingConnection) send(ctx context.Context, h MethodKey, arg []byte, opts CallOptions, exclude Endpoint) (*attempt, error) {
+	hdr, err := requestHeader(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+
+	rpc := &call{}
+	rpc.doneSignal = make(chan struct{})
+
+	conn, err := rc.startCall(ctx, rpc, opts, exclude)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+
+	mt, ext, payload := conn.getCompression().compress(requestMessage, conn.extendHeader(ctx, hdr[:], opts), arg)
+	if err := conn.writeRequest(ctx, mt, rpc.id, ext, payload, rc.opts.WriteFlattenLimit); err != nil {
+		if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+			// The caller gave up while the request was waiting to be
+			// written; the endpoint isn't to blame.
 			conn.endCall(rpc)
+			return nil, err
+		}
+		conn.shutdown("client send request", err)
+		conn.endCall(rpc)
+		err = fmt.Errorf("%w: %s", CommunicationError, err)
+		rc.record(conn.endpoint, err, time.Since(start))
+		return nil, err
+	}
+	if opts.Stats != nil && mt&compressedFlag != 0 {
+		opts.Stats.CompressedRequestBytes = len(payload)
+	}
+	return &attempt{conn: conn, rpc: rpc, start: start}, nil
+}
 
-			if !haveDeadline || time.Now().Before(deadline) {
-				// Early cancellation. Tell server about it.
-				if err := writeMessage(conn.c, &conn.wlock, cancelMessage, rpc.id, nil, nil, rc.opts.WriteFlattenLimit); err != nil {
-					conn.shutdown("client send cancel", err)
-				}
-			}
+// finish returns the result of a call that is done, and records its outcome
+// for outlier detection.
+//
+// REQUIRES: rc.mu is not held.
+func (rc *reconnectingConnection) finish(a *attempt, opts CallOptions) ([]byte, error) {
+	result, err := a.rpc.result(opts)
+	rc.record(a.conn.endpoint, err, time.Since(a.start))
+	return result, err
+}
 
-			return nil, ctx.Err()
+// abandon cancels a call because ctx is done, and returns ctx.Err(). The
+// outcome of the call is unknown, so it isn't recorded for outlier detection.
+//
+// REQUIRES: rc.mu is not held.
+func (rc *reconnectingConnection) abandon(ctx context.Context, a *attempt) ([]byte, error) {
+	rc.cancel(ctx, a)
+	return nil, ctx.Err()
+}
+
+// cancel ends a call that is not done. If ctx hasn't expired, the server is
+// told to cancel the call.
+//
+// REQUIRES: rc.mu is not held.
+func (rc *reconnectingConnection) cancel(ctx context.Context, a *attempt) {
+	a.conn.endCall(a.rpc)
+	if deadline, haveDeadline := ctx.Deadline(); !haveDeadline || time.Now().Before(deadline) {
+		// Early cancellation. Tell server about it.
+		if err := writeMessage(a.conn.c, &a.conn.wlock, cancelMessage, a.rpc.id, nil, nil, rc.opts.WriteFlattenLimit); err != nil {
+			a.conn.shutdown("client send cancel", err)
 		}
-	} else {
-		<-rpc.doneSignal
+	}
+}
+
+// result returns the result of a call that is done, and fills in the stats
+// requested by opts.
+func (rpc *call) result(opts CallOptions) ([]byte, error) {
+	if opts.Stats != nil {
+		opts.Stats.CompressedReplyBytes = rpc.compressedReply
 	}
 	return rpc.response, rpc.err
 }
 
+// Stream starts a streaming RPC over connection c.
+func (rc *reconnectingConnection) Stream(ctx context.Context, h MethodKey, arg []byte, opts CallOptions) (ClientStream, error) {
+	hdr, err := requestHeader(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+
+	rpc := &call{}
+	rpc.doneSignal = make(chan struct{})
+	rpc.stream = newStream()
+	conn, err := rc.startCall(ctx, rpc, opts, nil)
+	if err != nil {
+		return nil, err
+	}
+	if conn.getVersion() < streamingVersion {
+		conn.endCall(rpc)
+		return nil, fmt.Errorf("server at %s does not support streaming calls", conn.endpoint.Address())
+	}
+	comp := conn.getCompression()
+	rpc.stream.write = func(mt messageType, payload []byte) error {
+		mt, _, payload = comp.compress(mt, nil, payload)
+		if err := writeMessage(conn.c, &conn.wlock, mt, rpc.id, nil, payload, rc.opts.WriteFlattenLimit); err != nil {
+			conn.shutdown("client send stream", err)
+			return fmt.Errorf("%w: %s", CommunicationError, err)
+		}
+		return nil
+	}
+
+	mt, ext, payload := comp.compress(streamRequestMessage, conn.extendHeader(ctx, hdr[:], opts), arg)
+	if err := conn.writeRequest(ctx, mt, rpc.id, ext, payload, rc.opts.WriteFlattenLimit); err != nil {
+		if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+			// The caller gave up while the request was waiting to be
+			// written; the endpoint isn't to blame.
+			conn.endCall(rpc)
+			return nil, err
+		}
+		conn.shutdown("client send request", err)
+		conn.endCall(rpc)
+		return nil, fmt.Errorf("%w: %s", CommunicationError, err)
+	}
+
+	cs := &clientStream{ctx: ctx, conn: conn, rpc: rpc, stream: rpc.stream}
+	if ctx.Done() != nil {
+		go cs.watch(rc.opts)
+	}
+	return cs, nil
+}
+
 // watchResolver watches for updates to the set of endpoints. When a new set of
 // updates is available, watchResolver passes it to updateEndpoints.
 // REQUIRES: version != nil.
@@ -463,15 +725,17 @@ func (rc *reconnectingConnection) updateEndpoints(endpoints []Endpoint) error {
 	rc.removeDrainedConnections()
 
 	// Retain existing connections.
-	connections := make(map[string]*clientConnection, len(endpoints))
+	connections := make(map[string][]*clientConnection, len(endpoints))
 	for _, endpoint := range endpoints {
 		addr := endpoint.Address()
-		if conn, ok := rc.connections[addr]; ok {
-			connections[addr] = conn
+		if conns, ok := rc.connections[addr]; ok {
+			connections[addr] = conns
 			delete(rc.connections, addr)
-		} else if conn, ok := rc.draining[addr]; ok {
-			conn.draining = false
-			connections[addr] = conn
+		} else if conns, ok := rc.draining[addr]; ok {
+			for _, conn := range conns {
+				conn.draining = false
+			}
+			connections[addr] = conns
 			delete(rc.draining, addr)
 		} else {
 			// If we don't have an existing connection, it will be created
@@ -482,12 +746,24 @@ func (rc *reconnectingConnection) updateEndpoints(endpoints []Endpoint) error {
 
 	// Update our state.
 	rc.endpoints = endpoints
-	for addr, conn := range rc.connections {
-		conn.draining = true
-		rc.draining[addr] = conn
+	for addr := range rc.poolRetries {
+		if !rc.hasEndpoint(addr) {
+			delete(rc.poolRetries, addr)
+		}
+	}
+	rc.available = endpoints
+	if rc.outliers != nil {
+		rc.outliers.update(endpoints)
+		rc.available = rc.outliers.available(endpoints)
+	}
+	for addr, conns := range rc.connections {
+		for _, conn := range conns {
+			conn.draining = true
+		}
+		rc.draining[addr] = conns
 	}
 	rc.connections = connections
-	rc.opts.Balancer.Update(endpoints)
+	rc.opts.Balancer.Update(rc.available)
 
 	// Close draining connections that don't have any pending requests. If a
 	// draining connection does have pending requests, then the connection will
@@ -504,17 +780,26 @@ func (rc *reconnectingConnection) updateEndpoints(endpoints []Endpoint) error {
 //
 // REQUIRES: rc.mu is held.
 func (rc *reconnectingConnection) removeDrainedConnections() {
-	for addr, conn := range rc.draining {
-		conn.endIfDrained()
-		if conn.ended {
+	for addr, conns := range rc.draining {
+		live := conns[:0]
+		for _, conn := range conns {
+			conn.endIfDrained()
+			if !conn.ended {
+				live = append(live, conn)
+			}
+		}
+		if len(live) == 0 {
 			delete(rc.draining, addr)
+		} else {
+			rc.draining[addr] = live
 		}
 	}
 }
 
-// startCall registers a new in-progress call.
+// startCall registers a new in-progress call. If exclude is not nil, the call
+// is not sent to exclude.
 // REQUIRES: rc.mu is not held.
-func (rc *reconnectingConnection) startCall(ctx context.Context, rpc *call, opts CallOptions) (*clientConnection, error) {
+func (rc *reconnectingConnection) startCall(ctx context.Context, rpc *call, opts CallOptions, exclude Endpoint) (*clientConnection, error) {
 	rc.mu.Lock()
 	defer rc.mu.Unlock()
 
@@ -526,6 +811,16 @@ func (rc *reconnectingConnection) startCall(ctx context.Context, rpc *call, opts
 		return nil, fmt.Errorf("%w: no endpoints available", Unreachable)
 	}
 
+	if rc.outliers != nil {
+		now := time.Now()
+		if rc.outliers.circuitOpen(now) {
+			return nil, fmt.Errorf("%w: every endpoint is unhealthy", CircuitOpen)
+		}
+		if rc.outliers.expire(now) {
+			rc.updateAvailable()
+		}
+	}
+
 	// Note that it is important to hold rc.mu when calling Pick(), and it's
 	// important that we index into rc.connections with addr while still
 	// holding rc.mu. Otherwise, a Pick() call could operate on a stale set of
@@ -533,7 +828,7 @@ func (rc *reconnectingConnection) startCall(ctx context.Context, rpc *call, opts
 	var balancer = rc.opts.Balancer
 	if opts.Balancer != nil {
 		balancer = opts.Balancer
-		balancer.Update(rc.endpoints)
+		balancer.Update(rc.available)
 	}
 
 	// TODO(mwhittaker): Think about the other places where we can perform
@@ -546,53 +841,345 @@ func (rc *reconnectingConnection) startCall(ctx context.Context, rpc *call, opts
 			return nil, err
 		}
 		addr := endpoint.Address()
-
-		if conn, ok := rc.connections[addr]; !ok || conn.ended {
-			c, err := rc.reconnect(ctx, endpoint)
-			if err != nil {
-				connectErr = err
-				continue
-			}
-			rc.connections[addr] = c
+		if exclude != nil && addr == exclude.Address() {
+			connectErr = fmt.Errorf("%w: no other endpoint available", Unreachable)
+			continue
 		}
 
-		c := rc.connections[addr]
+		c, err := rc.pickConnection(ctx, endpoint)
+		if err != nil && ctx.Err() != nil {
+			// The caller gave up; the endpoint isn't to blame.
+			return nil, err
+		}
+		if err != nil {
+			connectErr = err
+			rc.recordLocked(endpoint, err, 0)
+			continue
+		}
 		c.lastID++
 		rpc.id = c.lastID
 		c.calls[rpc.id] = rpc
+		if lb, ok := balancer.(LoadBalancer); ok {
+			rpc.balancer = lb
+			rpc.endpoint = endpoint
+			rpc.start = time.Now()
+			lb.Begin(endpoint)
+		}
 		return c, nil
 	}
 	return nil, connectErr
 }
 
-// reconnect establishes (or re-establishes) the network connection to the server.
+// pickConnection returns the connection to the provided endpoint with the
+// fewest in-progress calls. If every connection to the endpoint has calls in
+// progress and there are fewer than ConnectionsPerEndpoint of them, a new
+// connection is established in the background. If there are no connections to
+// the endpoint, pickConnection waits for one to be established, or for ctx to
+// be done. Ended connections are discarded.
+//
+// pickConnection releases rc.mu while it waits for a connection to be
+// established, so that a slow or unresponsive endpoint doesn't block calls to
+// other endpoints.
+//
+// REQUIRES: rc.mu is held.
+func (rc *reconnectingConnection) pickConnection(ctx context.Context, endpoint Endpoint) (*clientConnection, error) {
+	addr := endpoint.Address()
+	for {
+		best := rc.leastLoaded(addr)
+		if best != nil && (len(best.calls) == 0 || len(rc.connections[addr]) >= rc.opts.ConnectionsPerEndpoint) {
+			return best, nil
+		}
+		if best != nil {
+			// Keep using the existing connections while the pool grows.
+			if _, ok := rc.dials[addr]; !ok {
+				rc.growPool(endpoint)
+			}
+			return best, nil
+		}
+		d, ok := rc.dials[addr]
+		if !ok {
+			d = rc.dial(endpoint)
+		}
+
+		// Wait for the pending dial to finish and try again.
+		rc.mu.Unlock()
+		select {
+		case <-d.done:
+		case <-ctx.Done():
+		}
+		rc.mu.Lock()
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if rc.closed {
+			return nil, fmt.Errorf("Call on closed Connection")
+		}
+		if d.err != nil && rc.leastLoaded(addr) == nil {
+			return nil, d.err
+		}
+	}
+}
+
+// dial starts to establish a new connection to the provided endpoint in the
+// background, and returns the pending dial. The dial is shared by every call
+// that waits for it, so it isn't bound to the context of any one call.
+//
 // REQUIRES: rc.mu is held.
+func (rc *reconnectingConnection) dial(endpoint Endpoint) *pendingDial {
+	addr := endpoint.Address()
+	d := &pendingDial{done: make(chan struct{})}
+	rc.dials[addr] = d
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), handshakeTimeout)
+		defer cancel()
+		c, err := rc.reconnect(ctx, endpoint)
+
+		rc.mu.Lock()
+		defer rc.mu.Unlock()
+		if err == nil {
+			err = rc.install(endpoint, c)
+		}
+		d.err = err
+		delete(rc.dials, addr)
+		close(d.done)
+		if err == nil || rc.closed || !rc.hasEndpoint(addr) || len(rc.connections[addr]) == 0 {
+			// Calls waiting for the first connection to an endpoint see the
+			// error themselves.
+			return
+		}
+
+		// The pool failed to grow. Back off before trying again.
+		logError(rc.opts.Logger, "connect", err)
+		r := rc.poolRetries[addr]
+		backoff := maxPoolBackoff
+		if r.failures < 16 && minPoolBackoff<<r.failures < maxPoolBackoff {
+			backoff = minPoolBackoff << r.failures
+		}
+		r.failures++
+		r.next = time.Now().Add(backoff)
+		rc.poolRetries[addr] = r
+	}()
+	return d
+}
+
+// growPool establishes an additional connection to the provided endpoint in
+// the background, unless an earlier attempt failed recently. Failed attempts
+// are retried with exponential backoff, the next time the pool needs to grow.
+//
+// REQUIRES: rc.mu is held.
+func (rc *reconnectingConnection) growPool(endpoint Endpoint) {
+	if time.Now().Before(rc.poolRetries[endpoint.Address()].next) {
+		return
+	}
+	rc.dial(endpoint)
+}
+
+// install adds a newly established connection to the provided endpoint to
+// rc.connections. If rc was closed or the endpoint was removed while the
+// connection was being established, install ends the connection instead and
+// returns an error.
+//
+// REQUIRES: rc.mu is held.
+func (rc *reconnectingConnection) install(endpoint Endpoint, c *clientConnection) error {
+	var err error
+	addr := endpoint.Address()
+	if rc.closed {
+		err = fmt.Errorf("Call on closed Connection")
+	} else if !rc.hasEndpoint(addr) {
+		err = fmt.Errorf("%w: endpoint %s removed", Unreachable, addr)
+	}
+	if err != nil {
+		c.endCalls(err)
+		return err
+	}
+	rc.connections[addr] = append(rc.connections[addr], c)
+	delete(rc.poolRetries, addr)
+	return nil
+}
+
+// leastLoaded discards the ended connections to the provided endpoint address
+// and returns the remaining connection with the fewest in-progress calls, or
+// nil if there are none.
+//
+// REQUIRES: rc.mu is held.
+func (rc *reconnectingConnection) leastLoaded(addr string) *clientConnection {
+	conns := rc.connections[addr][:0]
+	var best *clientConnection
+	for _, c := range rc.connections[addr] {
+		if c.ended {
+			continue
+		}
+		conns = append(conns, c)
+		if best == nil || len(c.calls) < len(best.calls) {
+			best = c
+		}
+	}
+	rc.connections[addr] = conns
+	return best
+}
+
+// hasEndpoint returns whether the provided address belongs to one of the
+// current endpoints.
+//
+// REQUIRES: rc.mu is held.
+func (rc *reconnectingConnection) hasEndpoint(addr string) bool {
+	for _, endpoint := range rc.endpoints {
+		if endpoint.Address() == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// record records the outcome of a call to the provided endpoint for outlier
+// detection. latency is the duration of the call, or zero if unknown.
+//
+// REQUIRES: rc.mu is not held.
+func (rc *reconnectingConnection) record(endpoint Endpoint, err error, latency time.Duration) {
+	if rc.outliers == nil {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.recordLocked(endpoint, err, latency)
+}
+
+// recordLocked is identical to record, but it requires that rc.mu is held.
+//
+// REQUIRES: rc.mu is held.
+func (rc *reconnectingConnection) recordLocked(endpoint Endpoint, err error, latency time.Duration) {
+	if rc.outliers == nil || rc.closed {
+		return
+	}
+	if rc.outliers.record(endpoint, err, latency, time.Now()) {
+		rc.updateAvailable()
+	}
+}
+
+// updateAvailable recomputes the set of endpoints that are not ejected, and
+// passes it to the balancer.
+//
+// REQUIRES: rc.mu is held.
+func (rc *reconnectingConnection) updateAvailable() {
+	rc.available = rc.outliers.available(rc.endpoints)
+	rc.opts.Balancer.Update(rc.available)
+}
+
+// reconnect establishes (or re-establishes) the network connection to the server.
+// REQUIRES: rc.mu is not held.
 func (rc *reconnectingConnection) reconnect(ctx context.Context, endpoint Endpoint) (*clientConnection, error) {
 	nc, err := endpoint.Dial(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", CommunicationError, err)
 	}
+	if rc.opts.TLSConfig != nil {
+		tc := tls.Client(nc, rc.opts.TLSConfig)
+		hctx, cancel := context.WithTimeout(ctx, handshakeTimeout)
+		err := tc.HandshakeContext(hctx)
+		cancel()
+		if err != nil {
+			nc.Close()
+			return nil, fmt.Errorf("%w: TLS handshake: %s", CommunicationError, err)
+		}
+		nc = tc
+	}
 	conn := &clientConnection{
 		logger:   rc.opts.Logger,
 		endpoint: endpoint,
 		c:        nc,
 		cbuf:     bufio.NewReader(nc),
 		mu:       &rc.mu,
+		versions: *rc.opts.versions,
 		version:  initialVersion, // Updated when we hear from server
 		calls:    map[uint64]*call{},
 		lastID:   0,
 	}
-	if err := writeVersion(conn.c, &conn.wlock); err != nil {
+	requested := compression{rc.opts.Compression, rc.opts.CompressionThreshold}
+	if err := writeVersion(conn.c, &conn.wlock, conn.versions, requested); err != nil {
+		nc.Close()
 		return nil, fmt.Errorf("%w: client send version: %s", CommunicationError, err)
 	}
+	if err := conn.handshake(ctx); err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("%w: client read version: %s", CommunicationError, err)
+	}
 	go conn.readResponses()
 	return conn, nil
 }
 
+// handshake waits for the server to send its version, so that the client
+// knows which version of the protocol to use for its requests. It must be
+// called before c is shared with other goroutines.
+func (c *clientConnection) handshake(ctx context.Context) error {
+	deadline := time.Now().Add(handshakeTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := c.c.SetReadDeadline(deadline); err != nil {
+		return err
+	}
+	mt, id, msg, err := readMessage(c.cbuf)
+	if err != nil {
+		return err
+	}
+	if mt != versionMessage {
+		return fmt.Errorf("got message type %d, want version", mt)
+	}
+	v, err := getVersion(id, msg, c.versions)
+	if err != nil {
+		return err
+	}
+	c.version = v
+	c.compression = getCompression(v, msg)
+	return c.c.SetReadDeadline(time.Time{})
+}
+
+// getVersion returns the protocol version used by c.
+//
+// REQUIRES: c.mu is not held.
+func (c *clientConnection) getVersion() version {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.version
+}
+
+// getCompression returns the compression settings used by c.
+//
+// REQUIRES: c.mu is not held.
+func (c *clientConnection) getCompression() compression {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.compression
+}
+
+// extendHeader returns hdr, followed by the header extension for a call made
+// with ctx and opts if the server supports it.
+//
+// REQUIRES: c.mu is not held.
+func (c *clientConnection) extendHeader(ctx context.Context, hdr []byte, opts CallOptions) []byte {
+	if c.getVersion() < metadataVersion {
+		return hdr
+	}
+	return appendHeaderExtension(ctx, hdr, opts.Caller)
+}
+
+// writeRequest writes the request message of a call made with ctx. Requests
+// that are waiting to be written are written in order of the criticality of
+// their calls (see Criticality). If ctx is done while the request is waiting,
+// the request isn't written, and writeRequest returns ctx.Err().
+func (c *clientConnection) writeRequest(ctx context.Context, mt messageType, id uint64, extraHdr []byte, payload []byte, flattenLimit int) error {
+	if err := c.sendq.enter(ctx, CriticalityFromContext(ctx)); err != nil {
+		return err
+	}
+	defer c.sendq.exit()
+	return writeMessage(c.c, &c.wlock, mt, id, extraHdr, payload, flattenLimit)
+}
+
 func (c *clientConnection) endCall(rpc *call) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	delete(c.calls, rpc.id)
+	rpc.endLoad(true)
 	c.endIfDrained()
 }
 
@@ -602,11 +1189,29 @@ func (c *clientConnection) findAndEndCall(id uint64) *call {
 	rpc := c.calls[id]
 	if rpc != nil {
 		delete(c.calls, id)
+		rpc.endLoad(true)
 		c.endIfDrained()
 	}
 	return rpc
 }
 
+// endLoad informs the load balancer that picked the endpoint of rpc, if any,
+// that rpc has ended. If measured is false, or if rpc is a streaming call, the
+// duration of rpc is not reported to the balancer.
+//
+// REQUIRES: c.mu is held, where c is the clientConnection of rpc.
+func (rpc *call) endLoad(measured bool) {
+	if rpc.balancer == nil {
+		return
+	}
+	var latency time.Duration
+	if measured && rpc.stream == nil {
+		latency = time.Since(rpc.start)
+	}
+	rpc.balancer.End(rpc.endpoint, latency)
+	rpc.balancer = nil
+}
+
 // endIfDrained closes c if it is a fully drained connection.
 //
 // REQUIRES: c.mu is held.
@@ -644,6 +1249,10 @@ func (c *clientConnection) endCalls(err error) {
 	c.ended = true
 	for id, active := range c.calls {
 		active.err = err
+		active.endLoad(false)
+		if active.stream != nil {
+			active.stream.end(err)
+		}
 		atomic.StoreUint32(&active.done, 1)
 		close(active.doneSignal)
 		delete(c.calls, id)
@@ -658,16 +1267,26 @@ func (c *clientConnection) readResponses() {
 			c.shutdown("client read", err)
 			return
 		}
+		var compressedSize int
+		if mt&compressedFlag != 0 {
+			compressedSize = len(msg)
+		}
+		mt, msg, err = decompress(mt, msg)
+		if err != nil {
+			c.shutdown("client read", err)
+			return
+		}
 
 		switch mt {
 		case versionMessage:
-			v, err := getVersion(id, msg)
+			v, err := getVersion(id, msg, c.versions)
 			if err != nil {
 				c.shutdown("client read", err)
 				return
 			}
 			c.mu.Lock()
 			c.version = v
+			c.compression = getCompression(v, msg)
 			c.mu.Unlock()
 		case responseMessage, responseError:
 			rpc := c.findAndEndCall(id)
@@ -683,8 +1302,23 @@ func (c *clientConnection) readResponses() {
 			} else {
 				rpc.response = msg
 			}
+			rpc.compressedReply = compressedSize
+			if rpc.stream != nil {
+				rpc.stream.end(io.EOF)
+			}
 			atomic.StoreUint32(&rpc.done, 1)
 			close(rpc.doneSignal)
+		case streamDataMessage, streamCreditMessage:
+			c.mu.Lock()
+			rpc := c.calls[id]
+			c.mu.Unlock()
+			if rpc == nil || rpc.stream == nil {
+				continue // May have been canceled
+			}
+			if err := handleStreamMessage(rpc.stream, mt, msg); err != nil {
+				c.shutdown("client read", err)
+				return
+			}
 		default:
 			c.shutdown("client read", fmt.Errorf("invalid response %d", mt))
 			return
@@ -701,21 +1335,32 @@ func (c *serverConnection) readRequests(ctx context.Context, hmap *HandlerMap, o
 			onDone()
 			return
 		}
+		mt, msg, err = decompress(mt, msg)
+		if err != nil {
+			c.shutdown("server read", err)
+			onDone()
+			return
+		}
 
 		switch mt {
 		case versionMessage:
-			v, err := getVersion(id, msg)
+			v, err := getVersion(id, msg, *c.opts.versions)
 			if err != nil {
+				// Send my versions anyway, so that the client can report the
+				// mismatch, before closing the connection.
+				writeVersion(c.c, &c.wlock, *c.opts.versions, compression{}) //nolint:errcheck // closing anyway
 				c.shutdown("server read version", err)
 				onDone()
 				return
 			}
+			accepted := getCompression(v, msg).accept()
 			c.mu.Lock()
 			c.version = v
+			c.compression = accepted
 			c.mu.Unlock()
 
-			// Respond with my version.
-			if err := writeVersion(c.c, &c.wlock); err != nil {
+			// Respond with my versions and the accepted compression settings.
+			if err := writeVersion(c.c, &c.wlock, *c.opts.versions, accepted); err != nil {
 				c.shutdown("server send version", err)
 				onDone()
 				return
@@ -727,14 +1372,33 @@ func (c *serverConnection) readRequests(ctx context.Context, hmap *HandlerMap, o
 				t := time.AfterFunc(c.opts.InlineHandlerDuration, func() {
 					c.readRequests(ctx, hmap, onDone)
 				})
-				c.runHandler(hmap, id, msg)
+				c.runHandler(hmap, id, msg, nil)
 				if !t.Stop() {
 					// Another goroutine is reading incoming requests: bail out.
 					return
 				}
 			} else {
 				// Run the handler in a separate goroutine.
-				go c.runHandler(hmap, id, msg)
+				go c.runHandler(hmap, id, msg, nil)
+			}
+		case streamRequestMessage:
+			// Streaming handlers are never run inline, since they may wait
+			// for stream messages that are read by this goroutine. Note that
+			// the stream is registered before the handler starts, since the
+			// client may send stream messages right after the request.
+			s := c.startStream(id)
+			go c.runHandler(hmap, id, msg, s)
+		case streamDataMessage, streamCloseMessage, streamCreditMessage:
+			c.mu.Lock()
+			s := c.streams[id]
+			c.mu.Unlock()
+			if s == nil {
+				continue // The handler may have returned
+			}
+			if err := handleStreamMessage(s, mt, msg); err != nil {
+				c.shutdown("server read", err)
+				onDone()
+				return
 			}
 		case cancelMessage:
 			c.endRequest(id)
@@ -750,7 +1414,12 @@ func (c *serverConnection) readRequests(ctx context.Context, hmap *HandlerMap, o
 
 // runHandler runs an application specified RPC handler at the server side.
 // The result (or error) from the handler is sent back to the client over c.
-func (c *serverConnection) runHandler(hmap *HandlerMap, id uint64, msg []byte) {
+// s is the stream state for streaming calls, and nil for regular calls.
+func (c *serverConnection) runHandler(hmap *HandlerMap, id uint64, msg []byte, s *stream) {
+	if s != nil {
+		defer c.endStream(id)
+	}
+
 	// Extract request header from front of payload.
 	if len(msg) < msgHeaderSize {
 		c.shutdown("server handler", fmt.Errorf("missing request header"))
@@ -793,21 +1462,70 @@ func (c *serverConnection) runHandler(hmap *HandlerMap, id uint64, msg []byte) {
 		}
 	}()
 
-	// Call the handler passing it the payload.
+	// Make the client's certificate, if any, available to the handler.
+	if tc, ok := c.c.(*tls.Conn); ok {
+		if certs := tc.ConnectionState().PeerCertificates; len(certs) > 0 {
+			ctx = context.WithValue(ctx, peerCertificateKey{}, certs[0])
+		}
+	}
+
+	// Extract metadata and the caller from the header extension, if any.
 	payload := msg[msgHeaderSize:]
+	c.mu.Lock()
+	v := c.version
+	c.mu.Unlock()
+	if v >= metadataVersion {
+		var err error
+		ctx, payload, err = readHeaderExtension(ctx, payload)
+		if err != nil {
+			c.shutdown("server handler", err)
+			return
+		}
+	}
+	if span.IsRecording() {
+		span.SetAttributes(CriticalityTraceKey.String(CriticalityFromContext(ctx).String()))
+	}
+
+	// Call the handler passing it the payload.
 	var err error
 	var result []byte
-	fn, ok := hmap.handlers[hkey]
-	if !ok {
-		err = fmt.Errorf("internal error: unknown function")
+	var fn Handler
+	var ok bool
+	if s != nil {
+		var sfn StreamHandler
+		if sfn, ok = hmap.streams[hkey]; ok {
+			fn = func(ctx context.Context, args []byte) ([]byte, error) {
+				return sfn(ctx, args, &serverStream{ctx: ctx, stream: s})
+			}
+		}
 	} else {
+		fn, ok = hmap.handlers[hkey]
+	}
+	switch {
+	case !c.ss.startHandler():
+		// The server is draining. Reject the request so that the client can
+		// retry it elsewhere.
+		err = fmt.Errorf("%w: server is shutting down", Unreachable)
+	case !ok:
+		c.ss.endHandler()
+		err = fmt.Errorf("internal error: unknown function")
+	default:
+		// Note that the handler is ended after the response is written, so
+		// that a draining server doesn't close the connection too early.
+		defer c.ss.endHandler()
 		if err := c.startRequest(id, cancelFunc); err != nil {
 			logError(c.opts.Logger, "handle "+hmap.names[hkey], err)
 			return
 		}
 		cancelFunc = nil // endRequest() or cancellation will deal with it
 		defer c.endRequest(id)
-		result, err = fn(ctx, payload)
+		if limiters := hmap.limiters[hkey]; len(limiters) == 0 {
+			result, err = fn(ctx, payload)
+		} else if err = acquire(ctx, limiters); err == nil {
+			start := time.Now()
+			result, err = fn(ctx, payload)
+			release(limiters, time.Since(start))
+		}
 	}
 
 	mt := responseMessage
@@ -818,6 +1536,10 @@ func (c *serverConnection) runHandler(hmap *HandlerMap, id uint64, msg []byte) {
 		span.SetStatus(codes.Error, err.Error())
 	}
 
+	c.mu.Lock()
+	comp := c.compression
+	c.mu.Unlock()
+	mt, _, result = comp.compress(mt, nil, result)
 	if err := writeMessage(c.c, &c.wlock, mt, id, nil, result, c.opts.WriteFlattenLimit); err != nil {
 		c.shutdown("server write "+hmap.names[hkey], err)
 	}
@@ -833,6 +1555,33 @@ func (c *serverConnection) startRequest(id uint64, cancelFunc func()) error {
 	return nil
 }
 
+// startStream registers the stream state for a new streaming call.
+func (c *serverConnection) startStream(id uint64) *stream {
+	s := newStream()
+	s.write = func(mt messageType, payload []byte) error {
+		c.mu.Lock()
+		comp := c.compression
+		c.mu.Unlock()
+		mt, _, payload = comp.compress(mt, nil, payload)
+		if err := writeMessage(c.c, &c.wlock, mt, id, nil, payload, c.opts.WriteFlattenLimit); err != nil {
+			c.shutdown("server send stream", err)
+			return err
+		}
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.streams[id] = s
+	return s
+}
+
+// endStream unregisters the stream state for a streaming call.
+func (c *serverConnection) endStream(id uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.streams, id)
+}
+
 func (c *serverConnection) endRequest(id uint64) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -15,13 +15,17 @@
 package call_test
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math/rand"
 	"net"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -33,6 +37,7 @@ import (
 	"github.com/ServiceWeaver/weaver/internal/net/call"
 	"github.com/ServiceWeaver/weaver/internal/traceio"
 	"github.com/ServiceWeaver/weaver/runtime/logging"
+	"github.com/ServiceWeaver/weaver/runtime/mtls"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -46,8 +51,18 @@ var (
 	cancelWaitKey = call.MakeMethodKey("", "cancelwait")
 	sleepKey      = call.MakeMethodKey("", "sleep")
 	traceKey      = call.MakeMethodKey("", "trace")
+	countKey      = call.MakeMethodKey("", "count")
+	sumKey        = call.MakeMethodKey("", "sum")
+	holdKey       = call.MakeMethodKey("", "hold")
+	metadataKey   = call.MakeMethodKey("", "metadata")
+	callerKey     = call.MakeMethodKey("", "caller")
+	criticalKey   = call.MakeMethodKey("", "criticality")
+	peerKey       = call.MakeMethodKey("", "peer")
 	handlers      = makeHandlerMap()
 
+	// mTLS configs used by the "tls" protocol.
+	clientTLSConfig, serverTLSConfig = tlsConfigs("test")
+
 	resolverMakers = map[string]resolverMaker{
 		"Constant": func(addrs ...call.Endpoint) call.Resolver {
 			return call.NewConstantResolver(addrs...)
@@ -64,6 +79,13 @@ func makeHandlerMap() *call.HandlerMap {
 	m.Set("", "error", errorHandler)
 	m.Set("", "cancelwait", cancelWaitHandler)
 	m.Set("", "sleep", sleepHandler)
+	m.SetStream("", "count", countHandler)
+	m.SetStream("", "sum", sumHandler)
+	m.SetStream("", "hold", holdHandler)
+	m.Set("", "metadata", metadataHandler)
+	m.Set("", "caller", callerHandler)
+	m.Set("", "criticality", criticalityHandler)
+	m.Set("", "peer", peerHandler)
 	return m
 }
 
@@ -78,9 +100,43 @@ func startServers(ctx context.Context, opts call.ServerOptions) map[string]call.
 	// TODO(mwhittaker): Use test logger.
 	go call.Serve(ctx, tcpListener, handlers, opts)
 
+	// Start the server that uses the TCP protocol with mutual TLS.
+	tlsListener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		panic(err)
+	}
+	opts.TLSConfig = serverTLSConfig
+	go call.Serve(ctx, tlsListener, handlers, opts)
+
 	return map[string]call.Endpoint{
 		"tcp": call.TCP(tcpListener.Addr().String()),
+		"tls": call.TCP(tlsListener.Addr().String()),
+
+		// Clients of the "deflate" endpoint ask for compression.
+		"deflate": call.TCP(tcpListener.Addr().String()),
+	}
+}
+
+// tlsConfigs returns client and server mTLS configs for the provided
+// deployment, with credentials issued by a fresh deployment CA.
+func tlsConfigs(deployment string) (client, server *tls.Config) {
+	ca, err := mtls.NewCA(deployment)
+	if err != nil {
+		panic(err)
+	}
+	creds, err := ca.Issue("weavelet", nil)
+	if err != nil {
+		panic(err)
+	}
+	client, err = mtls.ClientConfig(deployment, creds)
+	if err != nil {
+		panic(err)
+	}
+	server, err = mtls.ServerConfig(deployment, creds)
+	if err != nil {
+		panic(err)
 	}
+	return client, server
 }
 
 const (
@@ -155,6 +211,78 @@ func sleepHandler(ctx context.Context, arg []byte) ([]byte, error) {
 	}
 }
 
+// countHandler streams the integers in the range [0, n) to the client, where
+// n is the provided argument, and returns "done".
+func countHandler(_ context.Context, arg []byte, stream call.ServerStream) ([]byte, error) {
+	n, err := strconv.Atoi(string(arg))
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < n; i++ {
+		if err := stream.Send([]byte(strconv.Itoa(i))); err != nil {
+			return nil, err
+		}
+	}
+	return []byte("done"), nil
+}
+
+// sumHandler returns the sum of the integers streamed by the client.
+func sumHandler(_ context.Context, _ []byte, stream call.ServerStream) ([]byte, error) {
+	sum := 0
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return []byte(strconv.Itoa(sum)), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		x, err := strconv.Atoi(string(msg))
+		if err != nil {
+			return nil, err
+		}
+		sum += x
+	}
+}
+
+// holdHandler never receives any of the messages streamed by the client, and
+// returns when the call is canceled.
+func holdHandler(ctx context.Context, _ []byte, _ call.ServerStream) ([]byte, error) {
+	<-ctx.Done()
+	atomic.AddInt64(&cancelCount, 1)
+	return nil, ctx.Err()
+}
+
+// metadataHandler returns the metadata attached to the call, as sorted
+// "key=value" lines.
+func metadataHandler(ctx context.Context, _ []byte) ([]byte, error) {
+	var lines []string
+	for k, v := range call.MetadataFromContext(ctx) {
+		lines = append(lines, k+"="+v)
+	}
+	sort.Strings(lines)
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+// callerHandler returns the caller of the call.
+func callerHandler(ctx context.Context, _ []byte) ([]byte, error) {
+	return []byte(call.CallerFromContext(ctx)), nil
+}
+
+// criticalityHandler returns the criticality of the call.
+func criticalityHandler(ctx context.Context, _ []byte) ([]byte, error) {
+	return []byte(call.CriticalityFromContext(ctx).String()), nil
+}
+
+// peerHandler returns the common name in the client's certificate, if any.
+func peerHandler(ctx context.Context, _ []byte) ([]byte, error) {
+	cert := call.PeerCertificateFromContext(ctx)
+	if cert == nil {
+		return nil, nil
+	}
+	return []byte(cert.Subject.CommonName), nil
+}
+
 // traceHandler returns a handler that compares the given span context
 // with the context stored in the handler
 func traceHandler(expect trace.SpanContext) call.Handler {
@@ -184,6 +312,13 @@ func getClientConn(t testing.TB, protocol string, endpoint call.Endpoint, maker
 	ctx := context.Background()
 
 	opts := call.ClientOptions{Logger: logging.NewTestLogger(t)}
+	switch protocol {
+	case "tls":
+		opts.TLSConfig = clientTLSConfig
+	case "deflate":
+		opts.Compression = call.Deflate
+		opts.CompressionThreshold = 1
+	}
 	client, err := call.Connect(ctx, maker(endpoint), opts)
 	if err != nil {
 		t.Fatalf("connect: %v", err)
@@ -267,12 +402,14 @@ type connsEndpoint struct {
 
 	mu    sync.Mutex
 	conns []net.Conn
+	dials int // number of calls to Dial
 }
 
 func (c *connsEndpoint) Dial(context.Context) (net.Conn, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	c.dials++
 	if len(c.conns) == 0 {
 		return nil, fmt.Errorf("conns used up")
 	}
@@ -298,6 +435,24 @@ func (d *deadEndpoint) Address() string {
 	return fmt.Sprintf("dead://%s", d.name)
 }
 
+// silentEndpoint is an endpoint that emulates an unresponsive server. Every
+// dial returns a fresh network connection whose server side reads everything
+// the client sends but never replies.
+type silentEndpoint struct {
+	name string
+	t    testing.TB
+}
+
+func (s *silentEndpoint) Dial(context.Context) (net.Conn, error) {
+	client, server := pipe(s.t)
+	go io.Copy(io.Discard, server)
+	return client, nil
+}
+
+func (s *silentEndpoint) Address() string {
+	return fmt.Sprintf("silent://%s", s.name)
+}
+
 // waitUntil repeatedly calls f until it returns true, with a small delay
 // between invocations. If f doesn't return true before the testTimeout is
 // reached, the test is failed.
@@ -391,6 +546,139 @@ func testConcurrentCalls(t *testing.T, client call.Connection) {
 	}
 }
 
+func testServerStream(t *testing.T, client call.Connection) {
+	// Stream enough messages to exercise flow control.
+	const n = 1000
+	stream, err := client.Stream(context.Background(), countKey, []byte(strconv.Itoa(n)), call.CallOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; ; i++ {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			if i != n {
+				t.Fatalf("received %d messages, want %d", i, n)
+			}
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(msg), strconv.Itoa(i); got != want {
+			t.Fatalf("bad message: got %q, want %q", got, want)
+		}
+	}
+	result, err := stream.Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(result), "done"; got != want {
+		t.Fatalf("bad result: got %q, want %q", got, want)
+	}
+}
+
+func testClientStream(t *testing.T, client call.Connection) {
+	const n = 1000
+	stream, err := client.Stream(context.Background(), sumKey, nil, call.CallOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		if err := stream.Send([]byte(strconv.Itoa(i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send([]byte("1")); err == nil {
+		t.Fatal("unexpected success sending on a closed stream")
+	}
+	result, err := stream.Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(result), strconv.Itoa(n*(n-1)/2); got != want {
+		t.Fatalf("bad result: got %q, want %q", got, want)
+	}
+}
+
+func testStreamFlowControl(t *testing.T, client call.Connection) {
+	// The hold handler never consumes any messages, so Send should block once
+	// the client runs out of credits, until the call is canceled. Establish a
+	// connection first, so that the stream is started before it is canceled.
+	testCall(t, client)
+	atomic.StoreInt64(&cancelCount, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), shortDelay)
+	defer cancel()
+	stream, err := client.Stream(ctx, holdKey, nil, call.CallOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const n = 1000
+	for i := 0; i < n; i++ {
+		err = stream.Send([]byte(strconv.Itoa(i)))
+		if err != nil {
+			break
+		}
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Send: got %v, want %v", err, context.DeadlineExceeded)
+	}
+	if _, err := stream.Result(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Result: got %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// The server handler should be canceled too.
+	waitUntil(t, func() bool { return atomic.LoadInt64(&cancelCount) == 1 })
+}
+
+func testMetadata(t *testing.T, client call.Connection) {
+	ctx := call.ContextWithMetadata(context.Background(), map[string]string{"a": "1", "b": "2"})
+	ctx = call.ContextWithMetadata(ctx, map[string]string{"b": "3", "c": ""})
+	result, err := client.Call(ctx, metadataKey, nil, call.CallOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(result), "a=1\nb=3\nc="; got != want {
+		t.Fatalf("bad metadata: got %q, want %q", got, want)
+	}
+
+	// Calls without metadata have no metadata.
+	result, err = client.Call(context.Background(), metadataKey, nil, call.CallOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(result); got != "" {
+		t.Fatalf("bad metadata: got %q, want none", got)
+	}
+}
+
+func testCaller(t *testing.T, client call.Connection) {
+	for _, caller := range []string{"caller", ""} {
+		result, err := client.Call(context.Background(), callerKey, nil, call.CallOptions{Caller: caller})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(result); got != caller {
+			t.Fatalf("bad caller: got %q, want %q", got, caller)
+		}
+	}
+}
+
+func testCriticality(t *testing.T, client call.Connection) {
+	for _, c := range []call.Criticality{call.Sheddable, call.DefaultCriticality, call.Critical} {
+		ctx := call.ContextWithCriticality(context.Background(), c)
+		result, err := client.Call(ctx, criticalKey, nil, call.CallOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(result), c.String(); got != want {
+			t.Fatalf("bad criticality: got %q, want %q", got, want)
+		}
+	}
+}
+
 func testError(t *testing.T, client call.Connection) {
 	const msg = "error-message"
 	_, err := client.Call(context.Background(), errorKey, []byte(msg), call.CallOptions{})
@@ -412,6 +700,10 @@ func testDeadlineHandling(t *testing.T, client call.Connection) {
 	// Test cancellation and deadline expiration.
 	for _, useDeadline := range []bool{false, true} {
 		t.Run(fmt.Sprintf("deadline=%v", useDeadline), func(t *testing.T) {
+			// Establish a connection first. A call that is canceled while
+			// the connection is being established never reaches the server.
+			testCall(t, client)
+
 			// Run with a context that will get canceled shortly.
 			ctx := context.Background()
 			if useDeadline {
@@ -435,7 +727,9 @@ func testDeadlineHandling(t *testing.T, client call.Connection) {
 func testClose(t *testing.T, client call.Connection) {
 	ctx := context.Background()
 
-	// Test that Close cancels pending calls.
+	// Test that Close cancels pending calls. Establish a connection first, so
+	// that the pending call reaches the server.
+	testCall(t, client)
 	go func() {
 		time.Sleep(shortDelay)
 		client.Close()
@@ -470,12 +764,18 @@ func TestSingleTCPServer(t *testing.T) {
 		{"TestConcurrentCalls", testConcurrentCalls},
 		{"TestError", testError},
 		{"TestDeadlineHandling", testDeadlineHandling},
+		{"TestServerStream", testServerStream},
+		{"TestClientStream", testClientStream},
+		{"TestStreamFlowControl", testStreamFlowControl},
+		{"TestMetadata", testMetadata},
+		{"TestCaller", testCaller},
+		{"TestCriticality", testCriticality},
 		// Note that testClose has to come last because once the connection is
 		// closed, all other operations will fail.
 		{"TestClose", testClose},
 	}
 
-	protocols := []string{"tcp"}
+	protocols := []string{"tcp", "tls", "deflate"}
 	ctx := context.Background()
 	opts := call.ServerOptions{Logger: logging.NewTestLogger(t)}
 	endpoints := startServers(ctx, opts)
@@ -505,6 +805,119 @@ func TestSingleTCPServer(t *testing.T) {
 	}
 }
 
+// TestTLSOtherDeployment tests that a client with credentials for one
+// deployment cannot call a server in another deployment, and vice versa.
+func TestTLSOtherDeployment(t *testing.T) {
+	ctx := context.Background()
+	endpoints := startServers(ctx, call.ServerOptions{Logger: logging.NewTestLogger(t)})
+	otherClient, otherServer := tlsConfigs("other")
+
+	// Client in another deployment.
+	opts := call.ClientOptions{Logger: logging.NewTestLogger(t), TLSConfig: otherClient}
+	checkCallFails(t, endpoints["tls"], opts)
+
+	// Server in another deployment.
+	lis, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	serveCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go call.Serve(serveCtx, lis, handlers, call.ServerOptions{Logger: logging.NewTestLogger(t), TLSConfig: otherServer})
+	opts = call.ClientOptions{Logger: logging.NewTestLogger(t), TLSConfig: clientTLSConfig}
+	checkCallFails(t, call.TCP(lis.Addr().String()), opts)
+
+	// Client without TLS.
+	opts = call.ClientOptions{Logger: logging.NewTestLogger(t)}
+	checkCallFails(t, endpoints["tls"], opts)
+}
+
+// TestCompression tests that large payloads are compressed when the client
+// asks for compression, and that small payloads are not.
+func TestCompression(t *testing.T) {
+	ctx := context.Background()
+	endpoints := startServers(ctx, call.ServerOptions{Logger: logging.NewTestLogger(t)})
+	for _, test := range []struct {
+		name        string
+		compression call.Compression
+		size        int
+		compressed  bool
+	}{
+		{"Large", call.Deflate, 10 << 10, true},
+		{"Small", call.Deflate, 100, false},
+		{"Uncompressed", call.NoCompression, 10 << 10, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			opts := call.ClientOptions{Logger: logging.NewTestLogger(t), Compression: test.compression}
+			client, err := call.Connect(ctx, call.NewConstantResolver(endpoints["tcp"]), opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+
+			arg := bytes.Repeat([]byte("compress me "), test.size/12)
+			var stats call.CallStats
+			result, err := client.Call(ctx, echoKey, arg, call.CallOptions{Stats: &stats})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(result, arg) {
+				t.Fatalf("bad result: got %d bytes, want %d", len(result), len(arg))
+			}
+			for _, n := range []int{stats.CompressedRequestBytes, stats.CompressedReplyBytes} {
+				if test.compressed && (n == 0 || n >= len(arg)) {
+					t.Errorf("got %d compressed bytes, want between 0 and %d", n, len(arg))
+				}
+				if !test.compressed && n != 0 {
+					t.Errorf("got %d compressed bytes, want 0", n)
+				}
+			}
+		})
+	}
+}
+
+// TestPeerCertificate tests that a handler sees the certificate of a client
+// that connected with TLS.
+func TestPeerCertificate(t *testing.T) {
+	ctx := context.Background()
+	endpoints := startServers(ctx, call.ServerOptions{Logger: logging.NewTestLogger(t)})
+	for _, test := range []struct {
+		protocol string
+		want     string
+	}{
+		{"tcp", ""},
+		{"tls", "weavelet"},
+	} {
+		t.Run(test.protocol, func(t *testing.T) {
+			client := getClientConn(t, test.protocol, endpoints[test.protocol], resolverMakers["Constant"])
+			defer client.Close()
+			result, err := client.Call(ctx, peerKey, nil, call.CallOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(result); got != test.want {
+				t.Fatalf("bad peer: got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+// checkCallFails checks that a call to endpoint with the provided options
+// fails.
+func checkCallFails(t *testing.T, endpoint call.Endpoint, opts call.ClientOptions) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	client, err := call.Connect(ctx, call.NewConstantResolver(endpoint), opts)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+	if _, err := client.Call(ctx, echoKey, []byte("hello"), call.CallOptions{}); err == nil {
+		t.Fatal("unexpected success")
+	}
+}
+
 // TestTracePropagation tests that the trace context is propagated across
 // an RPC
 func TestTracePropagation(t *testing.T) {
@@ -771,6 +1184,76 @@ func TestEndpointsRetained(t *testing.T) {
 	}
 }
 
+// TestSlowHandshake tests that connecting to an unresponsive server doesn't
+// block endpoint updates or calls to other servers.
+func TestSlowHandshake(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Construct the client.
+	resolver := newDynamicResolver(&silentEndpoint{name: "1", t: t})
+	copts := call.ClientOptions{Logger: logging.NewTestLogger(t)}
+	client, err := call.Connect(ctx, resolver, copts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	// Start a call that hangs while waiting for the unresponsive server to
+	// complete the handshake.
+	go client.Call(ctx, echoKey, []byte{}, call.CallOptions{})
+	time.Sleep(shortDelay)
+
+	// Replace the unresponsive server with a healthy one. Calls to the healthy
+	// server should succeed long before the pending handshake times out.
+	start := time.Now()
+	resolver.Endpoints(server(t, "2"))
+	waitUntil(t, func() bool {
+		ctx, cancel := context.WithTimeout(ctx, delaySlop)
+		defer cancel()
+		result, err := client.Call(ctx, whoKey, []byte{}, call.CallOptions{})
+		return err == nil && string(result) == "2"
+	})
+	if elapsed := time.Since(start); elapsed > delaySlop {
+		t.Fatalf("calls blocked for %v by a pending handshake", elapsed)
+	}
+}
+
+// TestSlowTLSHandshake tests that a TLS handshake with an unresponsive server
+// doesn't block endpoint updates or calls to other servers.
+func TestSlowTLSHandshake(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	endpoints := startServers(ctx, call.ServerOptions{Logger: logging.NewTestLogger(t)})
+
+	// Construct the client.
+	resolver := newDynamicResolver(&silentEndpoint{name: "1", t: t})
+	copts := call.ClientOptions{Logger: logging.NewTestLogger(t), TLSConfig: clientTLSConfig}
+	client, err := call.Connect(ctx, resolver, copts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	// Start a call that hangs in the TLS handshake with the unresponsive
+	// server.
+	go client.Call(ctx, echoKey, []byte{}, call.CallOptions{})
+	time.Sleep(shortDelay)
+
+	// Replace the unresponsive server with a healthy one.
+	start := time.Now()
+	resolver.Endpoints(endpoints["tls"])
+	waitUntil(t, func() bool {
+		ctx, cancel := context.WithTimeout(ctx, delaySlop)
+		defer cancel()
+		_, err := client.Call(ctx, echoKey, []byte{}, call.CallOptions{})
+		return err == nil
+	})
+	if elapsed := time.Since(start); elapsed > delaySlop {
+		t.Fatalf("calls blocked for %v by a pending TLS handshake", elapsed)
+	}
+}
+
 // TestDraining tests that pending RPCs on a draining connection are allowed to
 // finish. Once the RPCs have completed, the draining connection should close.
 func TestDraining(t *testing.T) {
@@ -879,6 +1362,125 @@ func TestNoActiveDraining(t *testing.T) {
 	}
 }
 
+// TestConnectionsPerEndpoint tests that concurrent calls to a server are
+// spread across ClientOptions.ConnectionsPerEndpoint connections, and that
+// the connections are drained once the server is removed.
+func TestConnectionsPerEndpoint(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	// Construct the network.
+	const numConns = 3
+	var mocks []*closeMock
+	server1 := &connsEndpoint{name: "1"}
+	for i := 0; i < numConns; i++ {
+		c, s := pipe(t)
+		sopts := call.ServerOptions{Logger: logging.NewTestLogger(t)}
+		call.ServeOn(ctx, s, handlersFor("1"), sopts)
+		m := &closeMock{connWrapper: connWrapper{c}}
+		mocks = append(mocks, m)
+		server1.conns = append(server1.conns, m)
+	}
+	server2 := server(t, "2")
+
+	// Construct the client.
+	resolver := newDynamicResolver(server1)
+	copts := call.ClientOptions{
+		Logger:                 logging.NewTestLogger(t),
+		ConnectionsPerEndpoint: numConns,
+	}
+	client, err := call.Connect(ctx, resolver, copts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// Launch more concurrent calls than there are connections. Every call
+	// should succeed, even though server 1 has no more connections to give.
+	// The calls are staggered so that the pool has time to grow; calls that
+	// arrive while a connection is being established use an existing one.
+	numCallers := 10
+	errs := make(chan error, numCallers)
+	for i := 0; i < numCallers; i++ {
+		go func() {
+			_, err := client.Call(ctx, sleepKey, []byte(delaySlop.String()), call.CallOptions{})
+			errs <- err
+		}()
+		time.Sleep(shortDelay)
+	}
+
+	// Update the endpoints from server 1 to server 2, while the calls to
+	// server 1 are still in progress.
+	resolver.Endpoints(server2)
+	for i := 0; i < numCallers; i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// Make sure every connection was used, and closed once drained.
+	server1.mu.Lock()
+	unused := len(server1.conns)
+	server1.mu.Unlock()
+	if unused != 0 {
+		t.Fatalf("%d of %d connections unused", unused, numConns)
+	}
+	for i, m := range mocks {
+		if !m.Closed() {
+			t.Fatalf("drained connection %d not closed", i)
+		}
+	}
+}
+
+// TestConnectionsPerEndpointBackoff tests that a client keeps using its
+// existing connections to a server while it fails to establish more, and that
+// it backs off between the failed attempts.
+func TestConnectionsPerEndpointBackoff(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	// Construct the network. The server only accepts one connection.
+	c, s := pipe(t)
+	sopts := call.ServerOptions{Logger: logging.NewTestLogger(t)}
+	call.ServeOn(ctx, s, handlersFor("1"), sopts)
+	server1 := &connsEndpoint{name: "1", conns: []net.Conn{c}}
+
+	// Construct the client.
+	copts := call.ClientOptions{
+		Logger:                 logging.NewTestLogger(t),
+		ConnectionsPerEndpoint: 3,
+	}
+	client, err := call.Connect(ctx, call.NewConstantResolver(server1), copts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// Launch concurrent calls. Every call should succeed on the one
+	// connection, without a dial per call.
+	numCallers := 20
+	errs := make(chan error, numCallers)
+	for i := 0; i < numCallers; i++ {
+		go func() {
+			_, err := client.Call(ctx, sleepKey, []byte((10 * shortDelay).String()), call.CallOptions{})
+			errs <- err
+		}()
+		time.Sleep(time.Millisecond)
+	}
+	for i := 0; i < numCallers; i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	server1.mu.Lock()
+	dials := server1.dials
+	server1.mu.Unlock()
+	if dials > 3 {
+		t.Fatalf("got %d dials for %d calls, want at most 3", dials, numCallers)
+	}
+}
+
 // TestCloseDraining tests that Close closes draining connections.
 func TestCloseDraining(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
@@ -1361,6 +1963,91 @@ func TestCancelServe(t *testing.T) {
 	}
 }
 
+func TestDrainServe(t *testing.T) {
+	// Check that a draining server rejects new requests but lets in-progress
+	// requests finish before Serve returns.
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	blockKey := call.MakeMethodKey("", "block")
+	h := makeHandlerMap()
+	h.Set("", "block", func(context.Context, []byte) ([]byte, error) {
+		close(started)
+		<-release
+		return []byte("done"), nil
+	})
+
+	// Run server in the background.
+	lis, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	go func() {
+		opts := call.ServerOptions{
+			Logger:       logging.NewTestLogger(t),
+			DrainTimeout: testTimeout,
+		}
+		err := call.Serve(ctx, lis, h, opts)
+		if err != ctx.Err() {
+			t.Errorf("unexpected error from Serve: %v", err)
+		}
+		close(done)
+	}()
+
+	client := getClientConn(t, "tcp", call.TCP(lis.Addr().String()), resolverMakers["Constant"])
+
+	// Start a request that blocks until released.
+	type result struct {
+		res []byte
+		err error
+	}
+	results := make(chan result, 1)
+	go func() {
+		res, err := client.Call(context.Background(), blockKey, nil, call.CallOptions{})
+		results <- result{res, err}
+	}()
+	<-started
+
+	// Cancel the server and check that new requests are eventually rejected.
+	cancelFunc()
+	for start := time.Now(); ; {
+		_, err := client.Call(context.Background(), echoKey, []byte("hello"), call.CallOptions{})
+		if errors.Is(err, call.Unreachable) {
+			break
+		}
+		if time.Since(start) > testTimeout {
+			t.Fatalf("new requests not rejected while draining: %v", err)
+		}
+		time.Sleep(shortDelay)
+	}
+
+	// The server should be waiting for the blocked request.
+	select {
+	case <-done:
+		t.Fatal("Serve returned before in-progress request finished")
+	default:
+	}
+
+	// Release the blocked request, which should succeed.
+	close(release)
+	r := <-results
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+	if got, want := string(r.res), "done"; got != want {
+		t.Fatalf("bad result: got %q, want %q", got, want)
+	}
+	select {
+	case <-done:
+		// Stopped.
+	case <-time.After(delaySlop):
+		t.Fatal("Serve did not return after draining")
+	}
+}
+
 // failResolver is a resolver with a Resolve method that always fails after the
 // first time it's called.
 type failResolver struct {
@@ -1539,3 +2226,180 @@ func sizeString(s int) string {
 	}
 	return fmt.Sprint(s)
 }
+
+// TestOutlierEjection tests that a client ejects an endpoint it can't reach.
+func TestOutlierEjection(t *testing.T) {
+	ctx := context.Background()
+	dead := &deadEndpoint{"dead"}
+	var ejections []call.Ejection
+	opts := call.ClientOptions{
+		Logger:   logging.NewTestLogger(t),
+		Outliers: call.OutlierOptions{ConsecutiveErrors: 1},
+		OnEject:  func(e call.Ejection) { ejections = append(ejections, e) },
+	}
+	client, err := call.Connect(ctx, call.NewConstantResolver(server(t, "1"), server(t, "2"), dead), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// Calls that pick the dead endpoint are sent to another endpoint.
+	for i := 0; i < 10; i++ {
+		if _, err := client.Call(ctx, whoKey, []byte{}, call.CallOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(ejections) != 1 || ejections[0].Endpoint != dead {
+		t.Fatalf("got ejections %v, want one of %s", ejections, dead.Address())
+	}
+}
+
+// TestCircuitBreaker tests that a client fails calls immediately when every
+// endpoint is unhealthy.
+func TestCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	opts := call.ClientOptions{
+		Logger: logging.NewTestLogger(t),
+		Outliers: call.OutlierOptions{
+			ConsecutiveErrors: 1,
+			EjectionTime:      100 * time.Millisecond,
+			CircuitBreaker:    true,
+		},
+	}
+	client, err := call.Connect(ctx, call.NewConstantResolver(&deadEndpoint{"dead"}), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	_, err = client.Call(ctx, whoKey, []byte{}, call.CallOptions{})
+	if err == nil || errors.Is(err, call.CircuitOpen) {
+		t.Fatalf("first call: got %v, want a communication error", err)
+	}
+	_, err = client.Call(ctx, whoKey, []byte{}, call.CallOptions{})
+	if !errors.Is(err, call.CircuitOpen) {
+		t.Fatalf("second call: got %v, want %v", err, call.CircuitOpen)
+	}
+
+	// Once the ejection time elapses, calls are attempted again.
+	time.Sleep(100 * time.Millisecond)
+	_, err = client.Call(ctx, whoKey, []byte{}, call.CallOptions{})
+	if err == nil || errors.Is(err, call.CircuitOpen) {
+		t.Fatalf("third call: got %v, want a communication error", err)
+	}
+}
+
+// TestHedgedCall tests that a hedged call that doesn't finish in time is sent
+// to a second endpoint, and that the slower copy of the call is canceled.
+func TestHedgedCall(t *testing.T) {
+	ctx := context.Background()
+	canceled := make(chan struct{}, 1)
+	handlers := makeHandlerMap()
+	handlers.Set("", "who", func(ctx context.Context, _ []byte) ([]byte, error) {
+		<-ctx.Done()
+		canceled <- struct{}{}
+		return nil, ctx.Err()
+	})
+	slow := &pipeEndpoint{name: "slow", handlers: handlers, t: t}
+
+	// The round robin balancer picks the slow endpoint first.
+	opts := call.ClientOptions{Balancer: call.RoundRobin(), Logger: logging.NewTestLogger(t)}
+	client, err := call.Connect(ctx, call.NewConstantResolver(slow, server(t, "fast")), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	var stats call.CallStats
+	result, err := client.Call(ctx, whoKey, []byte{}, call.CallOptions{HedgeDelay: 10 * time.Millisecond, Stats: &stats})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(result), "fast"; got != want {
+		t.Fatalf("result: got %q, want %q", got, want)
+	}
+	if !stats.Hedged || !stats.HedgeWon {
+		t.Fatalf("stats: got %+v, want a winning hedge", stats)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(testTimeout):
+		t.Fatal("slow call not canceled")
+	}
+
+	// A call that finishes in time is not hedged.
+	stats = call.CallStats{}
+	result, err = client.Call(ctx, echoKey, []byte("hello"), call.CallOptions{HedgeDelay: testTimeout, Stats: &stats})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(result), "hello"; got != want {
+		t.Fatalf("result: got %q, want %q", got, want)
+	}
+	if stats.Hedged {
+		t.Fatalf("stats: got %+v, want no hedge", stats)
+	}
+}
+
+// TestHedgedCallOneEndpoint tests that a hedged call with a single endpoint
+// waits for the endpoint.
+func TestHedgedCallOneEndpoint(t *testing.T) {
+	ctx := context.Background()
+	opts := call.ClientOptions{Logger: logging.NewTestLogger(t)}
+	client, err := call.Connect(ctx, call.NewConstantResolver(server(t, "1")), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	var stats call.CallStats
+	copts := call.CallOptions{HedgeDelay: time.Millisecond, Stats: &stats}
+	if _, err := client.Call(ctx, sleepKey, []byte("20ms"), copts); err != nil {
+		t.Fatal(err)
+	}
+	if stats.Hedged {
+		t.Fatalf("stats: got %+v, want no hedge", stats)
+	}
+}
+
+// TestLoadShedding tests that a server sheds the calls in excess of its
+// concurrency limit with an Overloaded error.
+func TestLoadShedding(t *testing.T) {
+	ctx := context.Background()
+	started, unblock := make(chan struct{}), make(chan struct{})
+	handlers := makeHandlerMap()
+	handlers.Set("", "block", func(context.Context, []byte) ([]byte, error) {
+		started <- struct{}{}
+		<-unblock
+		return nil, nil
+	})
+	handlers.SetLimiters("", "block", call.NewLimiter(call.LimiterOptions{MaxConcurrent: 1}))
+	endpoint := &pipeEndpoint{name: "limited", handlers: handlers, t: t}
+
+	opts := call.ClientOptions{Logger: logging.NewTestLogger(t)}
+	client, err := call.Connect(ctx, call.NewConstantResolver(endpoint), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	blockKey := call.MakeMethodKey("", "block")
+	errs := make(chan error, 1)
+	go func() {
+		_, err := client.Call(ctx, blockKey, []byte{}, call.CallOptions{})
+		errs <- err
+	}()
+	<-started
+
+	// The second call is shed, but calls to other methods are not limited.
+	if _, err := client.Call(ctx, blockKey, []byte{}, call.CallOptions{}); !errors.Is(err, call.Overloaded) {
+		t.Fatalf("Call: got %v, want %v", err, call.Overloaded)
+	}
+	if _, err := client.Call(ctx, echoKey, []byte{}, call.CallOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	close(unblock)
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+}
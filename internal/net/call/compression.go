@@ -0,0 +1,163 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"bytes"
+	"compress/flate"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// # Compression
+//
+// Starting with compressionVersion, a client can ask a server to compress the
+// payloads sent over a connection in both directions. The client sends the
+// compression algorithm and threshold it wants in its versionMessage, and the
+// server responds with the algorithm and threshold it accepts, which are then
+// used by both sides. A server that doesn't support the requested algorithm
+// responds with NoCompression.
+//
+// The payload of a requestMessage, streamRequestMessage, responseMessage,
+// responseError, or streamDataMessage that is at least as large as the
+// threshold is compressed, unless compression doesn't make it smaller. A
+// compressed message has compressedFlag set in its message type, and its
+// payload (including the request headers, if any) is the compressed
+// serialization of the original payload.
+
+// Compression identifies a compression algorithm.
+type Compression uint8
+
+const (
+	NoCompression Compression = iota // payloads are not compressed
+	Deflate                          // payloads are compressed with DEFLATE (RFC 1951)
+)
+
+// DefaultCompressionThreshold is the compression threshold used when
+// ClientOptions.CompressionThreshold is zero.
+const DefaultCompressionThreshold = 1 << 10
+
+// compressedFlag is set in the type of compressed messages.
+const compressedFlag messageType = 0x80
+
+// ParseCompression returns the compression algorithm with the provided name
+// (e.g., "deflate"). The empty string and "none" name NoCompression.
+func ParseCompression(name string) (Compression, error) {
+	switch name {
+	case "", "none":
+		return NoCompression, nil
+	case "deflate":
+		return Deflate, nil
+	default:
+		return NoCompression, fmt.Errorf("unknown compression algorithm %q", name)
+	}
+}
+
+// String returns the name of the compression algorithm.
+func (c Compression) String() string {
+	switch c {
+	case NoCompression:
+		return "none"
+	case Deflate:
+		return "deflate"
+	default:
+		return fmt.Sprintf("Compression(%d)", uint8(c))
+	}
+}
+
+// compression holds the compression settings used by a connection.
+type compression struct {
+	algorithm Compression
+	threshold int // payloads smaller than threshold are not compressed
+}
+
+// accept returns the compression settings a server uses when a client asks
+// for c.
+func (c compression) accept() compression {
+	if c.algorithm != Deflate {
+		return compression{}
+	}
+	return c
+}
+
+// compressible returns true if the payloads of messages of type mt may be
+// compressed.
+func compressible(mt messageType) bool {
+	switch mt {
+	case requestMessage, streamRequestMessage, responseMessage, responseError, streamDataMessage:
+		return true
+	default:
+		return false
+	}
+}
+
+// compress returns the message type and the payload to send for a message
+// of type mt whose payload is the concatenation of extraHdr and payload. If
+// the payload is compressed, compress returns the compressed payload as the
+// payload, and a nil extraHdr.
+func (c compression) compress(mt messageType, extraHdr, payload []byte) (messageType, []byte, []byte) {
+	n := len(extraHdr) + len(payload)
+	if c.algorithm == NoCompression || n == 0 || n < c.threshold || !compressible(mt) {
+		return mt, extraHdr, payload
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(n / 2)
+	w := flateWriters.Get().(*flate.Writer)
+	defer flateWriters.Put(w)
+	w.Reset(&buf)
+	if _, err := w.Write(extraHdr); err != nil {
+		return mt, extraHdr, payload
+	}
+	if _, err := w.Write(payload); err != nil {
+		return mt, extraHdr, payload
+	}
+	if err := w.Close(); err != nil || buf.Len() >= n {
+		// Compression failed or didn't help.
+		return mt, extraHdr, payload
+	}
+	return mt | compressedFlag, nil, buf.Bytes()
+}
+
+// flateWriters holds reusable DEFLATE compressors.
+var flateWriters = sync.Pool{
+	New: func() any {
+		w, err := flate.NewWriter(nil, flate.BestSpeed)
+		if err != nil {
+			panic(err) // only fails for a bad compression level
+		}
+		return w
+	},
+}
+
+// decompress returns the type and the payload of a message of type mt with
+// payload msg, decompressing the payload if it is compressed.
+func decompress(mt messageType, msg []byte) (messageType, []byte, error) {
+	if mt&compressedFlag == 0 {
+		return mt, msg, nil
+	}
+	mt &^= compressedFlag
+	r := flate.NewReader(bytes.NewReader(msg))
+	defer r.Close()
+	data, err := io.ReadAll(io.LimitReader(r, maxMessageSize+1))
+	if err != nil {
+		return 0, nil, fmt.Errorf("decompress message: %w", err)
+	}
+	if len(data) > maxMessageSize {
+		return 0, nil, fmt.Errorf("overly large decompressed message")
+	}
+	return mt, data, nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Criticality is the criticality of a call. The criticality of a call is
+// attached to its context, and is sent to the server in the header extension,
+// so that it propagates to the calls made by the handler.
+//
+// A client sends more critical calls first when calls queue up to be sent on
+// a connection. A server with a concurrency limit (see Limiter) sheds less
+// critical calls first: sheddable calls are shed rather than queued, and a
+// more critical call that finds the queue full takes the place of the most
+// recently queued less critical call.
+type Criticality int8
+
+const (
+	// Sheddable calls can be shed as soon as a server is overloaded.
+	Sheddable Criticality = -1
+
+	// DefaultCriticality is the criticality of calls by default.
+	DefaultCriticality Criticality = 0
+
+	// Critical calls are the last to be shed.
+	Critical Criticality = 1
+
+	// numCriticalities is the number of criticalities.
+	numCriticalities = 3
+)
+
+// String implements the fmt.Stringer interface.
+func (c Criticality) String() string {
+	switch c {
+	case Sheddable:
+		return "sheddable"
+	case DefaultCriticality:
+		return "default"
+	case Critical:
+		return "critical"
+	default:
+		return fmt.Sprintf("Criticality(%d)", int8(c))
+	}
+}
+
+// ParseCriticality parses the name of a criticality (e.g., "sheddable").
+func ParseCriticality(s string) (Criticality, error) {
+	for c := Sheddable; c <= Critical; c++ {
+		if s == c.String() {
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown criticality %q", s)
+}
+
+// CriticalityTraceKey is the trace attribute key for the criticality of a
+// call. It is attached to the server span of every traced call.
+const CriticalityTraceKey = attribute.Key("serviceweaver.criticality")
+
+// index returns the index of c in [0, numCriticalities), with more critical
+// calls having higher indices. Unknown criticalities, which may be sent by
+// newer clients, are clamped.
+func (c Criticality) index() int {
+	switch {
+	case c < Sheddable:
+		return 0
+	case c > Critical:
+		return numCriticalities - 1
+	default:
+		return int(c - Sheddable)
+	}
+}
+
+// criticalityKey is the context key for the criticality of a call.
+type criticalityKey struct{}
+
+// ContextWithCriticality returns a copy of ctx with the provided criticality
+// attached. Calls made with the returned context have the provided
+// criticality.
+func ContextWithCriticality(ctx context.Context, c Criticality) context.Context {
+	return context.WithValue(ctx, criticalityKey{}, c)
+}
+
+// CriticalityFromContext returns the criticality attached to ctx, or
+// DefaultCriticality if there is none.
+func CriticalityFromContext(ctx context.Context) Criticality {
+	c, _ := ctx.Value(criticalityKey{}).(Criticality)
+	return c
+}
+
+// sendQueue orders the goroutines that send requests on a connection by the
+// criticality of their calls. A sendQueue admits one goroutine at a time. When
+// several goroutines are waiting, the one with the most critical call is
+// admitted first, and goroutines with equally critical calls are admitted in
+// order of arrival.
+type sendQueue struct {
+	mu      sync.Mutex
+	busy    bool                              // is a goroutine admitted?
+	waiters [numCriticalities][]chan struct{} // waiting goroutines, by criticality
+}
+
+// enter waits until the calling goroutine is admitted, or until ctx is done,
+// in which case enter returns ctx.Err(). Every successful call to enter must
+// be followed by a call to exit.
+func (q *sendQueue) enter(ctx context.Context, c Criticality) error {
+	q.mu.Lock()
+	if !q.busy {
+		q.busy = true
+		q.mu.Unlock()
+		return nil
+	}
+	admitted := make(chan struct{})
+	i := c.index()
+	q.waiters[i] = append(q.waiters[i], admitted)
+	q.mu.Unlock()
+
+	select {
+	case <-admitted:
+		return nil
+	case <-ctx.Done():
+	}
+
+	// Stop waiting. If the goroutine was admitted concurrently, admit the
+	// next waiting goroutine instead.
+	q.mu.Lock()
+	for j, w := range q.waiters[i] {
+		if w == admitted {
+			q.waiters[i] = append(q.waiters[i][:j], q.waiters[i][j+1:]...)
+			q.mu.Unlock()
+			return ctx.Err()
+		}
+	}
+	q.mu.Unlock()
+	q.exit()
+	return ctx.Err()
+}
+
+// exit admits the next waiting goroutine, if any.
+func (q *sendQueue) exit() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i := numCriticalities - 1; i >= 0; i-- {
+		if len(q.waiters[i]) > 0 {
+			close(q.waiters[i][0])
+			q.waiters[i] = q.waiters[i][1:]
+			return
+		}
+	}
+	q.busy = false
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseCriticality(t *testing.T) {
+	for _, c := range []Criticality{Sheddable, DefaultCriticality, Critical} {
+		got, err := ParseCriticality(c.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c {
+			t.Fatalf("ParseCriticality(%q): got %v, want %v", c.String(), got, c)
+		}
+	}
+	if _, err := ParseCriticality("urgent"); err == nil {
+		t.Fatalf("ParseCriticality(%q): unexpected success", "urgent")
+	}
+}
+
+func TestSendQueue(t *testing.T) {
+	ctx := context.Background()
+	var q sendQueue
+	if err := q.enter(ctx, DefaultCriticality); err != nil {
+		t.Fatal(err)
+	}
+
+	// Waiting goroutines are admitted in order of criticality, and then in
+	// order of arrival.
+	order := make(chan Criticality, 4)
+	enter := func(c Criticality, waiting int) {
+		go func() {
+			if err := q.enter(ctx, c); err != nil {
+				t.Error(err)
+				return
+			}
+			order <- c
+			q.exit()
+		}()
+		waitForWaiters(t, &q, waiting)
+	}
+	enter(Sheddable, 1)
+	enter(DefaultCriticality, 2)
+	enter(Critical, 3)
+	enter(Critical+1, 4) // unknown criticalities are clamped
+	q.exit()
+
+	for _, want := range []Criticality{Critical, Critical + 1, DefaultCriticality, Sheddable} {
+		if got := <-order; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+	for start := time.Now(); time.Since(start) < time.Second; time.Sleep(time.Millisecond) {
+		q.mu.Lock()
+		busy := q.busy
+		q.mu.Unlock()
+		if !busy {
+			return
+		}
+	}
+	t.Fatal("queue busy after every goroutine exited")
+}
+
+func TestSendQueueCancel(t *testing.T) {
+	var q sendQueue
+	if err := q.enter(context.Background(), DefaultCriticality); err != nil {
+		t.Fatal(err)
+	}
+
+	// A goroutine that gives up while waiting leaves the queue.
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() { errs <- q.enter(ctx, Critical) }()
+	waitForWaiters(t, &q, 1)
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Fatalf("enter: got %v, want %v", err, context.Canceled)
+	}
+	waitForWaiters(t, &q, 0)
+
+	// The next goroutine is admitted once the queue is exited.
+	admitted := make(chan error, 1)
+	go func() { admitted <- q.enter(context.Background(), Sheddable) }()
+	waitForWaiters(t, &q, 1)
+	q.exit()
+	if err := <-admitted; err != nil {
+		t.Fatalf("enter: %v", err)
+	}
+	q.exit()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.busy {
+		t.Fatal("queue busy after every goroutine exited")
+	}
+}
+
+// waitForWaiters waits until n goroutines are waiting in q.
+func waitForWaiters(t *testing.T, q *sendQueue, n int) {
+	t.Helper()
+	for start := time.Now(); time.Since(start) < time.Second; time.Sleep(time.Millisecond) {
+		q.mu.Lock()
+		waiting := 0
+		for _, w := range q.waiters {
+			waiting += len(w)
+		}
+		q.mu.Unlock()
+		if waiting == n {
+			return
+		}
+	}
+	t.Fatalf("timed out waiting for %d waiting goroutines", n)
+}
@@ -94,3 +94,40 @@ func ParseNetEndpoint(endpoint string) (NetEndpoint, error) {
 	}
 	return NetEndpoint{Net: net, Addr: addr}, nil
 }
+
+// PreferLocal returns an endpoint that dials local, typically a Unix socket
+// endpoint for a server on the same machine, and falls back to dialing
+// endpoint if local can't be dialed. The returned endpoint has the address of
+// endpoint, so that it is interchangeable with endpoint for the purposes of
+// balancing and routing. For example:
+//
+//	PreferLocal(TCP("localhost:8000"), Unix("/tmp/unix.sock"))
+func PreferLocal(endpoint, local Endpoint) Endpoint {
+	return preferLocalEndpoint{endpoint: endpoint, local: local}
+}
+
+// preferLocalEndpoint is the Endpoint returned by PreferLocal.
+type preferLocalEndpoint struct {
+	endpoint Endpoint
+	local    Endpoint
+}
+
+// Check that preferLocalEndpoint implements the Endpoint interface.
+var _ Endpoint = preferLocalEndpoint{}
+
+// Dial implements the Endpoint interface.
+func (e preferLocalEndpoint) Dial(ctx context.Context) (net.Conn, error) {
+	if conn, err := e.local.Dial(ctx); err == nil {
+		return conn, nil
+	}
+	return e.endpoint.Dial(ctx)
+}
+
+// Address implements the Endpoint interface.
+func (e preferLocalEndpoint) Address() string {
+	return e.endpoint.Address()
+}
+
+func (e preferLocalEndpoint) String() string {
+	return e.endpoint.Address()
+}
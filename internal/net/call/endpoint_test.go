@@ -15,6 +15,9 @@
 package call_test
 
 import (
+	"context"
+	"net"
+	"path/filepath"
 	"testing"
 
 	"github.com/ServiceWeaver/weaver/internal/net/call"
@@ -54,3 +57,43 @@ func TestParseNetEndpointError(t *testing.T) {
 		t.Fatal("expected an error parsing invalid endpoint")
 	}
 }
+
+func TestPreferLocal(t *testing.T) {
+	ctx := context.Background()
+	tcp, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tcp.Close()
+	socket := filepath.Join(t.TempDir(), "unix.sock")
+	unix, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The endpoint has the address of the TCP endpoint, but dials the Unix
+	// socket while it is available...
+	endpoint := call.PreferLocal(call.TCP(tcp.Addr().String()), call.Unix(socket))
+	if got, want := endpoint.Address(), "tcp://"+tcp.Addr().String(); got != want {
+		t.Fatalf("Address: got %q, want %q", got, want)
+	}
+	conn, err := endpoint.Dial(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+	if got, want := conn.RemoteAddr().Network(), "unix"; got != want {
+		t.Fatalf("Dial: got a %s connection, want %s", got, want)
+	}
+
+	// ...and falls back to the TCP endpoint once it isn't.
+	unix.Close()
+	conn, err = endpoint.Dial(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+	if got, want := conn.RemoteAddr().Network(), "tcp"; got != want {
+		t.Fatalf("Dial: got a %s connection, want %s", got, want)
+	}
+}
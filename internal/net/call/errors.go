@@ -32,6 +32,18 @@ const (
 	// server is unreachable. Check for it via errors.Is(call.Unreachable).
 	Unreachable
 
+	// CircuitOpen is the type of the error returned by a call when the
+	// client's circuit breaker is open because every server is unhealthy.
+	// Check for it via errors.Is(call.CircuitOpen).
+	CircuitOpen
+
+	// Overloaded is the type of the error returned by a call when the server
+	// sheds the call because it has too many concurrent calls. The call's
+	// handler is never run, so the call can safely be retried, preferably on
+	// another server after a backoff. Check for it via
+	// errors.Is(call.Overloaded).
+	Overloaded
+
 	// TODO: Decide what error most applications will want to check for. We may
 	// need to combine CommunicationError and Unreachable. We may also want to
 	// make errors.Is(CommunicationError) return true for both types of errors.
@@ -45,6 +57,10 @@ func (e transportError) Error() string {
 		return "communication error"
 	case Unreachable:
 		return "unreachable"
+	case CircuitOpen:
+		return "circuit breaker open"
+	case Overloaded:
+		return "server overloaded"
 	default:
 		return fmt.Sprintf("unknown error %d", e)
 	}
@@ -37,11 +37,18 @@ func MakeMethodKey(component, method string) MethodKey {
 // successfully.
 type Handler func(ctx context.Context, args []byte) ([]byte, error)
 
+// StreamHandler is a function that handles streaming remote procedure calls.
+// Besides the arguments, a StreamHandler receives a stream that it can use to
+// exchange stream messages with the client while the call is in progress.
+type StreamHandler func(ctx context.Context, args []byte, stream ServerStream) ([]byte, error)
+
 // HandlerMap is a mapping from MethodID to a Handler. The zero value for a
 // HandlerMap is an empty map.
 type HandlerMap struct {
 	handlers map[MethodKey]Handler
+	streams  map[MethodKey]StreamHandler
 	names    map[MethodKey]string
+	limiters map[MethodKey][]*Limiter
 }
 
 // Set registers a handler for the specified method of component.
@@ -54,3 +61,27 @@ func (hm *HandlerMap) Set(component, method string, handler Handler) {
 	hm.handlers[fp] = handler
 	hm.names[fp] = component + "." + method
 }
+
+// SetStream registers a streaming handler for the specified method of
+// component.
+func (hm *HandlerMap) SetStream(component, method string, handler StreamHandler) {
+	if hm.streams == nil {
+		hm.streams = map[MethodKey]StreamHandler{}
+	}
+	if hm.names == nil {
+		hm.names = map[MethodKey]string{}
+	}
+	fp := MakeMethodKey(component, method)
+	hm.streams[fp] = handler
+	hm.names[fp] = component + "." + method
+}
+
+// SetLimiters sets the limiters that limit the number of concurrent calls to
+// the specified method of component, regular and streaming alike. A call runs
+// once every limiter admits it. A limiter can be shared by multiple methods.
+func (hm *HandlerMap) SetLimiters(component, method string, limiters ...*Limiter) {
+	if hm.limiters == nil {
+		hm.limiters = map[MethodKey][]*Limiter{}
+	}
+	hm.limiters[MakeMethodKey(component, method)] = limiters
+}
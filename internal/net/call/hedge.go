@@ -0,0 +1,99 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"context"
+	"time"
+)
+
+// # Hedging
+//
+// A hedged call is first sent to a single endpoint, like any other call. If
+// the call hasn't finished after CallOptions.HedgeDelay, a second copy of the
+// call, the hedge, is sent to an endpoint picked by the balancer, other than
+// the endpoint of the first copy. The result of the first copy to finish is
+// returned, and the other copy is canceled with a cancel message.
+//
+// If the first copy to finish failed to reach its endpoint, the result of the
+// other copy is returned instead. If there is no other endpoint to send the
+// hedge to, the call waits for the first copy. The outcome of a canceled copy
+// is not recorded for outlier detection, since the copy neither succeeded nor
+// failed.
+
+// hedgedCall makes a hedged call. See CallOptions.HedgeDelay.
+//
+// REQUIRES: rc.mu is not held.
+func (rc *reconnectingConnection) hedgedCall(ctx context.Context, h MethodKey, arg []byte, opts CallOptions) ([]byte, error) {
+	first, err := rc.send(ctx, h, arg, opts, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(opts.HedgeDelay)
+	defer timer.Stop()
+	select {
+	case <-first.rpc.doneSignal:
+		return rc.finish(first, opts)
+	case <-ctx.Done():
+		return rc.abandon(ctx, first)
+	case <-timer.C:
+	}
+
+	// The call is taking too long. Send the hedge.
+	second, err := rc.send(ctx, h, arg, opts, first.conn.endpoint)
+	if err != nil {
+		return rc.await(ctx, first, opts)
+	}
+	if opts.Stats != nil {
+		opts.Stats.Hedged = true
+	}
+
+	winner, loser := first, second
+	select {
+	case <-first.rpc.doneSignal:
+	case <-second.rpc.doneSignal:
+		winner, loser = second, first
+	case <-ctx.Done():
+		rc.cancel(ctx, second)
+		return rc.abandon(ctx, first)
+	}
+	if failed(winner.rpc.err) {
+		// The winner failed to reach its endpoint, but the loser may not.
+		rc.finish(winner, opts)
+		if opts.Stats != nil {
+			opts.Stats.HedgeWon = loser == second
+		}
+		return rc.await(ctx, loser, opts)
+	}
+	rc.cancel(ctx, loser)
+	if opts.Stats != nil {
+		opts.Stats.HedgeWon = winner == second
+	}
+	return rc.finish(winner, opts)
+}
+
+// await waits for a call to finish and returns its result. If ctx is done
+// first, the call is abandoned.
+//
+// REQUIRES: rc.mu is not held.
+func (rc *reconnectingConnection) await(ctx context.Context, a *attempt, opts CallOptions) ([]byte, error) {
+	select {
+	case <-a.rpc.doneSignal:
+		return rc.finish(a, opts)
+	case <-ctx.Done():
+		return rc.abandon(ctx, a)
+	}
+}
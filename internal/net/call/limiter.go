@@ -0,0 +1,250 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// # Load Shedding
+//
+// A server can limit the number of calls that run concurrently, either for a
+// single method or for a set of methods (e.g., all the methods of a
+// component), by registering one or more Limiters with the methods' handlers
+// (see HandlerMap.SetLimiters). A call runs once every one of its limiters
+// admits it. A call in excess of a limit waits in the limiter's bounded queue
+// until another call finishes. A call in excess of the queue is shed: it is
+// rejected immediately with an Overloaded error, without running its handler.
+//
+// Limiters shed less critical calls first (see Criticality). A sheddable call
+// in excess of a limit is shed rather than queued. Queued calls are admitted
+// in order of criticality, and then in order of arrival. A call that finds the
+// queue full takes the place of the most recently queued less critical call,
+// if any, which is shed instead.
+//
+// An adaptive limiter adjusts its limit to the latency of the calls it admits,
+// in the style of a gradient concurrency limiter. It tracks a long-term and a
+// short-term moving average latency. While the short-term latency is close to
+// the long-term latency, the limit grows, up to the configured maximum. When
+// the short-term latency rises, which indicates that calls are queueing up
+// inside the server, the limit shrinks in proportion.
+
+// LimiterOptions configures a Limiter.
+type LimiterOptions struct {
+	// MaxConcurrent is the maximum number of calls that run concurrently. If
+	// Adaptive is true, MaxConcurrent is the maximum of the adaptive limit.
+	MaxConcurrent int
+
+	// MaxQueued is the maximum number of calls that wait for another call to
+	// finish. Calls in excess of MaxQueued are rejected with an Overloaded
+	// error. If zero, calls in excess of the limit are rejected immediately.
+	// Sheddable calls are never queued.
+	MaxQueued int
+
+	// Adaptive, if true, adapts the limit to the latency of calls.
+	Adaptive bool
+}
+
+const (
+	// Number of calls in the short-term and long-term moving average latency
+	// tracked by an adaptive Limiter.
+	shortLatencyWindow = 10
+	longLatencyWindow  = 600
+
+	// latencyTolerance is the ratio between the short-term and the long-term
+	// latencies that an adaptive Limiter tolerates before shrinking its limit.
+	latencyTolerance = 1.5
+
+	// limitSmoothing is the weight of a new limit computed by an adaptive
+	// Limiter, relative to its current limit.
+	limitSmoothing = 0.2
+)
+
+// A Limiter limits the number of calls that run concurrently. A Limiter is
+// safe for concurrent use.
+type Limiter struct {
+	opts LimiterOptions
+
+	mu      sync.Mutex
+	limit   float64                     // current limit
+	running int                         // number of running calls
+	queue   [numCriticalities][]*waiter // waiting calls, by criticality
+	queued  int                         // number of waiting calls
+
+	// Moving average latencies, in nanoseconds, of an adaptive Limiter.
+	short, long float64
+	samples     int
+}
+
+// waiter is a call waiting in the queue of a Limiter.
+type waiter struct {
+	done chan struct{} // closed when the call is admitted or shed
+	err  error         // if not nil, the call was shed
+}
+
+// NewLimiter returns a new Limiter.
+//
+// REQUIRES: opts.MaxConcurrent > 0 and opts.MaxQueued >= 0.
+func NewLimiter(opts LimiterOptions) *Limiter {
+	return &Limiter{opts: opts, limit: float64(opts.MaxConcurrent)}
+}
+
+// Limit returns the current limit on the number of concurrent calls.
+func (l *Limiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.limit)
+}
+
+// acquire waits until a call can run, and returns an error if the call is
+// shed or if ctx is done first. Every successful call to acquire must be
+// followed by a call to release.
+func (l *Limiter) acquire(ctx context.Context) error {
+	c := CriticalityFromContext(ctx)
+	l.mu.Lock()
+	if l.queued == 0 && l.running < int(l.limit) {
+		l.running++
+		l.mu.Unlock()
+		return nil
+	}
+	if c <= Sheddable {
+		l.mu.Unlock()
+		return fmt.Errorf("%w: too many concurrent calls to queue sheddable call", Overloaded)
+	}
+	if l.queued >= l.opts.MaxQueued && !l.evict(c) {
+		l.mu.Unlock()
+		return fmt.Errorf("%w: too many concurrent calls", Overloaded)
+	}
+	w := &waiter{done: make(chan struct{})}
+	i := c.index()
+	l.queue[i] = append(l.queue[i], w)
+	l.queued++
+	l.mu.Unlock()
+
+	select {
+	case <-w.done:
+		return w.err
+	case <-ctx.Done():
+	}
+
+	l.mu.Lock()
+	for j, other := range l.queue[i] {
+		if other == w {
+			l.queue[i] = append(l.queue[i][:j], l.queue[i][j+1:]...)
+			l.queued--
+			l.mu.Unlock()
+			return ctx.Err()
+		}
+	}
+	shed := w.err != nil
+	l.mu.Unlock()
+
+	if !shed {
+		// The call was admitted while ctx was done. Pass its turn on.
+		l.release(0)
+	}
+	return ctx.Err()
+}
+
+// evict sheds the most recently queued call that is less critical than c, if
+// any, and returns true if a call was shed.
+//
+// REQUIRES: l.mu is held.
+func (l *Limiter) evict(c Criticality) bool {
+	for i := 0; i < c.index(); i++ {
+		if n := len(l.queue[i]); n > 0 {
+			w := l.queue[i][n-1]
+			l.queue[i] = l.queue[i][:n-1]
+			l.queued--
+			w.err = fmt.Errorf("%w: too many concurrent calls, shed for a more critical call", Overloaded)
+			close(w.done)
+			return true
+		}
+	}
+	return false
+}
+
+// release records the end of a call admitted by acquire. latency is the
+// duration of the call, or zero if unknown.
+func (l *Limiter) release(latency time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.opts.Adaptive && latency > 0 {
+		l.adapt(latency)
+	}
+	l.running--
+	for i := numCriticalities - 1; i >= 0 && l.running < int(l.limit); i-- {
+		for len(l.queue[i]) > 0 && l.running < int(l.limit) {
+			close(l.queue[i][0].done)
+			l.queue[i] = l.queue[i][1:]
+			l.queued--
+			l.running++
+		}
+	}
+}
+
+// acquire waits until every one of the provided limiters admits a call. If a
+// limiter sheds the call or ctx is done first, acquire returns an error and
+// the call is not admitted by any limiter. Every successful call to acquire
+// must be followed by a call to release.
+func acquire(ctx context.Context, limiters []*Limiter) error {
+	for i, l := range limiters {
+		if err := l.acquire(ctx); err != nil {
+			release(limiters[:i], 0)
+			return err
+		}
+	}
+	return nil
+}
+
+// release records the end of a call admitted by acquire. latency is the
+// duration of the call, or zero if unknown.
+func release(limiters []*Limiter, latency time.Duration) {
+	for _, l := range limiters {
+		l.release(latency)
+	}
+}
+
+// adapt adapts the limit to the latency of a call.
+//
+// REQUIRES: l.mu is held.
+func (l *Limiter) adapt(latency time.Duration) {
+	x := float64(latency)
+	l.samples++
+	if l.samples == 1 {
+		l.short, l.long = x, x
+	} else {
+		l.short += (x - l.short) * 2 / (shortLatencyWindow + 1)
+		l.long += (x - l.long) * 2 / (longLatencyWindow + 1)
+	}
+	if l.long > 2*l.short {
+		// The long-term latency lags far behind a drop in latency. Let it
+		// catch up faster.
+		l.long *= 0.95
+	}
+	if float64(l.running) < l.limit/2 {
+		// The limiter is not saturated, so latency doesn't reflect the limit.
+		return
+	}
+
+	gradient := math.Max(0.5, math.Min(1, latencyTolerance*l.long/l.short))
+	limit := l.limit*gradient + math.Sqrt(l.limit)
+	limit = l.limit*(1-limitSmoothing) + limit*limitSmoothing
+	l.limit = math.Max(1, math.Min(float64(l.opts.MaxConcurrent), limit))
+}
@@ -0,0 +1,232 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// acquireAsync calls l.acquire in a new goroutine, and returns a channel that
+// receives its result.
+func acquireAsync(ctx context.Context, l *Limiter) chan error {
+	errs := make(chan error, 1)
+	go func() { errs <- l.acquire(ctx) }()
+	return errs
+}
+
+// waitQueued waits until l has n queued calls.
+func waitQueued(t *testing.T, l *Limiter, n int) {
+	t.Helper()
+	for start := time.Now(); time.Since(start) < time.Second; time.Sleep(time.Millisecond) {
+		l.mu.Lock()
+		queued := l.queued
+		l.mu.Unlock()
+		if queued == n {
+			return
+		}
+	}
+	t.Fatalf("timed out waiting for %d queued calls", n)
+}
+
+func TestLimiterQueue(t *testing.T) {
+	ctx := context.Background()
+	l := NewLimiter(LimiterOptions{MaxConcurrent: 1, MaxQueued: 1})
+	if err := l.acquire(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// The second call waits, and the third call is shed.
+	second := acquireAsync(ctx, l)
+	waitQueued(t, l, 1)
+	if err := l.acquire(ctx); !errors.Is(err, Overloaded) {
+		t.Fatalf("acquire: got %v, want %v", err, Overloaded)
+	}
+
+	// The second call runs once the first call ends.
+	l.release(time.Millisecond)
+	if err := <-second; err != nil {
+		t.Fatal(err)
+	}
+	l.release(time.Millisecond)
+	if l.running != 0 {
+		t.Fatalf("running calls: got %d, want 0", l.running)
+	}
+}
+
+func TestLimiterCancel(t *testing.T) {
+	l := NewLimiter(LimiterOptions{MaxConcurrent: 1, MaxQueued: 1})
+	if err := l.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// A waiting call whose context is canceled leaves the queue.
+	ctx, cancel := context.WithCancel(context.Background())
+	waiting := acquireAsync(ctx, l)
+	waitQueued(t, l, 1)
+	cancel()
+	if err := <-waiting; !errors.Is(err, context.Canceled) {
+		t.Fatalf("acquire: got %v, want %v", err, context.Canceled)
+	}
+	waitQueued(t, l, 0)
+	l.release(0)
+	if l.running != 0 {
+		t.Fatalf("running calls: got %d, want 0", l.running)
+	}
+}
+
+func TestLimiterCriticality(t *testing.T) {
+	ctx := context.Background()
+	sheddable := ContextWithCriticality(ctx, Sheddable)
+	critical := ContextWithCriticality(ctx, Critical)
+	l := NewLimiter(LimiterOptions{MaxConcurrent: 1, MaxQueued: 2})
+	if err := l.acquire(sheddable); err != nil {
+		t.Fatal(err)
+	}
+
+	// A sheddable call in excess of the limit is shed rather than queued.
+	if err := l.acquire(sheddable); !errors.Is(err, Overloaded) {
+		t.Fatalf("acquire sheddable: got %v, want %v", err, Overloaded)
+	}
+
+	// A critical call that finds the queue full takes the place of the most
+	// recently queued default call.
+	first := acquireAsync(ctx, l)
+	waitQueued(t, l, 1)
+	second := acquireAsync(ctx, l)
+	waitQueued(t, l, 2)
+	third := acquireAsync(critical, l)
+	if err := <-second; !errors.Is(err, Overloaded) {
+		t.Fatalf("acquire default: got %v, want %v", err, Overloaded)
+	}
+	fourth := acquireAsync(critical, l)
+	if err := <-first; !errors.Is(err, Overloaded) {
+		t.Fatalf("acquire default: got %v, want %v", err, Overloaded)
+	}
+
+	// A critical call that finds the queue full of critical calls is shed.
+	if err := l.acquire(critical); !errors.Is(err, Overloaded) {
+		t.Fatalf("acquire critical: got %v, want %v", err, Overloaded)
+	}
+
+	// Queued calls run in order of arrival.
+	l.release(time.Millisecond)
+	if err := <-third; err != nil {
+		t.Fatal(err)
+	}
+	l.release(time.Millisecond)
+	if err := <-fourth; err != nil {
+		t.Fatal(err)
+	}
+	l.release(time.Millisecond)
+	if l.running != 0 || l.queued != 0 {
+		t.Fatalf("got %d running and %d queued calls, want 0", l.running, l.queued)
+	}
+}
+
+func TestLimiterPriority(t *testing.T) {
+	ctx := context.Background()
+	l := NewLimiter(LimiterOptions{MaxConcurrent: 1, MaxQueued: 2})
+	if err := l.acquire(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// A queued critical call runs before a default call queued earlier.
+	def := acquireAsync(ctx, l)
+	waitQueued(t, l, 1)
+	critical := acquireAsync(ContextWithCriticality(ctx, Critical), l)
+	waitQueued(t, l, 2)
+	l.release(time.Millisecond)
+	if err := <-critical; err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-def:
+		t.Fatalf("default call admitted before the critical call ended: %v", err)
+	default:
+	}
+	l.release(time.Millisecond)
+	if err := <-def; err != nil {
+		t.Fatal(err)
+	}
+	l.release(time.Millisecond)
+}
+
+func TestAcquireMany(t *testing.T) {
+	ctx := context.Background()
+	a := NewLimiter(LimiterOptions{MaxConcurrent: 2})
+	b := NewLimiter(LimiterOptions{MaxConcurrent: 1})
+	if err := acquire(ctx, []*Limiter{a, b}); err != nil {
+		t.Fatal(err)
+	}
+
+	// b sheds the call, so a doesn't admit it either.
+	if err := acquire(ctx, []*Limiter{a, b}); !errors.Is(err, Overloaded) {
+		t.Fatalf("acquire: got %v, want %v", err, Overloaded)
+	}
+	if a.running != 1 {
+		t.Fatalf("running calls: got %d, want 1", a.running)
+	}
+	release([]*Limiter{a, b}, time.Millisecond)
+	if a.running != 0 || b.running != 0 {
+		t.Fatalf("running calls: got %d and %d, want 0", a.running, b.running)
+	}
+}
+
+func TestAdaptiveLimiter(t *testing.T) {
+	const max = 100
+	l := NewLimiter(LimiterOptions{MaxConcurrent: max, Adaptive: true})
+
+	// adapt adapts the limit to n calls with the provided latency, while the
+	// limiter is saturated.
+	adapt := func(n int, latency time.Duration) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		for i := 0; i < n; i++ {
+			l.running = int(l.limit)
+			l.adapt(latency)
+		}
+		l.running = 0
+	}
+
+	// The limit stays at its maximum while latency is stable.
+	adapt(longLatencyWindow, time.Millisecond)
+	if got := l.Limit(); got != max {
+		t.Fatalf("limit with stable latency: got %d, want %d", got, max)
+	}
+
+	// The limit shrinks when latency rises...
+	adapt(shortLatencyWindow, 10*time.Millisecond)
+	if got := l.Limit(); got >= max {
+		t.Fatalf("limit with rising latency: got %d, want < %d", got, max)
+	}
+
+	// ...but not while the limiter isn't saturated...
+	limit := l.Limit()
+	l.mu.Lock()
+	l.adapt(100 * time.Millisecond)
+	l.mu.Unlock()
+	if got := l.Limit(); got != limit {
+		t.Fatalf("limit of unsaturated limiter: got %d, want %d", got, limit)
+	}
+
+	// ...and grows back when latency falls.
+	adapt(longLatencyWindow, time.Millisecond)
+	if got := l.Limit(); got != max {
+		t.Fatalf("limit with falling latency: got %d, want %d", got, max)
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// latencyDecay is the time constant with which the latency tracked for an
+// endpoint decays. The latency of an endpoint that hasn't answered a call in a
+// while decays towards zero, so that a balancer eventually retries an
+// endpoint that was slow in the past.
+const latencyDecay = 10 * time.Second
+
+// A LoadBalancer is a Balancer that picks endpoints based on their load. A
+// client informs a LoadBalancer of the start and end of every call made to an
+// endpoint picked by the LoadBalancer. Like a Balancer, a LoadBalancer should
+// only be used by a single goroutine.
+type LoadBalancer interface {
+	Balancer
+
+	// Begin is called when a call is sent to an endpoint picked by the
+	// balancer.
+	Begin(endpoint Endpoint)
+
+	// End is called when a call passed to Begin ends. latency is the
+	// duration of the call, or zero if the duration of the call doesn't
+	// reflect the latency of the endpoint (e.g., for streaming calls and
+	// calls that failed to reach the endpoint).
+	End(endpoint Endpoint, latency time.Duration)
+
+	// Load returns the balancer's view of the load of the provided endpoint.
+	Load(endpoint Endpoint) Load
+}
+
+// Load is the load of an endpoint, as tracked by a LoadBalancer.
+type Load struct {
+	Outstanding int           // number of outstanding calls
+	Latency     time.Duration // exponentially weighted moving average latency
+}
+
+// NewBalancer returns a new balancer of the provided kind:
+//
+//   - "round_robin" (or ""): see RoundRobin.
+//   - "least_loaded": see LeastLoaded.
+//   - "power_of_two_choices": see PowerOfTwoChoices.
+//   - "least_latency": see LeastLatency.
+func NewBalancer(kind string) (Balancer, error) {
+	switch kind {
+	case "", "round_robin":
+		return RoundRobin(), nil
+	case "least_loaded":
+		return LeastLoaded(), nil
+	case "power_of_two_choices":
+		return PowerOfTwoChoices(), nil
+	case "least_latency":
+		return LeastLatency(), nil
+	default:
+		return nil, fmt.Errorf("unknown balancer %q", kind)
+	}
+}
+
+// LeastLoaded returns a balancer that picks the endpoint with the fewest
+// outstanding calls. Ties are broken randomly.
+func LeastLoaded() LoadBalancer {
+	return &loadBalancer{cost: func(l *endpointLoad, _ time.Time) float64 {
+		return float64(l.outstanding)
+	}}
+}
+
+// PowerOfTwoChoices returns a balancer that picks two endpoints at random and
+// picks the one of the two with the fewest outstanding calls.
+func PowerOfTwoChoices() LoadBalancer {
+	return &loadBalancer{
+		twoChoices: true,
+		cost: func(l *endpointLoad, _ time.Time) float64 {
+			return float64(l.outstanding)
+		},
+	}
+}
+
+// LeastLatency returns a balancer that picks the endpoint with the lowest
+// exponentially weighted moving average latency, weighted by the number of
+// outstanding calls to the endpoint. Ties are broken randomly.
+func LeastLatency() LoadBalancer {
+	return &loadBalancer{cost: func(l *endpointLoad, now time.Time) float64 {
+		// Endpoints that haven't answered any calls yet have a latency of
+		// zero. We add a microsecond to every latency so that calls to such
+		// endpoints are still balanced by their number of outstanding calls.
+		return float64(l.latency(now)+time.Microsecond) * float64(l.outstanding+1)
+	}}
+}
+
+// loadBalancer is the implementation of the balancers returned by
+// LeastLoaded, PowerOfTwoChoices, and LeastLatency.
+type loadBalancer struct {
+	// If twoChoices is true, Pick picks the cheaper of two endpoints chosen
+	// at random. Otherwise, Pick picks the cheapest of all endpoints.
+	twoChoices bool
+	cost       func(*endpointLoad, time.Time) float64
+
+	endpoints []Endpoint
+	loads     map[string]*endpointLoad // keyed by endpoint address
+}
+
+var _ LoadBalancer = &loadBalancer{}
+
+// endpointLoad is the load of an endpoint tracked by a loadBalancer.
+type endpointLoad struct {
+	outstanding int           // number of outstanding calls
+	ewma        time.Duration // moving average latency, as of updated
+	updated     time.Time     // when ewma was last updated
+}
+
+// latency returns the moving average latency of the endpoint, decayed to the
+// provided time.
+func (l *endpointLoad) latency(now time.Time) time.Duration {
+	if l.updated.IsZero() {
+		return 0
+	}
+	elapsed := now.Sub(l.updated)
+	return time.Duration(float64(l.ewma) * math.Exp(-float64(elapsed)/float64(latencyDecay)))
+}
+
+// record records a call with the provided latency.
+func (l *endpointLoad) record(latency time.Duration, now time.Time) {
+	if l.updated.IsZero() {
+		l.ewma = latency
+		l.updated = now
+		return
+	}
+	// The weight of the previous average decays with the time since it was
+	// last updated, so that the average reflects recent calls.
+	elapsed := now.Sub(l.updated)
+	w := math.Exp(-float64(elapsed) / float64(latencyDecay))
+	l.ewma = time.Duration(w*float64(l.ewma) + (1-w)*float64(latency))
+	l.updated = now
+}
+
+// Update implements the Balancer interface.
+func (lb *loadBalancer) Update(endpoints []Endpoint) {
+	// Retain the load of the existing endpoints.
+	loads := make(map[string]*endpointLoad, len(endpoints))
+	for _, endpoint := range endpoints {
+		addr := endpoint.Address()
+		if l, ok := lb.loads[addr]; ok {
+			loads[addr] = l
+		} else {
+			loads[addr] = &endpointLoad{}
+		}
+	}
+	lb.endpoints = endpoints
+	lb.loads = loads
+}
+
+// Pick implements the Balancer interface.
+func (lb *loadBalancer) Pick(CallOptions) (Endpoint, error) {
+	n := len(lb.endpoints)
+	if n == 0 {
+		return nil, fmt.Errorf("%w: no endpoints available", Unreachable)
+	}
+
+	now := time.Now()
+	if lb.twoChoices && n > 1 {
+		// Pick the cheaper of two distinct endpoints chosen at random.
+		i := rand.Intn(n)
+		j := rand.Intn(n - 1)
+		if j >= i {
+			j++
+		}
+		if lb.cost(lb.loads[lb.endpoints[j].Address()], now) < lb.cost(lb.loads[lb.endpoints[i].Address()], now) {
+			i = j
+		}
+		return lb.endpoints[i], nil
+	}
+
+	// Pick the cheapest of all endpoints, starting at a random offset so that
+	// ties are broken randomly.
+	start := rand.Intn(n)
+	best, bestCost := -1, 0.0
+	for j := 0; j < n; j++ {
+		i := (start + j) % n
+		if cost := lb.cost(lb.loads[lb.endpoints[i].Address()], now); best == -1 || cost < bestCost {
+			best, bestCost = i, cost
+		}
+	}
+	return lb.endpoints[best], nil
+}
+
+// Begin implements the LoadBalancer interface.
+func (lb *loadBalancer) Begin(endpoint Endpoint) {
+	if l, ok := lb.loads[endpoint.Address()]; ok {
+		l.outstanding++
+	}
+}
+
+// End implements the LoadBalancer interface.
+func (lb *loadBalancer) End(endpoint Endpoint, latency time.Duration) {
+	l, ok := lb.loads[endpoint.Address()]
+	if !ok {
+		// The endpoint was removed while the call was in progress.
+		return
+	}
+	if l.outstanding > 0 {
+		l.outstanding--
+	}
+	if latency > 0 {
+		l.record(latency, time.Now())
+	}
+}
+
+// Load implements the LoadBalancer interface.
+func (lb *loadBalancer) Load(endpoint Endpoint) Load {
+	l, ok := lb.loads[endpoint.Address()]
+	if !ok {
+		return Load{}
+	}
+	return Load{Outstanding: l.outstanding, Latency: l.latency(time.Now())}
+}
@@ -0,0 +1,208 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/internal/net/call"
+	"github.com/ServiceWeaver/weaver/runtime/logging"
+)
+
+// pickAll picks n endpoints with b and returns the number of times every
+// endpoint, identified by its address, was picked. Every pick is passed to
+// b.Begin, so the picks contribute to the load of the endpoints.
+func pickAll(t *testing.T, b call.LoadBalancer, n int) map[string]int {
+	t.Helper()
+	picks := map[string]int{}
+	for i := 0; i < n; i++ {
+		e, err := b.Pick(call.CallOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.Begin(e)
+		picks[e.Address()]++
+	}
+	return picks
+}
+
+func TestNewBalancer(t *testing.T) {
+	for _, kind := range []string{"", "round_robin", "least_loaded", "power_of_two_choices", "least_latency"} {
+		if _, err := call.NewBalancer(kind); err != nil {
+			t.Errorf("NewBalancer(%q): %v", kind, err)
+		}
+	}
+	if _, err := call.NewBalancer("random"); err == nil {
+		t.Errorf("NewBalancer(%q): unexpected success", "random")
+	}
+}
+
+func TestLoadBalancersNoEndpoints(t *testing.T) {
+	for name, b := range map[string]call.LoadBalancer{
+		"LeastLoaded":       call.LeastLoaded(),
+		"PowerOfTwoChoices": call.PowerOfTwoChoices(),
+		"LeastLatency":      call.LeastLatency(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := b.Pick(call.CallOptions{}); !errors.Is(err, call.Unreachable) {
+				t.Fatalf("Pick: got %v, want %v", err, call.Unreachable)
+			}
+			b.Update([]call.Endpoint{})
+			if _, err := b.Pick(call.CallOptions{}); !errors.Is(err, call.Unreachable) {
+				t.Fatalf("Pick: got %v, want %v", err, call.Unreachable)
+			}
+		})
+	}
+}
+
+func TestLeastLoaded(t *testing.T) {
+	a, b, c := call.TCP("a"), call.TCP("b"), call.TCP("c")
+	lb := call.LeastLoaded()
+	lb.Update([]call.Endpoint{a, b, c})
+
+	// With no other load, picks are spread evenly.
+	for addr, n := range pickAll(t, lb, 30) {
+		if n != 10 {
+			t.Fatalf("%s picked %d times, want 10", addr, n)
+		}
+	}
+
+	// Calls to a and b end, so the next picks go to them.
+	for i := 0; i < 5; i++ {
+		lb.End(a, time.Millisecond)
+		lb.End(b, time.Millisecond)
+	}
+	picks := pickAll(t, lb, 10)
+	if picks[a.Address()] != 5 || picks[b.Address()] != 5 {
+		t.Fatalf("got picks %v, want 5 for a and b", picks)
+	}
+	if got, want := lb.Load(c).Outstanding, 10; got != want {
+		t.Fatalf("outstanding calls to c: got %d, want %d", got, want)
+	}
+}
+
+func TestPowerOfTwoChoices(t *testing.T) {
+	a, b := call.TCP("a"), call.TCP("b")
+	lb := call.PowerOfTwoChoices()
+	lb.Update([]call.Endpoint{a, b})
+
+	// With two endpoints, both are always chosen, so the less loaded one is
+	// always picked.
+	lb.Begin(a)
+	for i := 0; i < 10; i++ {
+		e, err := lb.Pick(call.CallOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e != b {
+			t.Fatalf("Pick: got %s, want %s", e.Address(), b.Address())
+		}
+	}
+
+	// With a single endpoint, it is always picked.
+	lb.Update([]call.Endpoint{a})
+	e, err := lb.Pick(call.CallOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e != a {
+		t.Fatalf("Pick: got %s, want %s", e.Address(), a.Address())
+	}
+}
+
+func TestLeastLatency(t *testing.T) {
+	fast, slow := call.TCP("fast"), call.TCP("slow")
+	lb := call.LeastLatency()
+	lb.Update([]call.Endpoint{fast, slow})
+	for _, e := range []call.Endpoint{fast, slow} {
+		lb.Begin(e)
+	}
+	lb.End(fast, time.Millisecond)
+	lb.End(slow, 100*time.Millisecond)
+
+	// The fast endpoint is picked until it has many more outstanding calls
+	// than the slow one.
+	picks := pickAll(t, lb, 10)
+	if picks[fast.Address()] != 10 {
+		t.Fatalf("got picks %v, want 10 for fast", picks)
+	}
+	load := lb.Load(slow)
+	if load.Outstanding != 0 || load.Latency <= 10*time.Millisecond || load.Latency > 100*time.Millisecond {
+		t.Fatalf("load of slow endpoint: got %+v, want 0 outstanding calls and ~100ms latency", load)
+	}
+}
+
+func TestLoadBalancerUpdate(t *testing.T) {
+	a, b := call.TCP("a"), call.TCP("b")
+	lb := call.LeastLoaded()
+	lb.Update([]call.Endpoint{a, b})
+	lb.Begin(a)
+
+	// The load of retained endpoints is retained.
+	lb.Update([]call.Endpoint{call.TCP("a")})
+	if got, want := lb.Load(a).Outstanding, 1; got != want {
+		t.Fatalf("outstanding calls to a: got %d, want %d", got, want)
+	}
+
+	// The load of removed endpoints is forgotten.
+	lb.Update([]call.Endpoint{b})
+	lb.End(a, time.Millisecond)
+	if got, want := lb.Load(a), (call.Load{}); got != want {
+		t.Fatalf("load of a: got %+v, want %+v", got, want)
+	}
+}
+
+// TestLoadBalancerCalls tests that a client informs its load balancer of the
+// start and end of every call.
+func TestLoadBalancerCalls(t *testing.T) {
+	ctx := context.Background()
+	s1, s2 := server(t, "1"), server(t, "2")
+	lb := call.LeastLatency()
+	opts := call.ClientOptions{Balancer: lb, Logger: logging.NewTestLogger(t)}
+	client, err := call.Connect(ctx, call.NewConstantResolver(s1, s2), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	for i := 0; i < 10; i++ {
+		if _, err := client.Call(ctx, whoKey, []byte{}, call.CallOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, e := range []call.Endpoint{s1, s2} {
+		load := lb.Load(e)
+		if load.Outstanding != 0 || load.Latency <= 0 {
+			t.Errorf("load of %s: got %+v, want 0 outstanding calls and a positive latency", e.Address(), load)
+		}
+	}
+
+	// Outstanding streaming calls are tracked too.
+	sctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if _, err := client.Stream(sctx, holdKey, nil, call.CallOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	var outstanding int
+	for _, e := range []call.Endpoint{s1, s2} {
+		outstanding += lb.Load(e).Outstanding
+	}
+	if outstanding != 1 {
+		t.Fatalf("outstanding calls: got %d, want 1", outstanding)
+	}
+}
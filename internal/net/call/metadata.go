@@ -0,0 +1,158 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+)
+
+// metadataKey is the context key for call metadata.
+type metadataKey struct{}
+
+// ContextWithMetadata returns a copy of ctx with the provided metadata added
+// to the metadata already attached to ctx, if any. Entries in md override
+// existing entries with the same key. The metadata attached to the context of
+// a call is made available in the context passed to the handler.
+func ContextWithMetadata(ctx context.Context, md map[string]string) context.Context {
+	if len(md) == 0 {
+		return ctx
+	}
+	old := MetadataFromContext(ctx)
+	merged := make(map[string]string, len(old)+len(md))
+	for k, v := range old {
+		merged[k] = v
+	}
+	for k, v := range md {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, metadataKey{}, merged)
+}
+
+// MetadataFromContext returns the metadata attached to ctx, or nil if there is
+// none. The returned map must not be modified.
+func MetadataFromContext(ctx context.Context) map[string]string {
+	md, _ := ctx.Value(metadataKey{}).(map[string]string)
+	return md
+}
+
+// callerKey is the context key for the caller of a call.
+type callerKey struct{}
+
+// CallerFromContext returns the caller of the call being handled with ctx, as
+// provided by the client in CallOptions.Caller, or the empty string if the
+// client didn't provide a caller. Note that the caller is not authenticated.
+func CallerFromContext(ctx context.Context) string {
+	caller, _ := ctx.Value(callerKey{}).(string)
+	return caller
+}
+
+// peerCertificateKey is the context key for the certificate of the client.
+type peerCertificateKey struct{}
+
+// PeerCertificateFromContext returns the certificate presented by the client
+// of the call being handled with ctx, or nil if the client connected without
+// TLS or without a certificate.
+func PeerCertificateFromContext(ctx context.Context) *x509.Certificate {
+	cert, _ := ctx.Value(peerCertificateKey{}).(*x509.Certificate)
+	return cert
+}
+
+// # Header extension
+//
+// Starting with metadataVersion, the fixed-size header of requestMessage and
+// streamRequestMessage is followed by a header extension:
+//
+//    length     [4]byte       -- length of the extension
+//    extension  [length]byte  -- extension serialization
+//
+// The extension is serialized with a codegen.Encoder and holds:
+//
+//    metadata     map[string]string
+//    caller       string
+//    criticality  int8
+//
+// Fields are only ever appended to the extension. A reader ignores trailing
+// fields it doesn't know about, and treats missing trailing fields as empty,
+// so that clients and servers with different fields can talk to each other.
+
+// appendHeaderExtension appends the header extension for a call made with ctx
+// by the provided caller to hdr.
+func appendHeaderExtension(ctx context.Context, hdr []byte, caller string) []byte {
+	enc := codegen.NewEncoder()
+	md := MetadataFromContext(ctx)
+	enc.Len(len(md))
+	for k, v := range md {
+		enc.String(k)
+		enc.String(v)
+	}
+	enc.String(caller)
+	enc.Int8(int8(CriticalityFromContext(ctx)))
+
+	var n [4]byte
+	binary.LittleEndian.PutUint32(n[:], uint32(len(enc.Data())))
+	hdr = append(hdr, n[:]...)
+	return append(hdr, enc.Data()...)
+}
+
+// readHeaderExtension parses the header extension at the start of msg and
+// returns the context to use for the call, derived from ctx, along with the
+// remainder of msg.
+func readHeaderExtension(ctx context.Context, msg []byte) (_ context.Context, rest []byte, err error) {
+	if len(msg) < 4 {
+		return nil, nil, fmt.Errorf("missing request header extension")
+	}
+	n := binary.LittleEndian.Uint32(msg)
+	if uint64(len(msg)-4) < uint64(n) {
+		return nil, nil, fmt.Errorf("bad request header extension length %d", n)
+	}
+	ext, rest := msg[4:4+n], msg[4+n:]
+
+	defer func() {
+		if x := codegen.CatchPanics(recover()); x != nil {
+			err = fmt.Errorf("bad request header extension: %w", x)
+		}
+	}()
+	dec := codegen.NewDecoder(ext)
+	if l := dec.Len(); l > 0 {
+		// Every entry is at least two empty strings, i.e., two 4-byte
+		// lengths. Reject lengths that the extension can't possibly hold, so
+		// that a malformed header can't make us allocate a huge map.
+		if l > (len(ext)-4)/8 {
+			return nil, nil, fmt.Errorf("bad request header extension: %d metadata entries in %d bytes", l, len(ext))
+		}
+		md := make(map[string]string, l)
+		for i := 0; i < l; i++ {
+			k := dec.String()
+			md[k] = dec.String()
+		}
+		ctx = context.WithValue(ctx, metadataKey{}, md)
+	}
+	if !dec.Empty() {
+		if caller := dec.String(); caller != "" {
+			ctx = context.WithValue(ctx, callerKey{}, caller)
+		}
+	}
+	if !dec.Empty() {
+		if c := Criticality(dec.Int8()); c != DefaultCriticality {
+			ctx = context.WithValue(ctx, criticalityKey{}, c)
+		}
+	}
+	return ctx, rest, nil
+}
@@ -31,8 +31,11 @@ const (
 	responseMessage
 	responseError
 	cancelMessage
+	streamRequestMessage
+	streamDataMessage
+	streamCloseMessage
+	streamCreditMessage
 	// Other types to add?
-	// - chunked request/response messages?
 	// - health check
 	// - server status info
 )
@@ -42,9 +45,48 @@ type version uint32
 
 const (
 	initialVersion version = iota
+	streamingVersion
+	metadataVersion    // adds the request header extension (see metadata.go)
+	compressionVersion // adds compression negotiation (see compression.go)
+	rangeVersion       // adds the range of supported versions to versionMessage
 )
 
-const currentVersion = initialVersion
+// currentVersion and minSupportedVersion are the highest and lowest versions
+// of the protocol supported by this implementation.
+const (
+	currentVersion      = rangeVersion
+	minSupportedVersion = initialVersion
+)
+
+// versionRange is a range [min, max] of protocol versions supported by one
+// side of a connection.
+type versionRange struct {
+	min, max version
+}
+
+// supportedVersions is the range of versions supported by this
+// implementation.
+var supportedVersions = versionRange{minSupportedVersion, currentVersion}
+
+func (r versionRange) String() string {
+	return fmt.Sprintf("[%d, %d]", r.min, r.max)
+}
+
+// negotiate returns the highest version supported by both r and the range
+// supported by a peer, or an error if there is no such version.
+func (r versionRange) negotiate(peer versionRange) (version, error) {
+	v := r.max
+	if peer.max < v {
+		v = peer.max
+	}
+	if v < r.min || v < peer.min {
+		return 0, fmt.Errorf("no common protocol version: supported versions %v, peer supported versions %v", r, peer)
+	}
+	return v, nil
+}
+
+// maxMessageSize is the maximum size of a message payload.
+const maxMessageSize = 100 << 20
 
 // # Message formats
 //
@@ -54,15 +96,27 @@ const currentVersion = initialVersion
 //    length    [7]byte       -- length of the remainder of the message
 //    payload   [length]byte  -- message-type-specific data
 //
-// The format of payload depends on the message type.
+// The format of payload depends on the message type. The type of a message
+// whose payload is compressed has compressedFlag set (see compression.go).
 //
 // versionMessage: this is the first message sent on a connection by both sides.
-//    version  [4]byte
+// The client waits for the server's versionMessage before sending any requests.
+//    version     [4]byte  -- highest supported version
+//    algorithm   [1]byte  -- compression algorithm (compressionVersion and later)
+//    threshold   [4]byte  -- compression threshold (compressionVersion and later)
+//    minVersion  [4]byte  -- lowest supported version (rangeVersion and later)
+//
+// Both sides use the highest version supported by both of them (see
+// versionRange.negotiate). A peer that predates rangeVersion supports every
+// version up to the one it sends. A server that shares no version with a
+// client still sends its versionMessage, so that the client can report the
+// mismatch, and then closes the connection.
 //
 // requestMessage:
 //    headerKey    [16]byte   -- fingerprint of method name
 //    deadline      [8]byte   -- zero, or deadline in microseconds
 //    traceContext [25]byte   -- zero, or trace context
+//    extension               -- header extension, see metadata.go
 //    remainder               -- call argument serialization
 //
 // responseMessage:
@@ -73,6 +127,20 @@ const currentVersion = initialVersion
 //
 // cancelMessage:
 //    payload is empty
+//
+// streamRequestMessage: starts a streaming call. Same format as requestMessage.
+// The call ends with a responseMessage or responseError sent by the server.
+//
+// streamDataMessage: sent by either side of a streaming call.
+//    payload holds one stream message
+//
+// streamCloseMessage: sent by the client when it has no more stream messages
+// to send.
+//    payload is empty
+//
+// streamCreditMessage: sent by the receiver of stream messages to allow the
+// sender to send more of them. See stream.go for details.
+//    credits  [4]byte        -- number of additional messages that can be sent
 
 // writeMessage formats and sends a message over w.
 //
@@ -156,8 +224,7 @@ func readMessage(r io.Reader) (messageType, uint64, []byte, error) {
 	w2 := binary.LittleEndian.Uint64(hdr[8:])
 	mt := messageType(w2 & 0xff)
 	dataLen := w2 >> 8
-	const maxSize = 100 << 20
-	if dataLen > maxSize {
+	if dataLen > maxMessageSize {
 		return 0, 0, nil, fmt.Errorf("overly large message length %d", dataLen)
 	}
 
@@ -169,28 +236,65 @@ func readMessage(r io.Reader) (messageType, uint64, []byte, error) {
 	return mt, id, msg, nil
 }
 
-// writeVersion sends my version number to the peer.
-func writeVersion(w io.Writer, wlock *sync.Mutex) error {
-	var msg [4]byte
-	binary.LittleEndian.PutUint32(msg[:], uint32(currentVersion))
-	return writeFlat(w, wlock, versionMessage, 0, nil, msg[:])
+// writeVersion sends my range of supported versions, along with the requested
+// (by a client) or accepted (by a server) compression settings, to the peer.
+// Fields that postdate versions.max are omitted.
+func writeVersion(w io.Writer, wlock *sync.Mutex, versions versionRange, comp compression) error {
+	var buf [13]byte
+	binary.LittleEndian.PutUint32(buf[:], uint32(versions.max))
+	msg := buf[:4]
+	if versions.max >= compressionVersion {
+		buf[4] = byte(comp.algorithm)
+		binary.LittleEndian.PutUint32(buf[5:], uint32(comp.threshold))
+		msg = buf[:9]
+	}
+	if versions.max >= rangeVersion {
+		binary.LittleEndian.PutUint32(buf[9:], uint32(versions.min))
+		msg = buf[:13]
+	}
+	return writeFlat(w, wlock, versionMessage, 0, nil, msg)
 }
 
-// getVersion extracts the version number sent by the peer and picks the
-// appropriate version number to use for communicating with the peer.
-func getVersion(id uint64, msg []byte) (version, error) {
+// getCompression extracts the compression settings sent by a peer that uses
+// version v of the protocol.
+//
+// REQUIRES: msg is a valid versionMessage payload.
+func getCompression(v version, msg []byte) compression {
+	if v < compressionVersion || len(msg) < 9 {
+		return compression{}
+	}
+	return compression{
+		algorithm: Compression(msg[4]),
+		threshold: int(binary.LittleEndian.Uint32(msg[5:])),
+	}
+}
+
+// getVersions extracts the range of versions supported by the peer.
+func getVersions(id uint64, msg []byte) (versionRange, error) {
 	if id != 0 {
-		return 0, fmt.Errorf("invalid ID %d in handshake", id)
+		return versionRange{}, fmt.Errorf("invalid ID %d in handshake", id)
 	}
 	// Allow messages longer than needed so that future updates can send more info.
 	if len(msg) < 4 {
-		return 0, fmt.Errorf("bad version message length %d, must be >= 4", len(msg))
+		return versionRange{}, fmt.Errorf("bad version message length %d, must be >= 4", len(msg))
+	}
+	peer := versionRange{initialVersion, version(binary.LittleEndian.Uint32(msg))}
+	if peer.max >= rangeVersion {
+		if len(msg) < 13 {
+			return versionRange{}, fmt.Errorf("bad version message length %d, must be >= 13", len(msg))
+		}
+		peer.min = version(binary.LittleEndian.Uint32(msg[9:]))
 	}
-	v := binary.LittleEndian.Uint32(msg)
+	return peer, nil
+}
 
-	// We use the minimum of the peer and my version numbers.
-	if v < uint32(currentVersion) {
-		return version(v), nil
+// getVersion extracts the range of versions supported by the peer and picks
+// the highest version in both that range and the provided range of my
+// supported versions.
+func getVersion(id uint64, msg []byte, mine versionRange) (version, error) {
+	peer, err := getVersions(id, msg)
+	if err != nil {
+		return 0, err
 	}
-	return currentVersion, nil
+	return mine.negotiate(peer)
 }
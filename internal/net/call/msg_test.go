@@ -16,12 +16,19 @@ package call
 
 import (
 	"bytes"
+	"context"
+	"encoding/binary"
 	"fmt"
 	"math/rand"
 	"net"
+	"strings"
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+	"github.com/ServiceWeaver/weaver/runtime/logging"
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestConcurrentWrites(t *testing.T) {
@@ -148,3 +155,359 @@ func sizeString(s int) string {
 	}
 	return fmt.Sprint(s)
 }
+
+func TestHeaderExtension(t *testing.T) {
+	md := map[string]string{"tenant": "acme", "request": "42"}
+	ctx := ContextWithMetadata(context.Background(), md)
+	ctx = ContextWithCriticality(ctx, Sheddable)
+	hdr := appendHeaderExtension(ctx, []byte{1, 2, 3}, "caller")
+	msg := append(hdr, []byte("args")...)
+
+	got, rest, err := readHeaderExtension(context.Background(), msg[3:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(md, MetadataFromContext(got)); diff != "" {
+		t.Fatalf("bad metadata (-want +got):\n%s", diff)
+	}
+	if got, want := CallerFromContext(got), "caller"; got != want {
+		t.Fatalf("bad caller: got %q, want %q", got, want)
+	}
+	if got, want := CriticalityFromContext(got), Sheddable; got != want {
+		t.Fatalf("bad criticality: got %v, want %v", got, want)
+	}
+	if string(rest) != "args" {
+		t.Fatalf("bad remainder: got %q, want %q", rest, "args")
+	}
+
+	// Truncated extensions are rejected.
+	for i := 0; i < len(hdr)-3; i++ {
+		if _, _, err := readHeaderExtension(context.Background(), hdr[3:3+i]); err == nil {
+			t.Errorf("readHeaderExtension(%v): unexpected success", hdr[3:3+i])
+		}
+	}
+}
+
+func TestHeaderExtensionWithoutCaller(t *testing.T) {
+	// Clients that predate the caller field send only the metadata.
+	enc := codegen.NewEncoder()
+	enc.Len(1)
+	enc.String("tenant")
+	enc.String("acme")
+	var n [4]byte
+	binary.LittleEndian.PutUint32(n[:], uint32(len(enc.Data())))
+	msg := append(n[:], enc.Data()...)
+	msg = append(msg, []byte("args")...)
+
+	got, rest, err := readHeaderExtension(context.Background(), msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]string{"tenant": "acme"}, MetadataFromContext(got)); diff != "" {
+		t.Fatalf("bad metadata (-want +got):\n%s", diff)
+	}
+	if caller := CallerFromContext(got); caller != "" {
+		t.Fatalf("bad caller: got %q, want none", caller)
+	}
+	if got, want := CriticalityFromContext(got), DefaultCriticality; got != want {
+		t.Fatalf("bad criticality: got %v, want %v", got, want)
+	}
+	if string(rest) != "args" {
+		t.Fatalf("bad remainder: got %q, want %q", rest, "args")
+	}
+}
+
+func TestHeaderExtensionHugeMetadataLength(t *testing.T) {
+	// A malformed extension that claims a huge number of metadata entries is
+	// rejected without allocating space for them.
+	enc := codegen.NewEncoder()
+	enc.Len(1<<31 - 1)
+	enc.String("tenant")
+	enc.String("acme")
+	var n [4]byte
+	binary.LittleEndian.PutUint32(n[:], uint32(len(enc.Data())))
+	msg := append(n[:], enc.Data()...)
+
+	if _, _, err := readHeaderExtension(context.Background(), msg); err == nil {
+		t.Fatal("readHeaderExtension: unexpected success")
+	}
+}
+
+// oldEndpoint is an Endpoint for a server that speaks initialVersion of the
+// protocol and echoes the arguments of every request.
+type oldEndpoint struct {
+	t *testing.T
+}
+
+func (e oldEndpoint) Address() string { return "old" }
+
+func (e oldEndpoint) Dial(context.Context) (net.Conn, error) {
+	client, server := net.Pipe()
+	e.t.Cleanup(func() { server.Close() })
+	go func() {
+		var wlock sync.Mutex
+		for {
+			mt, id, msg, err := readMessage(server)
+			if err != nil {
+				return
+			}
+			switch mt {
+			case versionMessage:
+				var v [4]byte
+				binary.LittleEndian.PutUint32(v[:], uint32(initialVersion))
+				writeFlat(server, &wlock, versionMessage, 0, nil, v[:])
+			case requestMessage:
+				// An old server doesn't expect a header extension.
+				writeFlat(server, &wlock, responseMessage, id, nil, msg[msgHeaderSize:])
+			default:
+				server.Close()
+				return
+			}
+		}
+	}()
+	return client, nil
+}
+
+func TestOldServer(t *testing.T) {
+	// Check that a client doesn't send a header extension, or streaming
+	// requests, to a server that doesn't support them.
+	ctx := ContextWithMetadata(context.Background(), map[string]string{"a": "b"})
+	opts := ClientOptions{Logger: logging.NewTestLogger(t)}
+	client, err := Connect(ctx, NewConstantResolver(oldEndpoint{t}), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	result, err := client.Call(ctx, MakeMethodKey("", "echo"), []byte("hello"), CallOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(result), "hello"; got != want {
+		t.Fatalf("bad result: got %q, want %q", got, want)
+	}
+
+	if _, err := client.Stream(ctx, MakeMethodKey("", "echo"), nil, CallOptions{}); err == nil {
+		t.Fatal("unexpected success streaming to an old server")
+	}
+}
+
+func TestCompress(t *testing.T) {
+	comp := compression{algorithm: Deflate, threshold: 10}
+	for _, test := range []struct {
+		name       string
+		mt         messageType
+		hdr        []byte
+		payload    []byte
+		compressed bool
+	}{
+		{"Compressible", requestMessage, []byte("header"), bytes.Repeat([]byte("x"), 1000), true},
+		{"BelowThreshold", responseMessage, nil, []byte("tiny"), false},
+		{"Incompressible", responseMessage, nil, []byte("abcdefghijklmnopqrstuvwxyz"), false},
+		{"Cancel", cancelMessage, nil, bytes.Repeat([]byte("x"), 1000), false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			mt, hdr, payload := comp.compress(test.mt, test.hdr, test.payload)
+			if got := mt&compressedFlag != 0; got != test.compressed {
+				t.Fatalf("compressed: got %t, want %t", got, test.compressed)
+			}
+			mt, msg, err := decompress(mt, append(hdr, payload...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if mt != test.mt {
+				t.Fatalf("message type: got %d, want %d", mt, test.mt)
+			}
+			want := append(append([]byte{}, test.hdr...), test.payload...)
+			if !bytes.Equal(msg, want) {
+				t.Fatalf("payload: got %q, want %q", msg, want)
+			}
+		})
+	}
+
+	// Corrupt payloads are rejected.
+	if _, _, err := decompress(responseMessage|compressedFlag, []byte("garbage")); err == nil {
+		t.Fatal("decompress: unexpected success")
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	for _, test := range []struct {
+		mine, peer versionRange
+		want       version
+		ok         bool
+	}{
+		{supportedVersions, supportedVersions, currentVersion, true},
+		{versionRange{initialVersion, rangeVersion}, versionRange{initialVersion, metadataVersion}, metadataVersion, true},
+		{versionRange{initialVersion, metadataVersion}, versionRange{initialVersion, rangeVersion}, metadataVersion, true},
+		{versionRange{streamingVersion, rangeVersion}, versionRange{metadataVersion, rangeVersion + 5}, rangeVersion, true},
+		{versionRange{metadataVersion, rangeVersion}, versionRange{initialVersion, streamingVersion}, 0, false},
+		{versionRange{initialVersion, streamingVersion}, versionRange{metadataVersion, rangeVersion}, 0, false},
+	} {
+		got, err := test.mine.negotiate(test.peer)
+		if test.ok && (err != nil || got != test.want) {
+			t.Errorf("%v.negotiate(%v): got %v, %v; want %v", test.mine, test.peer, got, err, test.want)
+		}
+		if !test.ok && err == nil {
+			t.Errorf("%v.negotiate(%v): got %v, want error", test.mine, test.peer, got)
+		}
+	}
+}
+
+func TestVersionMessage(t *testing.T) {
+	// A peer that predates rangeVersion sends only its highest version, and
+	// supports every version up to it.
+	comp := compression{algorithm: Deflate, threshold: 100}
+	for _, test := range []struct {
+		sent, want versionRange
+		size       int
+	}{
+		{versionRange{initialVersion, initialVersion}, versionRange{initialVersion, initialVersion}, 4},
+		{versionRange{streamingVersion, metadataVersion}, versionRange{initialVersion, metadataVersion}, 4},
+		{versionRange{metadataVersion, compressionVersion}, versionRange{initialVersion, compressionVersion}, 9},
+		{versionRange{metadataVersion, rangeVersion}, versionRange{metadataVersion, rangeVersion}, 13},
+	} {
+		var buf bytes.Buffer
+		if err := writeVersion(&buf, &sync.Mutex{}, test.sent, comp); err != nil {
+			t.Fatal(err)
+		}
+		mt, id, msg, err := readMessage(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mt != versionMessage || len(msg) != test.size {
+			t.Errorf("writeVersion(%v): got type %d and size %d, want %d and %d", test.sent, mt, len(msg), versionMessage, test.size)
+		}
+		got, err := getVersions(id, msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("getVersions(writeVersion(%v)): got %v, want %v", test.sent, got, test.want)
+		}
+		if test.sent.max >= compressionVersion {
+			if got := getCompression(got.max, msg); got != comp {
+				t.Errorf("getCompression(writeVersion(%v)): got %+v, want %+v", test.sent, got, comp)
+			}
+		}
+	}
+
+	// A message that claims rangeVersion without a lowest version is invalid.
+	var msg [9]byte
+	binary.LittleEndian.PutUint32(msg[:], uint32(rangeVersion))
+	if _, err := getVersions(0, msg[:]); err == nil {
+		t.Error("getVersions: unexpected success")
+	}
+}
+
+// TestMixedVersions tests calls between clients and servers that support
+// different ranges of versions of the protocol, as happens when a client and
+// a server are built with different versions of Service Weaver.
+func TestMixedVersions(t *testing.T) {
+	versions := map[string]versionRange{
+		"Initial":     {initialVersion, initialVersion},
+		"Streaming":   {initialVersion, streamingVersion},
+		"Metadata":    {initialVersion, metadataVersion},
+		"Compression": {initialVersion, compressionVersion},
+		"Current":     supportedVersions,
+		"Future":      {metadataVersion, rangeVersion + 1},
+	}
+	hmap := &HandlerMap{}
+	hmap.Set("", "metadata", func(ctx context.Context, _ []byte) ([]byte, error) {
+		return []byte(MetadataFromContext(ctx)["key"]), nil
+	})
+	hmap.SetStream("", "stream", func(_ context.Context, arg []byte, _ ServerStream) ([]byte, error) {
+		return arg, nil
+	})
+
+	for cname, cversions := range versions {
+		for sname, sversions := range versions {
+			cversions, sversions := cversions, sversions
+			t.Run(fmt.Sprintf("%sClient%sServer", cname, sname), func(t *testing.T) {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				ctx = ContextWithMetadata(ctx, map[string]string{"key": "value"})
+
+				// Start the server and connect the client.
+				endpoint := &versionedEndpoint{t: t, hmap: hmap, opts: ServerOptions{
+					Logger:   logging.NewTestLogger(t),
+					versions: &sversions,
+				}}
+				opts := ClientOptions{
+					Logger:      logging.NewTestLogger(t),
+					Compression: Deflate,
+					versions:    &cversions,
+				}
+				client, err := Connect(ctx, NewConstantResolver(endpoint), opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer client.Close()
+
+				want, err := cversions.negotiate(sversions)
+				result, callErr := client.Call(ctx, MakeMethodKey("", "metadata"), nil, CallOptions{})
+				if err != nil {
+					// There is no common version.
+					if callErr == nil || !strings.Contains(callErr.Error(), "no common protocol version") {
+						t.Fatalf("Call: got %v, want no common protocol version", callErr)
+					}
+					return
+				}
+				if callErr != nil {
+					t.Fatal(callErr)
+				}
+
+				// Check the negotiated version and compression settings.
+				rc := client.(*reconnectingConnection)
+				rc.mu.Lock()
+				conn := rc.connections[endpoint.Address()][0]
+				got, comp := conn.version, conn.compression
+				rc.mu.Unlock()
+				if got != want {
+					t.Fatalf("version: got %d, want %d", got, want)
+				}
+				if got, want := comp.algorithm == Deflate, want >= compressionVersion; got != want {
+					t.Errorf("compression: got %t, want %t", got, want)
+				}
+
+				// Features are used only if both sides support them.
+				if got, want := string(result) == "value", want >= metadataVersion; got != want {
+					t.Errorf("metadata sent: got %t, want %t", got, want)
+				}
+				stream, err := client.Stream(ctx, MakeMethodKey("", "stream"), []byte("hello"), CallOptions{})
+				if want < streamingVersion {
+					if err == nil {
+						t.Fatal("Stream: unexpected success")
+					}
+					return
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if result, err := stream.Result(); err != nil || string(result) != "hello" {
+					t.Fatalf("Stream: got %q, %v; want %q", result, err, "hello")
+				}
+			})
+		}
+	}
+}
+
+// versionedEndpoint is an Endpoint for a server that serves hmap with the
+// provided options, and in particular the provided range of versions.
+type versionedEndpoint struct {
+	t    *testing.T
+	hmap *HandlerMap
+	opts ServerOptions
+}
+
+func (e *versionedEndpoint) Address() string { return "versioned" }
+
+func (e *versionedEndpoint) Dial(context.Context) (net.Conn, error) {
+	client, server := net.Pipe()
+	e.t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+	ServeOn(context.Background(), server, e.hmap, e.opts)
+	return client, nil
+}
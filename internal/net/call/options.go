@@ -15,6 +15,7 @@
 package call
 
 import (
+	"crypto/tls"
 	"time"
 
 	"github.com/ServiceWeaver/weaver/internal/traceio"
@@ -38,6 +39,38 @@ type ClientOptions struct {
 	// If non-zero, all writes smaller than this limit are flattened into
 	// a single buffer before being written on the connection.
 	WriteFlattenLimit int
+
+	// If not nil, connections to servers use TLS with the provided config.
+	TLSConfig *tls.Config
+
+	// Compression, if not NoCompression, is the compression algorithm that
+	// the client asks servers to use for the payloads sent in either
+	// direction. Servers that don't support compression don't use it.
+	Compression Compression
+
+	// CompressionThreshold is the minimum size, in bytes, of a compressed
+	// payload. Defaults to DefaultCompressionThreshold if zero.
+	CompressionThreshold int
+
+	// Outliers configures the detection and ejection of outlier servers.
+	// Outlier detection is disabled by default.
+	Outliers OutlierOptions
+
+	// OnEject, if not nil, is called whenever a server is ejected by outlier
+	// detection.
+	OnEject func(Ejection)
+
+	// ConnectionsPerEndpoint is the maximum number of network connections
+	// the client opens to every server. Calls to a server are spread across
+	// its connections, so that a slow or large call doesn't delay the calls
+	// behind it. Connections are opened on demand, when every existing
+	// connection to the server has calls in progress. Defaults to 1 if zero.
+	ConnectionsPerEndpoint int
+
+	// versions, if not nil, is the range of protocol versions supported by
+	// the client, instead of supportedVersions. Used by tests to emulate
+	// clients that use other versions of the protocol.
+	versions *versionRange
 }
 
 // ServerOption are the options to configure an RPC server.
@@ -55,6 +88,20 @@ type ServerOptions struct {
 	// If non-zero, all writes smaller than this limit are flattened into
 	// a single buffer before being written on the connection.
 	WriteFlattenLimit int
+
+	// If non-zero, Serve drains the server when its context is canceled.
+	// While draining, the server stops accepting new connections, rejects new
+	// requests with an Unreachable error, and waits up to DrainTimeout for
+	// in-progress requests to finish before closing its connections.
+	DrainTimeout time.Duration
+
+	// If not nil, connections from clients use TLS with the provided config.
+	TLSConfig *tls.Config
+
+	// versions, if not nil, is the range of protocol versions supported by
+	// the server, instead of supportedVersions. Used by tests to emulate
+	// servers that use other versions of the protocol.
+	versions *versionRange
 }
 
 // CallOptions are call-specific options.
@@ -70,6 +117,34 @@ type CallOptions struct {
 	// Balancer that the client was constructed with (provided in
 	// ClientOptions).
 	Balancer Balancer
+
+	// Caller, if not empty, identifies the caller to the server. The server
+	// makes it available to the handler; see CallerFromContext.
+	Caller string
+
+	// HedgeDelay, if positive, hedges the call: if the call hasn't finished
+	// after HedgeDelay, a second copy of the call is sent to a different
+	// endpoint. The result of the first copy to finish is returned, and the
+	// other copy is canceled. Only calls that can safely be executed more
+	// than once should be hedged. HedgeDelay is ignored by Stream.
+	HedgeDelay time.Duration
+
+	// Stats, if not nil, is filled in by Call before it returns.
+	Stats *CallStats
+}
+
+// CallStats holds statistics about a call.
+type CallStats struct {
+	// The sizes, in bytes, of the compressed request and response sent over
+	// the network, or zero if they were not compressed.
+	CompressedRequestBytes int
+	CompressedReplyBytes   int
+
+	// Hedged is true if a second copy of the call was sent to a different
+	// endpoint (see CallOptions.HedgeDelay), and HedgeWon is true if the
+	// result of the call is the result of the second copy.
+	Hedged   bool
+	HedgeWon bool
 }
 
 // withDefaults returns a copy of the ClientOptions with zero values replaced
@@ -81,6 +156,15 @@ func (c ClientOptions) withDefaults() ClientOptions {
 	if c.Balancer == nil {
 		c.Balancer = RoundRobin()
 	}
+	if c.CompressionThreshold == 0 {
+		c.CompressionThreshold = DefaultCompressionThreshold
+	}
+	if c.ConnectionsPerEndpoint <= 0 {
+		c.ConnectionsPerEndpoint = 1
+	}
+	if c.versions == nil {
+		c.versions = &supportedVersions
+	}
 	return c
 }
 
@@ -93,5 +177,8 @@ func (s ServerOptions) withDefaults() ServerOptions {
 	if s.Tracer == nil {
 		s.Tracer = traceio.TestTracer()
 	}
+	if s.versions == nil {
+		s.versions = &supportedVersions
+	}
 	return s
 }
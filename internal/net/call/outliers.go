@@ -0,0 +1,326 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"errors"
+	"time"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/exp/slog"
+)
+
+// # Outlier Detection
+//
+// A client passively tracks the outcome of the calls it makes to every
+// endpoint. An endpoint that fails too many calls in a row, or whose recent
+// latency is much higher than the latency of the other endpoints, is an
+// outlier. An outlier is ejected, i.e. hidden from the client's balancer, for
+// an ejection time that grows with the number of times the endpoint has been
+// ejected. Only a fraction of the endpoints may be ejected at any time, so
+// that a widespread problem doesn't leave a client with no endpoints at all.
+//
+// If the client has a circuit breaker and every endpoint is an outlier that
+// is still within its ejection time, the circuit breaker is open, and calls
+// fail immediately with a CircuitOpen error. Once the ejection time of an
+// endpoint elapses, calls are sent to the endpoint again. A successful call
+// restores the endpoint to health, while a failed one ejects it again.
+
+// OutlierOptions configures the detection and ejection of outlier endpoints.
+// Outlier detection is disabled if both ConsecutiveErrors and LatencyFactor
+// are zero.
+type OutlierOptions struct {
+	// ConsecutiveErrors, if positive, is the number of consecutive failed
+	// calls after which an endpoint is ejected. A call fails if it can't
+	// reach the endpoint, if there is a communication error, or if the
+	// endpoint is overloaded. Errors returned by the handler of a call, and
+	// calls abandoned because their context is done, are not failures.
+	ConsecutiveErrors int
+
+	// LatencyFactor, if positive, ejects an endpoint whose moving average
+	// latency is more than LatencyFactor times the median moving average
+	// latency of all endpoints. Latency outliers are only detected among
+	// three or more endpoints.
+	LatencyFactor float64
+
+	// EjectionTime is the time an endpoint is ejected for the first time. An
+	// endpoint ejected for the nth time in a row is ejected for n times
+	// EjectionTime, up to MaxEjectionTime. Defaults to 30 seconds if zero.
+	EjectionTime time.Duration
+
+	// MaxEjectionTime is the maximum time an endpoint is ejected for.
+	// Defaults to 5 minutes if zero.
+	MaxEjectionTime time.Duration
+
+	// MaxEjectionFraction is the maximum fraction of endpoints that may be
+	// ejected at any time. Defaults to 0.5 if zero.
+	MaxEjectionFraction float64
+
+	// CircuitBreaker, if true, fails calls with a CircuitOpen error while
+	// every endpoint is ejected or would have been ejected if not for
+	// MaxEjectionFraction.
+	CircuitBreaker bool
+}
+
+// Ejection describes the ejection of an endpoint.
+type Ejection struct {
+	Endpoint Endpoint      // the ejected endpoint
+	Reason   string        // why the endpoint was ejected
+	Duration time.Duration // how long the endpoint is ejected for
+}
+
+// Reasons why an endpoint is ejected.
+const (
+	consecutiveErrorsReason = "consecutive_errors"
+	latencyReason           = "latency"
+)
+
+// minLatencySamples is the minimum number of calls whose latency must be
+// recorded for an endpoint before the endpoint can be a latency outlier.
+const minLatencySamples = 10
+
+// enabled returns true if outlier detection is enabled.
+func (o OutlierOptions) enabled() bool {
+	return o.ConsecutiveErrors > 0 || o.LatencyFactor > 0
+}
+
+// withDefaults returns a copy of the OutlierOptions with zero values replaced
+// with default values.
+func (o OutlierOptions) withDefaults() OutlierOptions {
+	if o.EjectionTime == 0 {
+		o.EjectionTime = 30 * time.Second
+	}
+	if o.MaxEjectionTime == 0 {
+		o.MaxEjectionTime = 5 * time.Minute
+	}
+	if o.MaxEjectionFraction == 0 {
+		o.MaxEjectionFraction = 0.5
+	}
+	return o
+}
+
+// failed returns true if a call that returned err counts as a failure of the
+// endpoint it was sent to. A call whose context expires doesn't count, since
+// the deadline is chosen by the caller, not by the endpoint.
+func failed(err error) bool {
+	return errors.Is(err, CommunicationError) ||
+		errors.Is(err, Unreachable) ||
+		errors.Is(err, Overloaded)
+}
+
+// outlierDetector tracks the health of a set of endpoints. An outlierDetector
+// is guarded by the mutex of the reconnectingConnection that owns it.
+type outlierDetector struct {
+	opts     OutlierOptions
+	logger   *slog.Logger
+	onEject  func(Ejection)
+	health   map[string]*endpointHealth // keyed by endpoint address
+	ejected  int                        // number of ejected endpoints
+	earliest time.Time                  // earliest end of an ejection
+	open     bool                       // is the circuit breaker open?
+}
+
+// endpointHealth is the health of an endpoint.
+type endpointHealth struct {
+	endpoint          Endpoint
+	consecutiveErrors int          // number of consecutive failed calls
+	latency           endpointLoad // moving average latency
+	samples           int          // number of calls in latency
+	ejections         int          // number of ejections in a row
+	unhealthy         bool         // is the endpoint an outlier?
+	until             time.Time    // end of the latest ejection, if any
+	ejected           bool         // is the endpoint currently ejected?
+}
+
+// newOutlierDetector returns a new outlierDetector, or nil if outlier
+// detection is disabled.
+func newOutlierDetector(opts OutlierOptions, logger *slog.Logger, onEject func(Ejection)) *outlierDetector {
+	if !opts.enabled() {
+		return nil
+	}
+	return &outlierDetector{
+		opts:    opts.withDefaults(),
+		logger:  logger,
+		onEject: onEject,
+		health:  map[string]*endpointHealth{},
+	}
+}
+
+// update updates the set of endpoints, retaining the health of existing
+// endpoints.
+func (od *outlierDetector) update(endpoints []Endpoint) {
+	health := make(map[string]*endpointHealth, len(endpoints))
+	od.ejected = 0
+	for _, endpoint := range endpoints {
+		addr := endpoint.Address()
+		h, ok := od.health[addr]
+		if !ok {
+			h = &endpointHealth{}
+		}
+		h.endpoint = endpoint
+		if h.ejected {
+			od.ejected++
+		}
+		health[addr] = h
+	}
+	od.health = health
+}
+
+// available returns the endpoints that are not ejected, in the order they
+// appear in endpoints.
+func (od *outlierDetector) available(endpoints []Endpoint) []Endpoint {
+	if od.ejected == 0 {
+		return endpoints
+	}
+	available := make([]Endpoint, 0, len(endpoints)-od.ejected)
+	for _, endpoint := range endpoints {
+		if h, ok := od.health[endpoint.Address()]; !ok || !h.ejected {
+			available = append(available, endpoint)
+		}
+	}
+	return available
+}
+
+// expire returns ejected endpoints whose ejection time has elapsed, and
+// returns true if any endpoint was returned.
+func (od *outlierDetector) expire(now time.Time) bool {
+	if od.ejected == 0 || now.Before(od.earliest) {
+		return false
+	}
+	expired := false
+	od.earliest = time.Time{}
+	for _, h := range od.health {
+		if !h.ejected {
+			continue
+		}
+		if !now.Before(h.until) {
+			h.ejected = false
+			od.ejected--
+			expired = true
+		} else if od.earliest.IsZero() || h.until.Before(od.earliest) {
+			od.earliest = h.until
+		}
+	}
+	return expired
+}
+
+// circuitOpen returns true if the circuit breaker is open, i.e., if every
+// endpoint is unhealthy and within its ejection time.
+func (od *outlierDetector) circuitOpen(now time.Time) bool {
+	if !od.opts.CircuitBreaker {
+		return false
+	}
+	open := len(od.health) > 0
+	for _, h := range od.health {
+		if !h.unhealthy || !now.Before(h.until) {
+			open = false
+			break
+		}
+	}
+	if open != od.open {
+		od.open = open
+		if open {
+			od.logger.Error("Circuit breaker open: every endpoint is unhealthy", "endpoints", len(od.health))
+		} else {
+			od.logger.Info("Circuit breaker closed")
+		}
+	}
+	return open
+}
+
+// record records the outcome of a call to the provided endpoint, and returns
+// true if the endpoint was ejected as a result.
+func (od *outlierDetector) record(endpoint Endpoint, err error, latency time.Duration, now time.Time) bool {
+	h, ok := od.health[endpoint.Address()]
+	if !ok {
+		// The endpoint was removed while the call was in progress.
+		return false
+	}
+
+	if failed(err) {
+		h.consecutiveErrors++
+		if od.opts.ConsecutiveErrors > 0 && h.consecutiveErrors >= od.opts.ConsecutiveErrors {
+			return od.eject(h, consecutiveErrorsReason, now)
+		}
+		return false
+	}
+
+	// The endpoint answered the call, so it is healthy. An endpoint that stays
+	// healthy for MaxEjectionTime after its last ejection is forgiven.
+	h.consecutiveErrors = 0
+	h.unhealthy = false
+	if h.ejections > 0 && now.Sub(h.until) > od.opts.MaxEjectionTime {
+		h.ejections = 0
+	}
+	if od.opts.LatencyFactor <= 0 || latency <= 0 {
+		return false
+	}
+	h.latency.record(latency, now)
+	h.samples++
+	if h.samples < minLatencySamples || len(od.health) < 3 {
+		return false
+	}
+	var latencies []time.Duration
+	for _, other := range od.health {
+		if other.samples >= minLatencySamples && !other.ejected {
+			latencies = append(latencies, other.latency.latency(now))
+		}
+	}
+	if len(latencies) < 3 {
+		return false
+	}
+	slices.Sort(latencies)
+	median := latencies[len(latencies)/2]
+	if float64(h.latency.latency(now)) > od.opts.LatencyFactor*float64(median) {
+		return od.eject(h, latencyReason, now)
+	}
+	return false
+}
+
+// eject marks the provided endpoint as unhealthy, and ejects it unless too
+// many endpoints are already ejected. It returns true if the endpoint was
+// ejected.
+func (od *outlierDetector) eject(h *endpointHealth, reason string, now time.Time) bool {
+	if h.unhealthy && now.Before(h.until) {
+		// The endpoint is already known to be unhealthy.
+		return false
+	}
+	h.ejections++
+	d := time.Duration(h.ejections) * od.opts.EjectionTime
+	if d > od.opts.MaxEjectionTime {
+		d = od.opts.MaxEjectionTime
+	}
+	h.unhealthy = true
+	h.until = now.Add(d)
+	// Reset the latency of the endpoint, so that it is measured afresh when
+	// the endpoint returns.
+	h.latency = endpointLoad{}
+	h.samples = 0
+
+	if float64(od.ejected+1) > od.opts.MaxEjectionFraction*float64(len(od.health)) {
+		// Too many endpoints are ejected already.
+		return false
+	}
+	h.ejected = true
+	od.ejected++
+	if od.earliest.IsZero() || h.until.Before(od.earliest) {
+		od.earliest = h.until
+	}
+	od.logger.Info("Ejected endpoint", "endpoint", h.endpoint.Address(), "reason", reason, "duration", d)
+	if od.onEject != nil {
+		od.onEject(Ejection{Endpoint: h.endpoint, Reason: reason, Duration: d})
+	}
+	return true
+}
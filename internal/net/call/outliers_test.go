@@ -0,0 +1,154 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime/logging"
+)
+
+// newTestDetector returns a new outlierDetector for the provided endpoints,
+// along with the ejections it makes.
+func newTestDetector(t *testing.T, opts OutlierOptions, endpoints ...Endpoint) (*outlierDetector, *[]Ejection) {
+	var ejections []Ejection
+	od := newOutlierDetector(opts, logging.NewTestLogger(t), func(e Ejection) {
+		ejections = append(ejections, e)
+	})
+	od.update(endpoints)
+	return od, &ejections
+}
+
+func TestOutlierDetectionDisabled(t *testing.T) {
+	if od := newOutlierDetector(OutlierOptions{CircuitBreaker: true}, nil, nil); od != nil {
+		t.Fatalf("newOutlierDetector: got %v, want nil", od)
+	}
+}
+
+func TestConsecutiveErrors(t *testing.T) {
+	a, b := TCP("a"), TCP("b")
+	opts := OutlierOptions{ConsecutiveErrors: 3, EjectionTime: time.Second, MaxEjectionTime: 3 * time.Second}
+	od, ejections := newTestDetector(t, opts, a, b)
+	now := time.Now()
+	commErr := fmt.Errorf("%w: oops", CommunicationError)
+
+	// Application errors, successful calls, and calls that ran out of time
+	// reset the consecutive failures.
+	for _, err := range []error{commErr, commErr, errors.New("app error"), commErr, commErr, nil, commErr, commErr, context.DeadlineExceeded, commErr, commErr} {
+		if od.record(a, err, time.Millisecond, now) {
+			t.Fatalf("record(%v): unexpected ejection", err)
+		}
+	}
+
+	// The third failure in a row ejects the endpoint, for increasing times.
+	for i, want := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second} {
+		if !od.record(a, Overloaded, 0, now) {
+			t.Fatalf("ejection %d: endpoint not ejected", i)
+		}
+		if got := (*ejections)[i]; got.Duration != want || got.Reason != consecutiveErrorsReason {
+			t.Fatalf("ejection %d: got %+v, want %v for %s", i, got, want, consecutiveErrorsReason)
+		}
+		if got := od.available([]Endpoint{a, b}); len(got) != 1 || got[0] != b {
+			t.Fatalf("ejection %d: got available endpoints %v, want [b]", i, got)
+		}
+
+		// The endpoint returns once its ejection time has elapsed, but the
+		// next failure ejects it again.
+		now = now.Add(want)
+		if !od.expire(now) {
+			t.Fatalf("ejection %d: endpoint not returned", i)
+		}
+		if got := od.available([]Endpoint{a, b}); len(got) != 2 {
+			t.Fatalf("ejection %d: got available endpoints %v, want [a b]", i, got)
+		}
+	}
+}
+
+func TestMaxEjectionFraction(t *testing.T) {
+	a, b, c, d := TCP("a"), TCP("b"), TCP("c"), TCP("d")
+	opts := OutlierOptions{ConsecutiveErrors: 1}
+	od, _ := newTestDetector(t, opts, a, b, c, d)
+	now := time.Now()
+
+	// Only half of the endpoints can be ejected.
+	for _, e := range []Endpoint{a, b} {
+		if !od.record(e, CommunicationError, 0, now) {
+			t.Fatalf("%s not ejected", e.Address())
+		}
+	}
+	if od.record(c, CommunicationError, 0, now) {
+		t.Fatalf("%s ejected", c.Address())
+	}
+	if got, want := len(od.available([]Endpoint{a, b, c, d})), 2; got != want {
+		t.Fatalf("got %d available endpoints, want %d", got, want)
+	}
+}
+
+func TestLatencyOutlier(t *testing.T) {
+	a, b, c := TCP("a"), TCP("b"), TCP("c")
+	opts := OutlierOptions{LatencyFactor: 3}
+	od, ejections := newTestDetector(t, opts, a, b, c)
+	now := time.Now()
+
+	for i := 0; i < minLatencySamples; i++ {
+		od.record(a, nil, time.Millisecond, now)
+		od.record(b, nil, 2*time.Millisecond, now)
+	}
+	for i := 0; i < minLatencySamples-1; i++ {
+		if od.record(c, nil, 10*time.Millisecond, now) {
+			t.Fatalf("%s ejected before enough samples", c.Address())
+		}
+	}
+	if !od.record(c, nil, 10*time.Millisecond, now) {
+		t.Fatalf("%s not ejected", c.Address())
+	}
+	if got := (*ejections)[0]; got.Endpoint != c || got.Reason != latencyReason {
+		t.Fatalf("got ejection %+v, want %s for %s", got, c.Address(), latencyReason)
+	}
+}
+
+func TestCircuitOpen(t *testing.T) {
+	a, b := TCP("a"), TCP("b")
+	opts := OutlierOptions{ConsecutiveErrors: 1, EjectionTime: time.Second, CircuitBreaker: true}
+	od, _ := newTestDetector(t, opts, a, b)
+	now := time.Now()
+
+	od.record(a, CommunicationError, 0, now)
+	if od.circuitOpen(now) {
+		t.Fatal("circuit open with a healthy endpoint")
+	}
+
+	// b is unhealthy, even though it can't be ejected.
+	od.record(b, CommunicationError, 0, now)
+	if !od.circuitOpen(now) {
+		t.Fatal("circuit closed with no healthy endpoint")
+	}
+
+	// The circuit half opens once the ejection time elapses, and closes once
+	// a call succeeds.
+	now = now.Add(time.Second)
+	if od.circuitOpen(now) {
+		t.Fatal("circuit open after the ejection time")
+	}
+	od.record(a, nil, time.Millisecond, now)
+	od.record(b, CommunicationError, 0, now)
+	if od.circuitOpen(now) {
+		t.Fatal("circuit open with a healthy endpoint")
+	}
+}
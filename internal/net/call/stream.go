@@ -0,0 +1,337 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+// # Streaming calls
+//
+// A streaming call is a call that, in addition to its arguments and result,
+// carries a sequence of stream messages from the client to the server, from
+// the server to the client, or both. A streaming call is started with a
+// streamRequestMessage, and the stream messages are carried in
+// streamDataMessages. The client sends a streamCloseMessage once it is done
+// sending stream messages. The server ends the call, as for a regular call,
+// with a responseMessage or responseError, which also signals that the server
+// won't send any more stream messages.
+//
+// # Flow control
+//
+// Every side of a streaming call can send at most streamWindow stream
+// messages that haven't been consumed by the receiver. The receiver tracks
+// the number of messages consumed by the application and, once it reaches
+// half of the window, sends a streamCreditMessage to the sender that allows
+// it to send that many more messages. A sender that is out of credits blocks
+// until it receives a streamCreditMessage. A receiver that gets more messages
+// than it has granted credits for treats it as a protocol error.
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// streamWindow is the number of stream messages that a sender can send
+// before they are consumed by the receiver.
+const streamWindow = 16
+
+// errSendClosed is returned by Send on a stream that has been closed by
+// CloseSend.
+var errSendClosed = errors.New("send on closed stream")
+
+// ClientStream is the client side of a streaming call.
+//
+// Send and CloseSend must not be called concurrently with each other, and
+// Recv must not be called concurrently with itself.
+type ClientStream interface {
+	// Send sends a stream message to the server, blocking if the server
+	// hasn't consumed enough of the previously sent messages. If the server
+	// has already ended the call, Send returns io.EOF, and the outcome of the
+	// call can be retrieved with Result.
+	Send([]byte) error
+
+	// CloseSend tells the server that no more messages will be sent.
+	CloseSend() error
+
+	// Recv returns the next stream message sent by the server. Recv returns
+	// io.EOF once the call has ended and all messages have been received.
+	Recv() ([]byte, error)
+
+	// Result waits for the call to end and returns its result. Note that a
+	// server may not end the call until the client has received all of the
+	// stream messages sent by the server.
+	Result() ([]byte, error)
+}
+
+// ServerStream is the server side of a streaming call.
+//
+// Send must not be called concurrently with itself, and Recv must not be
+// called concurrently with itself.
+type ServerStream interface {
+	// Send sends a stream message to the client, blocking if the client
+	// hasn't consumed enough of the previously sent messages.
+	Send([]byte) error
+
+	// Recv returns the next stream message sent by the client. Recv returns
+	// io.EOF once the client has closed its side of the stream.
+	Recv() ([]byte, error)
+}
+
+// stream holds the flow control state of one side of a streaming call.
+type stream struct {
+	// write writes a message of the provided type for this stream.
+	write func(mt messageType, payload []byte) error
+
+	mu       sync.Mutex
+	changed  chan struct{} // closed and replaced when the fields below change
+	queue    [][]byte      // received messages not yet returned by recv
+	recvErr  error         // if not nil, returned by recv once queue is empty
+	sendErr  error         // if not nil, returned by send
+	credits  int           // number of messages that can be sent
+	consumed int           // number of consumed messages not yet credited
+}
+
+func newStream() *stream {
+	return &stream{changed: make(chan struct{}), credits: streamWindow}
+}
+
+// notify wakes up any goroutines waiting for s to change.
+//
+// REQUIRES: s.mu is held.
+func (s *stream) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// send sends msg to the peer, waiting for credits if needed.
+func (s *stream) send(ctx context.Context, msg []byte) error {
+	for {
+		s.mu.Lock()
+		if s.sendErr != nil {
+			err := s.sendErr
+			s.mu.Unlock()
+			return err
+		}
+		if s.credits > 0 {
+			s.credits--
+			s.mu.Unlock()
+			return s.write(streamDataMessage, msg)
+		}
+		changed := s.changed
+		s.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// recv returns the next message received from the peer, waiting for one to
+// arrive if needed.
+func (s *stream) recv(ctx context.Context) ([]byte, error) {
+	for {
+		s.mu.Lock()
+		if len(s.queue) > 0 {
+			msg := s.queue[0]
+			s.queue[0] = nil
+			s.queue = s.queue[1:]
+			s.consumed++
+			var grant int
+			if s.consumed >= streamWindow/2 && s.recvErr == nil {
+				grant, s.consumed = s.consumed, 0
+			}
+			s.mu.Unlock()
+
+			if grant > 0 {
+				var payload [4]byte
+				binary.LittleEndian.PutUint32(payload[:], uint32(grant))
+				if err := s.write(streamCreditMessage, payload[:]); err != nil {
+					return nil, err
+				}
+			}
+			return msg, nil
+		}
+		if s.recvErr != nil {
+			err := s.recvErr
+			s.mu.Unlock()
+			return nil, err
+		}
+		changed := s.changed
+		s.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// deliver queues a message received from the peer. It returns an error if
+// the peer has sent more messages than it was allowed to.
+func (s *stream) deliver(msg []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.recvErr != nil {
+		// The stream has ended; drop the message.
+		return nil
+	}
+	if len(s.queue)+s.consumed >= streamWindow {
+		return fmt.Errorf("stream flow control violation")
+	}
+	s.queue = append(s.queue, msg)
+	s.notify()
+	return nil
+}
+
+// grant processes a streamCreditMessage received from the peer.
+func (s *stream) grant(msg []byte) error {
+	if len(msg) < 4 {
+		return fmt.Errorf("bad stream credit message length %d, must be >= 4", len(msg))
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.credits += int(binary.LittleEndian.Uint32(msg))
+	s.notify()
+	return nil
+}
+
+// closeRecv records that no more messages will be received. Once all queued
+// messages have been consumed, recv returns err.
+func (s *stream) closeRecv(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.recvErr == nil {
+		s.recvErr = err
+		s.notify()
+	}
+}
+
+// closeSend records that no more messages can be sent. Future calls to send
+// return err. closeSend returns false if s was already closed for sending.
+func (s *stream) closeSend(err error) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sendErr != nil {
+		return false
+	}
+	s.sendErr = err
+	s.notify()
+	return true
+}
+
+// end closes both directions of the stream.
+func (s *stream) end(err error) {
+	s.closeRecv(err)
+	s.closeSend(err)
+}
+
+// clientStream is the ClientStream implementation.
+type clientStream struct {
+	ctx    context.Context
+	conn   *clientConnection
+	rpc    *call
+	stream *stream
+}
+
+var _ ClientStream = &clientStream{}
+
+// Send implements the ClientStream interface.
+func (cs *clientStream) Send(msg []byte) error {
+	return cs.stream.send(cs.ctx, msg)
+}
+
+// CloseSend implements the ClientStream interface.
+func (cs *clientStream) CloseSend() error {
+	if !cs.stream.closeSend(errSendClosed) {
+		// The stream is already closed, or the call has ended.
+		return nil
+	}
+	return cs.stream.write(streamCloseMessage, nil)
+}
+
+// Recv implements the ClientStream interface.
+func (cs *clientStream) Recv() ([]byte, error) {
+	return cs.stream.recv(cs.ctx)
+}
+
+// Result implements the ClientStream interface.
+func (cs *clientStream) Result() ([]byte, error) {
+	select {
+	case <-cs.rpc.doneSignal:
+		return cs.rpc.response, cs.rpc.err
+	case <-cs.ctx.Done():
+		return nil, cs.ctx.Err()
+	}
+}
+
+// watch ends the call if cs.ctx is canceled before the call ends.
+func (cs *clientStream) watch(opts ClientOptions) {
+	select {
+	case <-cs.rpc.doneSignal:
+	case <-cs.ctx.Done():
+		cs.conn.endCall(cs.rpc)
+		cs.stream.end(cs.ctx.Err())
+		deadline, haveDeadline := cs.ctx.Deadline()
+		if !haveDeadline || time.Now().Before(deadline) {
+			// Early cancellation. Tell server about it.
+			if err := writeMessage(cs.conn.c, &cs.conn.wlock, cancelMessage, cs.rpc.id, nil, nil, opts.WriteFlattenLimit); err != nil {
+				cs.conn.shutdown("client send cancel", err)
+			}
+		}
+	}
+}
+
+// serverStream is the ServerStream implementation.
+type serverStream struct {
+	ctx    context.Context
+	stream *stream
+}
+
+var _ ServerStream = &serverStream{}
+
+// Send implements the ServerStream interface.
+func (ss *serverStream) Send(msg []byte) error {
+	return ss.stream.send(ss.ctx, msg)
+}
+
+// Recv implements the ServerStream interface.
+func (ss *serverStream) Recv() ([]byte, error) {
+	return ss.stream.recv(ss.ctx)
+}
+
+// isStreamMessage returns whether mt is sent in the middle of a streaming call.
+func isStreamMessage(mt messageType) bool {
+	return mt == streamDataMessage || mt == streamCloseMessage || mt == streamCreditMessage
+}
+
+// handleStreamMessage processes a stream message of type mt received for s.
+func handleStreamMessage(s *stream, mt messageType, msg []byte) error {
+	switch mt {
+	case streamDataMessage:
+		return s.deliver(msg)
+	case streamCloseMessage:
+		s.closeRecv(io.EOF)
+		return nil
+	case streamCreditMessage:
+		return s.grant(msg)
+	default:
+		return fmt.Errorf("invalid stream message type %d", mt)
+	}
+}
@@ -26,10 +26,11 @@ import (
 
 // Proxy is an HTTP proxy that forwards traffic to a set of backends.
 type Proxy struct {
-	logger   *slog.Logger          // logger
-	reverse  httputil.ReverseProxy // underlying proxy
-	mu       sync.Mutex            // guards backends
-	backends []string              // backend addresses
+	logger    *slog.Logger          // logger
+	reverse   httputil.ReverseProxy // underlying proxy
+	mu        sync.Mutex            // guards backends and unhealthy
+	backends  []string              // backend addresses
+	unhealthy map[string]bool       // unhealthy backend addresses
 }
 
 // NewProxy returns a new proxy.
@@ -52,6 +53,22 @@ func (p *Proxy) AddBackend(backend string) {
 	p.backends = append(p.backends, backend)
 }
 
+// SetHealthy marks the provided backend as healthy or unhealthy. Traffic is
+// only forwarded to healthy backends, unless every backend is unhealthy.
+// Backends are healthy by default.
+func (p *Proxy) SetHealthy(backend string, healthy bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if healthy {
+		delete(p.unhealthy, backend)
+		return
+	}
+	if p.unhealthy == nil {
+		p.unhealthy = map[string]bool{}
+	}
+	p.unhealthy[backend] = true
+}
+
 // director implements a ReverseProxy.Director function [1].
 //
 // [1]: https://pkg.go.dev/net/http/httputil#ReverseProxy
@@ -62,6 +79,18 @@ func (p *Proxy) director(r *http.Request) {
 		p.logger.Error("director", errors.New("no backends"), "url", r.URL)
 		return
 	}
+	backends := p.backends
+	if len(p.unhealthy) > 0 {
+		var healthy []string
+		for _, backend := range p.backends {
+			if !p.unhealthy[backend] {
+				healthy = append(healthy, backend)
+			}
+		}
+		if len(healthy) > 0 {
+			backends = healthy
+		}
+	}
 	r.URL.Scheme = "http" // TODO(mwhittaker): Support HTTPS.
-	r.URL.Host = p.backends[rand.Intn(len(p.backends))]
+	r.URL.Host = backends[rand.Intn(len(backends))]
 }
@@ -0,0 +1,57 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/runtime/logging"
+)
+
+// hosts returns the set of backends picked by p for n requests.
+func hosts(p *Proxy, n int) map[string]bool {
+	picked := map[string]bool{}
+	for i := 0; i < n; i++ {
+		r := httptest.NewRequest("GET", "/", nil)
+		p.director(r)
+		picked[r.URL.Host] = true
+	}
+	return picked
+}
+
+func TestSetHealthy(t *testing.T) {
+	p := NewProxy(logging.NewTestLogger(t))
+	p.AddBackend("a")
+	p.AddBackend("b")
+
+	// Unhealthy backends don't receive traffic.
+	p.SetHealthy("a", false)
+	if got := hosts(p, 100); len(got) != 1 || !got["b"] {
+		t.Fatalf("got backends %v, want only b", got)
+	}
+
+	// If every backend is unhealthy, every backend receives traffic.
+	p.SetHealthy("b", false)
+	if got := hosts(p, 100); len(got) != 2 {
+		t.Fatalf("got backends %v, want a and b", got)
+	}
+
+	// Backends can become healthy again.
+	p.SetHealthy("a", true)
+	if got := hosts(p, 100); len(got) != 1 || !got["a"] {
+		t.Fatalf("got backends %v, want only a", got)
+	}
+}
@@ -229,11 +229,13 @@ func (d *dashboard) handleDeployment(w http.ResponseWriter, r *http.Request) {
 		*Status
 		Tool     string
 		Traffic  []edge
+		Loads    []load
 		Commands []Command
 	}{
 		Status:   status,
 		Tool:     d.spec.Tool,
 		Traffic:  computeTraffic(status, metrics.Metrics),
+		Loads:    computeLoads(metrics.Metrics),
 		Commands: d.spec.Commands(id),
 	}
 	if err := deploymentTemplate.Execute(w, content); err != nil {
@@ -280,6 +282,71 @@ func computeTraffic(status *Status, metrics []*protos.MetricSnapshot) []edge {
 	return edges
 }
 
+// A load is a load balancer's view of the load of an endpoint.
+type load struct {
+	Weavelet    string  // weavelet running the balancer, if known
+	Component   string  // component whose calls are balanced
+	Balancer    string  // load balancer name
+	Endpoint    string  // endpoint address
+	Outstanding float64 // number of outstanding calls
+	LatencyMs   float64 // moving average latency, in ms
+}
+
+// computeLoads extracts the load of every endpoint tracked by a load balancer
+// from the provided metrics.
+func computeLoads(metrics []*protos.MetricSnapshot) []load {
+	type key struct {
+		weavelet  string
+		component string
+		balancer  string
+		endpoint  string
+	}
+	byKey := map[key]*load{}
+	for _, metric := range metrics {
+		name := metric.Name
+		if name != codegen.BalancerOutstandingCalls.Name() && name != codegen.BalancerLatencies.Name() {
+			continue
+		}
+		k := key{
+			weavelet:  metric.Labels["serviceweaver_node"],
+			component: metric.Labels["component"],
+			balancer:  metric.Labels["balancer"],
+			endpoint:  metric.Labels["endpoint"],
+		}
+		l, ok := byKey[k]
+		if !ok {
+			l = &load{
+				Weavelet:  logging.Shorten(k.weavelet),
+				Component: k.component,
+				Balancer:  k.balancer,
+				Endpoint:  k.endpoint,
+			}
+			byKey[k] = l
+		}
+		if name == codegen.BalancerOutstandingCalls.Name() {
+			l.Outstanding = metric.Value
+		} else {
+			l.LatencyMs = metric.Value / 1000
+		}
+	}
+
+	loads := make([]load, 0, len(byKey))
+	for _, l := range byKey {
+		loads = append(loads, *l)
+	}
+	sort.Slice(loads, func(i, j int) bool {
+		li, lj := loads[i], loads[j]
+		if li.Component != lj.Component {
+			return li.Component < lj.Component
+		}
+		if li.Weavelet != lj.Weavelet {
+			return li.Weavelet < lj.Weavelet
+		}
+		return li.Endpoint < lj.Endpoint
+	})
+	return loads
+}
+
 // handleMetrics handles requests to /metrics?id=<deployment id>
 func (d *dashboard) handleMetrics(w http.ResponseWriter, r *http.Request) {
 	// TODO(mwhittaker): Change to /<deployment id>/metrics?
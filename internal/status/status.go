@@ -111,7 +111,7 @@ func formatComponents(w io.Writer, statuses []*Status) {
 	title := []colors.Text{{{S: "COMPONENTS", Bold: true}}}
 	t := colors.NewTabularizer(w, title, colors.PrefixDim)
 	defer t.Flush()
-	t.Row("APP", "DEPLOYMENT", "COMPONENT", "REPLICA PIDS")
+	t.Row("APP", "DEPLOYMENT", "COMPONENT", "REPLICA PIDS", "RESTARTS")
 	for _, status := range statuses {
 		sort.Slice(status.Components, func(i, j int) bool {
 			return status.Components[i].Name < status.Components[j].Name
@@ -126,7 +126,7 @@ func formatComponents(w io.Writer, statuses []*Status) {
 			for i, pid := range component.Pids {
 				pids[i] = fmt.Sprint(pid)
 			}
-			t.Row(status.App, prefix, c, strings.Join(pids, ", "))
+			t.Row(status.App, prefix, c, strings.Join(pids, ", "), fmt.Sprint(component.Restarts))
 		}
 	}
 }
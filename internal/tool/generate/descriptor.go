@@ -0,0 +1,359 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/ServiceWeaver/weaver/internal/net/call"
+)
+
+// descriptor describes the API of a set of packages: their components and
+// the layouts of the types serialized by the methods of the components.
+// "weaver generate --descriptor" writes a descriptor as JSON, and "weaver
+// generate --check-compat" checks that the current code is wire-compatible
+// with a previously written descriptor.
+type descriptor struct {
+	Components []componentDescriptor `json:"components"`
+	Types      []typeDescriptor      `json:"types"`
+}
+
+// componentDescriptor describes a component.
+type componentDescriptor struct {
+	Name    string             `json:"name"` // e.g., "github.com/foo/Cache"
+	Methods []methodDescriptor `json:"methods"`
+}
+
+// methodDescriptor describes a component method.
+type methodDescriptor struct {
+	Name    string   `json:"name"`
+	Key     string   `json:"key"`     // hex-encoded call.MakeMethodKey
+	Args    []string `json:"args"`    // argument types, without the context
+	Results []string `json:"results"` // result types, without the error
+}
+
+// typeDescriptor describes the layout of a named type.
+type typeDescriptor struct {
+	Name       string            `json:"name"` // e.g., "github.com/foo.Pair"
+	Kind       string            `json:"kind"` // see below
+	Underlying string            `json:"underlying,omitempty"`
+	Fields     []fieldDescriptor `json:"fields,omitempty"`
+}
+
+// Kinds of types, which determine how values of the types are encoded.
+const (
+	kindAutoMarshal = "auto_marshal"     // a struct that embeds weaver.AutoMarshal
+	kindCustom      = "custom"           // implements codegen.AutoMarshal by hand
+	kindProto       = "proto"            // a protocol buffer
+	kindBinary      = "binary_marshaler" // implements encoding.BinaryMarshaler
+	kindInterface   = "interface"        // an interface
+	kindNamed       = "named"            // encoded like its underlying type
+)
+
+// fieldDescriptor describes a field of a struct that embeds
+// weaver.AutoMarshal.
+type fieldDescriptor struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Number int    `json:"number,omitempty"` // field number, if tagged
+}
+
+// fullTypeString returns the string representation of t, where every named
+// type is qualified by the full path of its package.
+func fullTypeString(t types.Type) string {
+	return types.TypeString(t, func(pkg *types.Package) string { return pkg.Path() })
+}
+
+// describe returns a descriptor of the components in g's package and of the
+// types they serialize, along with the AutoMarshal structs of the package.
+func (g *generator) describe() descriptor {
+	var d descriptor
+	seen := map[string]bool{}
+	for _, comp := range g.components {
+		c := componentDescriptor{Name: comp.fullName}
+		for _, m := range comp.methods {
+			sig := m.Type().(*types.Signature)
+			method := methodDescriptor{
+				Name:    m.Name(),
+				Args:    []string{},
+				Results: []string{},
+			}
+			key := call.MakeMethodKey(comp.fullName, m.Name())
+			method.Key = hex.EncodeToString(key[:])
+			for i := 1; i < sig.Params().Len(); i++ {
+				t := sig.Params().At(i).Type()
+				method.Args = append(method.Args, fullTypeString(t))
+				g.describeType(t, seen, &d.Types)
+			}
+			for i := 0; i < sig.Results().Len()-1; i++ {
+				t := sig.Results().At(i).Type()
+				method.Results = append(method.Results, fullTypeString(t))
+				g.describeType(t, seen, &d.Types)
+			}
+			c.Methods = append(c.Methods, method)
+		}
+		sort.Slice(c.Methods, func(i, j int) bool { return c.Methods[i].Name < c.Methods[j].Name })
+		d.Components = append(d.Components, c)
+	}
+	for _, t := range g.tset.automarshalCandidates.Keys() {
+		g.describeType(t, seen, &d.Types)
+	}
+	return d
+}
+
+// describeType appends to types a descriptor of every named type that is
+// serialized as part of a value of type t, and that is not in seen.
+func (g *generator) describeType(t types.Type, seen map[string]bool, descs *[]typeDescriptor) {
+	switch x := t.(type) {
+	case *types.Pointer:
+		g.describeType(x.Elem(), seen, descs)
+	case *types.Array:
+		g.describeType(x.Elem(), seen, descs)
+	case *types.Slice:
+		g.describeType(x.Elem(), seen, descs)
+	case *types.Map:
+		g.describeType(x.Key(), seen, descs)
+		g.describeType(x.Elem(), seen, descs)
+	case *types.Named:
+		if isWeaverStreamReader(x) || isWeaverStreamWriter(x) {
+			// Only the values in a stream are serialized.
+			for i := 0; i < x.TypeArgs().Len(); i++ {
+				g.describeType(x.TypeArgs().At(i), seen, descs)
+			}
+			return
+		}
+		name := fullTypeString(x)
+		if seen[name] || isWeaverAutoMarshal(x) || isContext(x) || isError(x) {
+			return
+		}
+		seen[name] = true
+
+		desc := typeDescriptor{Name: name}
+		s, isStruct := x.Underlying().(*types.Struct)
+		_, isInterface := x.Underlying().(*types.Interface)
+		switch {
+		case g.tset.isProto(x):
+			desc.Kind = kindProto
+		case g.tset.hasMarshalBinary(x):
+			desc.Kind = kindBinary
+		case isInterface:
+			desc.Kind = kindInterface
+		case isStruct && embedsAutoMarshal(s):
+			desc.Kind = kindAutoMarshal
+			for i := 0; i < s.NumFields(); i++ {
+				f := s.Field(i)
+				if isWeaverAutoMarshal(f.Type()) {
+					continue
+				}
+				desc.Fields = append(desc.Fields, fieldDescriptor{
+					Name:   f.Name(),
+					Type:   fullTypeString(f.Type()),
+					Number: fieldNumber(s, i),
+				})
+				g.describeType(f.Type(), seen, descs)
+			}
+		case g.tset.automarshals.At(x) != nil || g.tset.implementsAutoMarshal(x):
+			desc.Kind = kindCustom
+		default:
+			desc.Kind = kindNamed
+			desc.Underlying = fullTypeString(x.Underlying())
+			g.describeType(x.Underlying(), seen, descs)
+		}
+		*descs = append(*descs, desc)
+	}
+}
+
+// fieldNumber returns the number of the ith field of s, as declared by its
+// weaver struct tag, or zero if it doesn't have a valid one.
+func fieldNumber(s *types.Struct, i int) int {
+	tag, ok := reflect.StructTag(s.Tag(i)).Lookup("weaver")
+	if !ok {
+		return 0
+	}
+	n, err := strconv.ParseUint(tag, 10, 32)
+	if err != nil {
+		return 0
+	}
+	return int(n)
+}
+
+// merge merges the provided descriptors into one, sorted by name.
+func merge(descs []descriptor) descriptor {
+	merged := descriptor{Components: []componentDescriptor{}, Types: []typeDescriptor{}}
+	seen := map[string]bool{}
+	for _, d := range descs {
+		merged.Components = append(merged.Components, d.Components...)
+		for _, t := range d.Types {
+			if !seen[t.Name] {
+				seen[t.Name] = true
+				merged.Types = append(merged.Types, t)
+			}
+		}
+	}
+	sort.Slice(merged.Components, func(i, j int) bool {
+		return merged.Components[i].Name < merged.Components[j].Name
+	})
+	sort.Slice(merged.Types, func(i, j int) bool {
+		return merged.Types[i].Name < merged.Types[j].Name
+	})
+	return merged
+}
+
+// writeDescriptor writes the provided descriptor to the provided file as
+// JSON.
+func writeDescriptor(filename string, d descriptor) error {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode descriptor: %w", err)
+	}
+	return os.WriteFile(filename, append(data, '\n'), 0644)
+}
+
+// readDescriptor reads a descriptor written by writeDescriptor.
+func readDescriptor(filename string) (descriptor, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return descriptor{}, err
+	}
+	var d descriptor
+	if err := json.Unmarshal(data, &d); err != nil {
+		return descriptor{}, fmt.Errorf("decode descriptor %s: %w", filename, err)
+	}
+	return d, nil
+}
+
+// checkCompat checks that the API described by new is wire-compatible with
+// the API described by old, i.e., that a binary built from the old code and
+// a binary built from the new code can call each other's components. It
+// returns an error for every incompatible change.
+func checkCompat(old, new descriptor) []error {
+	var errs []error
+	errorf := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	// Check the components. Components and methods can be added, but not
+	// removed or changed.
+	components := map[string]componentDescriptor{}
+	for _, c := range new.Components {
+		components[c.Name] = c
+	}
+	for _, o := range old.Components {
+		c, ok := components[o.Name]
+		if !ok {
+			errorf("component %s was removed", o.Name)
+			continue
+		}
+		methods := map[string]methodDescriptor{}
+		for _, m := range c.Methods {
+			methods[m.Name] = m
+		}
+		for _, om := range o.Methods {
+			m, ok := methods[om.Name]
+			name := fmt.Sprintf("%s.%s", o.Name, om.Name)
+			switch {
+			case !ok:
+				errorf("method %s was removed", name)
+			case m.Key != om.Key:
+				errorf("method %s changed key from %s to %s", name, om.Key, m.Key)
+			default:
+				errs = append(errs, checkTypes(name, "argument", om.Args, m.Args)...)
+				errs = append(errs, checkTypes(name, "result", om.Results, m.Results)...)
+			}
+		}
+	}
+
+	// Check the types that are in both descriptors. Types that are no longer
+	// serialized are irrelevant, and changes to the types that are still
+	// serialized are caught by the checks above.
+	typeDescs := map[string]typeDescriptor{}
+	for _, t := range new.Types {
+		typeDescs[t.Name] = t
+	}
+	for _, o := range old.Types {
+		t, ok := typeDescs[o.Name]
+		if !ok {
+			continue
+		}
+		switch {
+		case t.Kind != o.Kind:
+			errorf("type %s changed from %s to %s", o.Name, o.Kind, t.Kind)
+		case t.Underlying != o.Underlying:
+			errorf("type %s changed underlying type from %s to %s", o.Name, o.Underlying, t.Underlying)
+		case t.Kind == kindAutoMarshal:
+			errs = append(errs, checkFields(o, t)...)
+		}
+	}
+	return errs
+}
+
+// checkTypes checks that the types of the arguments (or results) of a method
+// haven't changed.
+func checkTypes(method, what string, old, new []string) []error {
+	if len(old) != len(new) {
+		return []error{fmt.Errorf("method %s changed from %d to %d %ss", method, len(old), len(new), what)}
+	}
+	var errs []error
+	for i := range old {
+		if old[i] != new[i] {
+			errs = append(errs, fmt.Errorf("method %s changed %s %d from %s to %s", method, what, i, old[i], new[i]))
+		}
+	}
+	return errs
+}
+
+// checkFields checks that the fields of a struct that embeds
+// weaver.AutoMarshal have evolved compatibly. Fields of a struct encoded with
+// field tags can be added and removed, as long as the types of the remaining
+// fields don't change. Fields of other structs can't change at all.
+func checkFields(old, new typeDescriptor) []error {
+	tagged := func(t typeDescriptor) bool {
+		return len(t.Fields) > 0 && t.Fields[0].Number > 0
+	}
+	switch {
+	case tagged(old) != tagged(new):
+		return []error{fmt.Errorf("type %s changed whether it is encoded with field tags", old.Name)}
+
+	case tagged(old):
+		toSchema := func(t typeDescriptor) schema {
+			s := schema{name: t.Name}
+			for _, f := range t.Fields {
+				s.fields = append(s.fields, schemaField{number: f.Number, name: f.Name, typ: f.Type})
+			}
+			return s
+		}
+		_, errs := evolve(toSchema(old), toSchema(new))
+		return errs
+
+	default:
+		if len(old.Fields) != len(new.Fields) {
+			return []error{fmt.Errorf("type %s changed from %d to %d fields. Consider encoding it with field tags.", old.Name, len(old.Fields), len(new.Fields))}
+		}
+		var errs []error
+		for i := range old.Fields {
+			if o, n := old.Fields[i], new.Fields[i]; o.Type != n.Type {
+				errs = append(errs, fmt.Errorf("type %s changed field %d from %s %s to %s %s. Consider encoding it with field tags.", old.Name, i, o.Name, o.Type, n.Name, n.Type))
+			}
+		}
+		return errs
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"encoding/hex"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/internal/net/call"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDescribe(t *testing.T) {
+	const contents = `package foo
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type Cache interface {
+	Get(context.Context, Key) (Value, error)
+	Put(context.Context, Key, Value) error
+}
+
+type cache struct {
+	weaver.Implements[Cache]
+}
+
+func (*cache) Get(context.Context, Key) (Value, error) { return Value{}, nil }
+func (*cache) Put(context.Context, Key, Value) error   { return nil }
+
+type Key string
+
+type Value struct {
+	weaver.AutoMarshal
+	Data    []byte ` + "`weaver:\"1\"`" + `
+	Version int    ` + "`weaver:\"2\"`" + `
+}
+`
+	tmp := t.TempDir()
+	save := func(f, data string) {
+		if err := os.WriteFile(filepath.Join(tmp, f), []byte(data), 0644); err != nil {
+			t.Fatalf("error writing %s: %v", f, err)
+		}
+	}
+	save("foo.go", contents)
+	save("go.mod", goModFile)
+	tidy := exec.Command("go", "mod", "tidy")
+	tidy.Dir = tmp
+	tidy.Stdout = os.Stdout
+	tidy.Stderr = os.Stderr
+	if err := tidy.Run(); err != nil {
+		t.Fatalf("go mod tidy: %v", err)
+	}
+
+	filename := filepath.Join(tmp, "api.json")
+	if err := Generate(tmp, []string{tmp}, Options{Descriptor: filename}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readDescriptor(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := func(method string) string {
+		k := call.MakeMethodKey("foo/Cache", method)
+		return hex.EncodeToString(k[:])
+	}
+	want := descriptor{
+		Components: []componentDescriptor{{
+			Name: "foo/Cache",
+			Methods: []methodDescriptor{
+				{Name: "Get", Key: key("Get"), Args: []string{"foo.Key"}, Results: []string{"foo.Value"}},
+				{Name: "Put", Key: key("Put"), Args: []string{"foo.Key", "foo.Value"}, Results: []string{}},
+			},
+		}},
+		Types: []typeDescriptor{
+			{Name: "foo.Key", Kind: kindNamed, Underlying: "string"},
+			{Name: "foo.Value", Kind: kindAutoMarshal, Fields: []fieldDescriptor{
+				{Name: "Data", Type: "[]byte", Number: 1},
+				{Name: "Version", Type: "int", Number: 2},
+			}},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("descriptor (-want +got):\n%s", diff)
+	}
+
+	// The package is compatible with itself.
+	if err := Generate(tmp, []string{tmp}, Options{CheckCompat: filename}); err != nil {
+		t.Fatalf("check compat: %v", err)
+	}
+}
+
+func TestCheckCompat(t *testing.T) {
+	// base returns a descriptor that is modified by every test case.
+	base := func() descriptor {
+		return descriptor{
+			Components: []componentDescriptor{{
+				Name: "foo/Cache",
+				Methods: []methodDescriptor{
+					{Name: "Get", Key: "01", Args: []string{"foo.Key"}, Results: []string{"foo.Value"}},
+					{Name: "Put", Key: "02", Args: []string{"foo.Key", "foo.Value"}, Results: []string{}},
+				},
+			}},
+			Types: []typeDescriptor{
+				{Name: "foo.Key", Kind: kindNamed, Underlying: "string"},
+				{Name: "foo.Value", Kind: kindAutoMarshal, Fields: []fieldDescriptor{
+					{Name: "Data", Type: "[]byte"},
+					{Name: "Version", Type: "int"},
+				}},
+				{Name: "foo.Tagged", Kind: kindAutoMarshal, Fields: []fieldDescriptor{
+					{Name: "X", Type: "int", Number: 1},
+					{Name: "Y", Type: "string", Number: 2},
+				}},
+			},
+		}
+	}
+
+	for _, c := range []struct {
+		name   string
+		modify func(*descriptor)
+		err    string // expected error, if any
+	}{
+		{"unchanged", func(*descriptor) {}, ""},
+		{"added component", func(d *descriptor) {
+			d.Components = append(d.Components, componentDescriptor{Name: "foo/Queue"})
+		}, ""},
+		{"added method", func(d *descriptor) {
+			d.Components[0].Methods = append(d.Components[0].Methods, methodDescriptor{Name: "Delete", Key: "03"})
+		}, ""},
+		{"removed type", func(d *descriptor) {
+			d.Types = d.Types[:1]
+		}, ""},
+		{"added tagged field", func(d *descriptor) {
+			d.Types[2].Fields = append(d.Types[2].Fields, fieldDescriptor{Name: "Z", Type: "bool", Number: 3})
+		}, ""},
+		{"removed tagged field", func(d *descriptor) {
+			d.Types[2].Fields = d.Types[2].Fields[1:]
+		}, ""},
+		{"removed component", func(d *descriptor) {
+			d.Components = nil
+		}, "component foo/Cache was removed"},
+		{"removed method", func(d *descriptor) {
+			d.Components[0].Methods = d.Components[0].Methods[1:]
+		}, "method foo/Cache.Get was removed"},
+		{"changed key", func(d *descriptor) {
+			d.Components[0].Methods[0].Key = "ff"
+		}, "changed key from 01 to ff"},
+		{"added argument", func(d *descriptor) {
+			d.Components[0].Methods[0].Args = append(d.Components[0].Methods[0].Args, "int")
+		}, "changed from 1 to 2 arguments"},
+		{"changed argument", func(d *descriptor) {
+			d.Components[0].Methods[1].Args[1] = "*foo.Value"
+		}, "changed argument 1 from foo.Value to *foo.Value"},
+		{"changed result", func(d *descriptor) {
+			d.Components[0].Methods[0].Results[0] = "[]byte"
+		}, "changed result 0 from foo.Value to []byte"},
+		{"changed kind", func(d *descriptor) {
+			d.Types[1].Kind = kindProto
+			d.Types[1].Fields = nil
+		}, "changed from auto_marshal to proto"},
+		{"changed underlying", func(d *descriptor) {
+			d.Types[0].Underlying = "int"
+		}, "changed underlying type from string to int"},
+		{"added field", func(d *descriptor) {
+			d.Types[1].Fields = append(d.Types[1].Fields, fieldDescriptor{Name: "Expiry", Type: "int64"})
+		}, "changed from 2 to 3 fields"},
+		{"changed field", func(d *descriptor) {
+			d.Types[1].Fields[1].Type = "int64"
+		}, "changed field 1 from Version int to Version int64"},
+		{"changed tagged field", func(d *descriptor) {
+			d.Types[2].Fields[0].Type = "int64"
+		}, "changed type from int to int64"},
+		{"untagged", func(d *descriptor) {
+			d.Types[2].Fields[0].Number = 0
+			d.Types[2].Fields[1].Number = 0
+		}, "changed whether it is encoded with field tags"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			new := base()
+			c.modify(&new)
+			errs := checkCompat(base(), new)
+			if c.err == "" {
+				if len(errs) != 0 {
+					t.Fatalf("checkCompat: unexpected errors %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), c.err) {
+				t.Fatalf("checkCompat: got errors %v, want %q", errs, c.err)
+			}
+		})
+	}
+}
@@ -25,9 +25,11 @@ import (
 	"go/types"
 	"io"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/ServiceWeaver/weaver/internal/files"
@@ -46,7 +48,11 @@ const (
 	Usage = `Generate code for a Service Weaver application.
 
 Usage:
-  weaver generate [packages]
+  weaver generate [flags] [packages]
+
+Flags:
+  --descriptor=<file>    Write an API descriptor to the provided file.
+  --check-compat=<file>  Check compatibility with the provided API descriptor.
 
 Description:
   "weaver generate" generates code for the Service Weaver applications in the provided
@@ -67,6 +73,15 @@ Description:
 
   and then use the normal "go generate" command.
 
+  "weaver generate" can also write a machine-readable API descriptor that
+  lists the components in the provided packages, the signatures and keys of
+  their methods, and the layouts of the types that the methods serialize. A
+  descriptor written for the version of an application running in production
+  can later be passed to --check-compat to check that a new version of the
+  application is wire-compatible with it. Removed components and methods,
+  changed argument and result types, and incompatible changes to serialized
+  structs are reported as errors.
+
 Examples:
   # Generate code for the package in the current directory.
   weaver generate
@@ -78,7 +93,14 @@ Examples:
   weaver generate ./foo
 
   # Generate code for all packages in all subdirectories of current directory.
-  weaver generate ./...`
+  weaver generate ./...
+
+  # Generate code, and write an API descriptor to api.json.
+  weaver generate --descriptor=api.json ./...
+
+  # Generate code, and check that the components and serialized types are
+  # wire-compatible with the ones described by old_api.json.
+  weaver generate --check-compat=old_api.json ./...`
 )
 
 // ErrorList holds a list of errors.
@@ -92,10 +114,22 @@ func (list ErrorList) Error() string {
 	return b.String()
 }
 
+// Options configures Generate.
+type Options struct {
+	// If non-empty, Descriptor is the file to which an API descriptor of the
+	// packages is written.
+	Descriptor string
+
+	// If non-empty, CheckCompat is a file holding an API descriptor written
+	// by a previous run of Generate. The packages are checked to be
+	// wire-compatible with it.
+	CheckCompat string
+}
+
 // Generate generates Service Weaver code for the specified packages.
 // The list of supplied packages are treated similarly to the arguments
 // passed to "go build" (see "go help packages" for details).
-func Generate(dir string, pkgs []string) error {
+func Generate(dir string, pkgs []string, opt Options) error {
 	fset := token.NewFileSet()
 	cfg := &packages.Config{
 		Mode:      packages.NeedName | packages.NeedSyntax | packages.NeedImports | packages.NeedTypes | packages.NeedTypesInfo,
@@ -110,6 +144,7 @@ func Generate(dir string, pkgs []string) error {
 
 	var automarshals typeutil.Map
 	var errs []error
+	var descs []descriptor
 	for _, p := range pkgList {
 		g := &generator{
 			pkg:            p,
@@ -119,10 +154,27 @@ func Generate(dir string, pkgs []string) error {
 		}
 		g.processPackage(p)
 		errs = append(errs, g.errors...)
+		if len(g.errors) == 0 {
+			descs = append(descs, g.describe())
+		}
 	}
 	if len(errs) != 0 {
 		return ErrorList(errs)
 	}
+
+	desc := merge(descs)
+	if opt.CheckCompat != "" {
+		old, err := readDescriptor(opt.CheckCompat)
+		if err != nil {
+			return err
+		}
+		if errs := checkCompat(old, desc); len(errs) != 0 {
+			return ErrorList(errs)
+		}
+	}
+	if opt.Descriptor != "" {
+		return writeDescriptor(opt.Descriptor, desc)
+	}
 	return nil
 }
 
@@ -136,6 +188,25 @@ func parseNonWeaverGenFile(fset *token.FileSet, filename string, src []byte) (*a
 	return parser.ParseFile(fset, filename, src, parser.ParseComments|parser.DeclarationErrors)
 }
 
+// inGeneratedCode returns whether the provided error is a compilation error
+// that only concerns weaver_gen.go files.
+func inGeneratedCode(err packages.Error) bool {
+	if err.Kind != packages.ListError {
+		return false
+	}
+	found := false
+	for _, line := range strings.Split(strings.TrimSpace(err.Msg), "\n") {
+		if strings.HasPrefix(line, "# ") || line == "too many errors" {
+			continue
+		}
+		if !strings.Contains(line, generatedCodeFile+":") {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
 type generator struct {
 	pkg            *packages.Package
 	tset           *typeSet
@@ -143,9 +214,11 @@ type generator struct {
 	errors         []error
 	components     []*component
 	componentImpls map[string]token.Pos
-	types          []types.Type // all types that need to be serialized
-	sizeFuncNeeded typeutil.Map // types that need a serviceweaver_size_* function
-	generated      typeutil.Map // memo cache for generateEncDecMethodsFor
+	types          []types.Type                    // all types that need to be serialized
+	sizeFuncNeeded typeutil.Map                    // types that need a serviceweaver_size_* function
+	generated      typeutil.Map                    // memo cache for generateEncDecMethodsFor
+	methodDocs     map[token.Pos]*ast.CommentGroup // see methodDoc
+	schemas        map[*types.Named]schema         // schemas of structs with field tags
 }
 
 func (g *generator) addError(pos token.Pos, err error) {
@@ -167,8 +240,14 @@ func (g *generator) errorf(pos token.Pos, format string, args ...interface{}) {
 }
 
 func (g *generator) processPackage(pkg *packages.Package) {
-	// Abort if there are any errors loading the package.
+	// Abort if there are any errors loading the package. Errors in an
+	// existing weaver_gen.go file are ignored, since the file may reference
+	// types and fields that no longer exist, and it is about to be
+	// regenerated anyway.
 	for _, err := range pkg.Errors {
+		if inGeneratedCode(err) {
+			continue
+		}
 		g.errors = append(g.errors, err)
 	}
 	if len(g.errors) > 0 {
@@ -186,6 +265,10 @@ func (g *generator) processPackage(pkg *packages.Package) {
 		}
 	}
 
+	for _, t := range g.tset.automarshalCandidates.Keys() {
+		g.findFieldTags(t.(*types.Named))
+	}
+
 	for _, t := range g.tset.automarshalCandidates.Keys() {
 		n := t.(*types.Named)
 		if errs := g.tset.checkSerializable(n); len(errs) > 0 {
@@ -207,6 +290,12 @@ func (g *generator) processPackage(pkg *packages.Package) {
 		g.findComponents(f)
 	}
 
+	// Check that the encodings of structs with field tags are compatible with
+	// the previously generated code.
+	if len(g.errors) == 0 && g.tset.automarshalCandidates.Len() > 0 {
+		g.schemas = g.checkSchemas(filepath.Join(g.pkgDir(), generatedCodeFile))
+	}
+
 	if len(g.errors) == 0 && len(g.components)+g.tset.automarshalCandidates.Len() > 0 {
 		g.generate()
 	}
@@ -285,9 +374,8 @@ func (g *generator) findAutoMarshals(f *ast.File) []*types.Named {
 				continue
 			}
 
-			// Ignore generic types. Generic types don't play well with
-			// embedded AutoMarshals. For example, consider the following type
-			// declaration:
+			// Generic types are handled separately. For example, consider the
+			// following type declaration:
 			//
 			//     type Register[A any] struct {
 			//         weaver.AutoMarshal
@@ -295,14 +383,18 @@ func (g *generator) findAutoMarshals(f *ast.File) []*types.Named {
 			//     }
 			//
 			// Is Register[A] serializable? It depends on A. Plus, we cannot
-			// really generate WeaverMarshal and WeaverUnmarshal methods for
-			// specific instantiations of Register[A]. Because of these
-			// complications, we ignore generic types.
-			//
-			// TODO(mwhittaker): Handle generics somehow?
+			// generate WeaverMarshal and WeaverUnmarshal methods for specific
+			// instantiations of Register[A]. Instead, every instantiation,
+			// e.g., Register[int], is checked when it is used, and is encoded
+			// field by field by generated functions. See isGenericAutoMarshal.
 			if n.TypeParams() != nil { // generics have non-nil TypeParams()
-				name := g.tset.typeString(n)
-				g.addError(pos, fmt.Errorf("generic struct %v cannot embed weaver.AutoMarshal. See serviceweaver.dev/docs.html#serializable-types for more information.", name))
+				for i := 0; i < t.NumFields(); i++ {
+					if _, ok := reflect.StructTag(t.Tag(i)).Lookup("weaver"); ok {
+						name := g.tset.typeString(n)
+						g.errorf(t.Field(i).Pos(), "field %s of generic struct %v has a weaver struct tag. Generic structs cannot be encoded with field tags.", t.Field(i).Name(), name)
+						break
+					}
+				}
 				continue
 			}
 
@@ -388,7 +480,7 @@ func (g *generator) processComponentImplementation(file *ast.File, spec ast.Spec
 		return
 	}
 
-	fullName := filepath.Join(componentType.Obj().Pkg().Path(), componentType.Obj().Name())
+	fullName := componentName(componentType)
 	if pos, exists := g.componentImpls[fullName]; exists {
 		g.errorf(spec.Pos(), "Duplicate implementation for component %v, other declaration: %v", fullName, g.fileset.Position(pos))
 		return
@@ -401,10 +493,12 @@ func (g *generator) processComponentImplementation(file *ast.File, spec ast.Spec
 	}
 
 	comp := &component{
-		name:      componentType.Obj().Name(),
+		name:      g.tset.typeString(componentType),
+		ident:     componentIdent(componentType),
 		pos:       spec.Pos(),
 		fullName:  fullName,
 		implName:  implName,
+		iface:     componentType,
 		intf:      componentType.Underlying().(*types.Interface),
 		file:      file,
 		router:    routerType,
@@ -421,11 +515,53 @@ func (g *generator) processComponentImplementation(file *ast.File, spec ast.Spec
 	g.components = append(g.components, comp)
 }
 
+// componentName returns the name with which the provided component interface
+// is registered, e.g., "github.com/foo/Cache". The name of an instantiated
+// generic interface includes its type arguments, qualified by the full paths
+// of their packages, e.g., "github.com/foo/Cache[github.com/foo.User]".
+func componentName(t *types.Named) string {
+	name := filepath.Join(t.Obj().Pkg().Path(), t.Obj().Name())
+	n := t.TypeArgs().Len()
+	if n == 0 {
+		return name
+	}
+	args := make([]string, n)
+	for i := 0; i < n; i++ {
+		args[i] = fullTypeString(t.TypeArgs().At(i))
+	}
+	return fmt.Sprintf("%s[%s]", name, strings.Join(args, ","))
+}
+
+// componentIdent returns a valid Go identifier for the provided component
+// interface, used to name the stubs and functions generated for it. For a
+// non-generic interface Foo, it returns Foo. For an instantiated generic
+// interface, it returns the names of the interface and its named and basic
+// type arguments, followed by a hash of the component name to ensure
+// uniqueness. For example, Cache[User] -> Cache_User_2b8a1c9e.
+func componentIdent(t *types.Named) string {
+	if t.TypeArgs().Len() == 0 {
+		return t.Obj().Name()
+	}
+	parts := []string{t.Obj().Name()}
+	for i := 0; i < t.TypeArgs().Len(); i++ {
+		switch x := t.TypeArgs().At(i).(type) {
+		case *types.Named:
+			parts = append(parts, x.Obj().Name())
+		case *types.Basic:
+			parts = append(parts, x.Name())
+		}
+	}
+	hash := sha256.Sum256([]byte(componentName(t)))
+	return fmt.Sprintf("%s_%x", strings.Join(parts, "_"), hash[:4])
+}
+
 type component struct {
-	name          string           // component interface name
+	name          string           // component interface name, e.g., Cache[User]
+	ident         string           // identifier used to name the generated code
 	pos           token.Pos        // Location of component implementation
 	fullName      string           // package-prefixed component interface name
 	implName      string           // name of the component implementation type
+	iface         *types.Named     // component interface type
 	intf          *types.Interface // component's interface type
 	file          *ast.File        // file that contains component's implementation
 	methods       []*types.Func
@@ -433,6 +569,21 @@ type component struct {
 	hasConfig     bool            // True iff implementation contains a weaver.WithConfig field.
 	routingKey    types.Type      // routing key, or nil if there is no router.
 	routedMethods map[string]bool // the set of methods with a routing function
+
+	// options holds the call options of the methods that have //weaver:
+	// directives, keyed by method name.
+	options map[string]methodOptions
+}
+
+// methodOptions holds the call options of a method, as declared with //weaver:
+// directives in the method's doc comment. See codegen.MethodOptions.
+type methodOptions struct {
+	timeout    time.Duration
+	retries    int
+	backoff    time.Duration
+	idempotent bool
+	hedge      time.Duration
+	hedgeP95   bool
 }
 
 // processMethods fills in the method information for the given component.
@@ -461,10 +612,22 @@ func (g *generator) processMethods(comp *component) {
 			continue
 		}
 
-		// All arguments but context.Context must be serializable.
+		// All arguments but context.Context must be serializable. The last
+		// argument may also be a weaver.StreamReader[T] or a
+		// weaver.StreamWriter[T], where T is serializable.
 		for i := 1; i < mt.Params().Len(); i++ {
 			arg := mt.Params().At(i)
-			errs := g.tset.checkSerializable(arg.Type())
+			t := arg.Type()
+			if isWeaverStreamReader(t) || isWeaverStreamWriter(t) {
+				if i != mt.Params().Len()-1 {
+					g.errorf(m.Pos(), bad("argument",
+						"Argument %d has type %v, but only the last argument can be a weaver.StreamReader or a weaver.StreamWriter.",
+						i, pretty(t)))
+					continue
+				}
+				t = t.(*types.Named).TypeArgs().At(0)
+			}
+			errs := g.tset.checkSerializable(t)
 			for _, err := range errs {
 				g.addError(arg.Pos(), err)
 			}
@@ -474,7 +637,7 @@ func (g *generator) processMethods(comp *component) {
 					"Argument %d has type %v, which is not serializable. All arguments, besides the initial context.Context, must be serializable.",
 					i, pretty(arg.Type())))
 			}
-			g.types = append(g.types, arg.Type())
+			g.types = append(g.types, t)
 		}
 
 		// Last result must be error.
@@ -494,6 +657,21 @@ func (g *generator) processMethods(comp *component) {
 			g.types = append(g.types, res.Type())
 		}
 
+		// Parse the call options, if any.
+		if doc := g.methodDoc(m); doc != nil {
+			opts, ok := g.parseDirectives(doc)
+			if ok && opts != (methodOptions{}) {
+				if elem, _ := streamArg(mt); elem != nil {
+					g.errorf(m.Pos(), "Method %s of Service Weaver component %q is a streaming method. Streaming methods cannot have call options.", m.Name(), comp.name)
+					continue
+				}
+				if comp.options == nil {
+					comp.options = map[string]methodOptions{}
+				}
+				comp.options[m.Name()] = opts
+			}
+		}
+
 		comp.methods = append(comp.methods, m)
 	}
 
@@ -520,6 +698,108 @@ func (g *generator) processMethods(comp *component) {
 	})
 }
 
+// methodDoc returns the doc comment of the provided interface method, or nil
+// if the method has no doc comment or is not declared in the package.
+func (g *generator) methodDoc(m *types.Func) *ast.CommentGroup {
+	if g.methodDocs == nil {
+		g.methodDocs = map[token.Pos]*ast.CommentGroup{}
+		for _, f := range g.pkg.Syntax {
+			ast.Inspect(f, func(n ast.Node) bool {
+				iface, ok := n.(*ast.InterfaceType)
+				if !ok {
+					return true
+				}
+				for _, field := range iface.Methods.List {
+					if field.Doc != nil && len(field.Names) == 1 {
+						g.methodDocs[field.Names[0].Pos()] = field.Doc
+					}
+				}
+				return true
+			})
+		}
+	}
+	return g.methodDocs[m.Pos()]
+}
+
+// parseDirectives parses the //weaver: directives in the provided method doc
+// comment, which declare the method's call options. For example:
+//
+//	type Cache interface {
+//		// Get returns the value associated with the provided key.
+//		//
+//		//weaver:idempotent
+//		//weaver:timeout 1s
+//		//weaver:retries 3
+//		//weaver:backoff 10ms
+//		//weaver:hedge p95
+//		Get(context.Context, string) (string, error)
+//	}
+//
+// parseDirectives returns false if any of the directives are invalid.
+func (g *generator) parseDirectives(doc *ast.CommentGroup) (methodOptions, bool) {
+	const prefix = "//weaver:"
+	var opts methodOptions
+	ok := true
+	for _, c := range doc.List {
+		if !strings.HasPrefix(c.Text, prefix) {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(c.Text, prefix))
+		if len(fields) == 0 {
+			g.errorf(c.Pos(), "Invalid directive %q.", c.Text)
+			ok = false
+			continue
+		}
+		name, args := fields[0], fields[1:]
+
+		var err error
+		switch name {
+		case "idempotent":
+			if len(args) != 0 {
+				err = fmt.Errorf("takes no arguments")
+			}
+			opts.idempotent = true
+		case "timeout", "backoff":
+			var d time.Duration
+			if len(args) != 1 {
+				err = fmt.Errorf("takes a single duration argument (e.g., 1s)")
+			} else if d, err = time.ParseDuration(args[0]); err == nil && d <= 0 {
+				err = fmt.Errorf("duration must be positive")
+			}
+			if name == "timeout" {
+				opts.timeout = d
+			} else {
+				opts.backoff = d
+			}
+		case "hedge":
+			if len(args) != 1 {
+				err = fmt.Errorf("takes a single duration argument (e.g., 50ms) or p95")
+			} else if args[0] == "p95" {
+				opts.hedgeP95 = true
+			} else if opts.hedge, err = time.ParseDuration(args[0]); err == nil && opts.hedge <= 0 {
+				err = fmt.Errorf("duration must be positive")
+			}
+		case "retries":
+			if len(args) != 1 {
+				err = fmt.Errorf("takes a single integer argument")
+			} else if opts.retries, err = strconv.Atoi(args[0]); err == nil && opts.retries < 0 {
+				err = fmt.Errorf("number of retries must not be negative")
+			}
+		default:
+			err = fmt.Errorf("unknown directive (must be one of idempotent, timeout, retries, backoff, or hedge)")
+		}
+		if err != nil {
+			g.errorf(c.Pos(), "Invalid directive %q: %v.", c.Text, err)
+			ok = false
+		}
+	}
+	if ok && (opts.hedge > 0 || opts.hedgeP95) && !opts.idempotent {
+		g.errorf(doc.Pos(), "Invalid directives: only idempotent methods can be hedged (add //weaver:idempotent).")
+		ok = false
+	}
+	return opts, ok
+}
+
 // routerMethods returns the routing key and the set of routed methods for comp.
 //
 // A developer can annotate a Service Weaver component with a router, like this:
@@ -565,6 +845,11 @@ func (g *generator) routerMethods(comp *component) (types.Type, map[string]bool,
 		}
 		mt := m.Type().(*types.Signature)
 
+		// Streaming methods can't be routed.
+		if elem, _ := streamArg(componentMethod); elem != nil {
+			return nil, nil, fmt.Errorf("Streaming method %q of %q cannot be routed", name, comp.name)
+		}
+
 		// Router method args must match component method args.
 		if !types.Identical(mt.Params(), componentMethod.Params()) {
 			return nil, nil, fmt.Errorf("Component %q method arguments %s do not match router method arguments %s",
@@ -711,13 +996,14 @@ func (g *generator) generateRegisteredComponents(p printFn) {
 	p(``)
 	p(`func init() {`)
 	for _, comp := range g.components {
-		name := comp.name
+		name := comp.ident
+		iface := g.tset.genTypeString(comp.iface)
 
 		// E.g.,
 		//   func(impl any, caller string, tracer trace.Tracer) any {
 		//       return foo_local_stub{imple: impl.(Foo), tracer: tracer, ...}
 		//   }
-		localStubFn := fmt.Sprintf(`func(impl any, tracer %v) any { return %s_local_stub{impl: impl.(%s), tracer: tracer } }`, g.trace().qualify("Tracer"), notExported(name), name)
+		localStubFn := fmt.Sprintf(`func(impl any, tracer %v) any { return %s_local_stub{impl: impl.(%s), tracer: tracer } }`, g.trace().qualify("Tracer"), notExported(name), iface)
 
 		// E.g.,
 		//   func(stub *codegen.Stub, caller string) any {
@@ -734,7 +1020,7 @@ func (g *generator) generateRegisteredComponents(p printFn) {
 		//   func(impl any, addLoad func(uint64, float64)) codegen.Server {
 		//       return foo_server_stub{impl: impl.(Foo), addLoad: addLoad}
 		//   }
-		serverStubFn := fmt.Sprintf(`func(impl any, addLoad func(uint64, float64)) %s { return %s_server_stub{impl: impl.(%s), addLoad: addLoad } }`, g.codegen().qualify("Server"), notExported(name), name)
+		serverStubFn := fmt.Sprintf(`func(impl any, addLoad func(uint64, float64)) %s { return %s_server_stub{impl: impl.(%s), addLoad: addLoad } }`, g.codegen().qualify("Server"), notExported(name), iface)
 
 		// E.g.,
 		//	weaver.Register(weaver.Registration{
@@ -747,7 +1033,7 @@ func (g *generator) generateRegisteredComponents(p printFn) {
 		// To get a reflect.Type for an interface, we have to first get a type
 		// of its pointer and then resolve the underlying type. See:
 		//   https://pkg.go.dev/reflect#example-TypeOf
-		p(`		Iface: %s((*%s)(nil)).Elem(),`, reflect.qualify("TypeOf"), name)
+		p(`		Iface: %s((*%s)(nil)).Elem(),`, reflect.qualify("TypeOf"), iface)
 		p(`		New: func() any { return &%s{} },`, comp.implName)
 		if comp.hasConfig {
 			p(`		ConfigFn: func(i any) any { return i.(*%s).WithConfig.Config() },`, comp.implName)
@@ -755,6 +1041,36 @@ func (g *generator) generateRegisteredComponents(p printFn) {
 		if comp.router != nil {
 			p(`		Routed: true,`)
 		}
+		if len(comp.options) > 0 {
+			p(`		MethodOptions: map[string]%s{`, g.codegen().qualify("MethodOptions"))
+			for _, m := range comp.methods {
+				opts, ok := comp.options[m.Name()]
+				if !ok {
+					continue
+				}
+				var fields []string
+				if opts.timeout > 0 {
+					fields = append(fields, fmt.Sprintf("Timeout: %d /* %v */", opts.timeout, opts.timeout))
+				}
+				if opts.retries > 0 {
+					fields = append(fields, fmt.Sprintf("Retries: %d", opts.retries))
+				}
+				if opts.backoff > 0 {
+					fields = append(fields, fmt.Sprintf("Backoff: %d /* %v */", opts.backoff, opts.backoff))
+				}
+				if opts.idempotent {
+					fields = append(fields, "Idempotent: true")
+				}
+				if opts.hedge > 0 {
+					fields = append(fields, fmt.Sprintf("Hedge: %d /* %v */", opts.hedge, opts.hedge))
+				}
+				if opts.hedgeP95 {
+					fields = append(fields, "HedgeP95: true")
+				}
+				p(`			%q: {%s},`, m.Name(), strings.Join(fields, ", "))
+			}
+			p(`		},`)
+		}
 		p(`		LocalStubFn: %s,`, localStubFn)
 		p(`		ClientStubFn: %s,`, clientStubFn)
 		p(`		ServerStubFn: %s,`, serverStubFn)
@@ -771,10 +1087,10 @@ func (g *generator) generateLocalStubs(p printFn) {
 
 	var b strings.Builder
 	for _, comp := range g.components {
-		stub := notExported(comp.name) + "_local_stub"
+		stub := notExported(comp.ident) + "_local_stub"
 		p(``)
 		p(`type %s struct{`, stub)
-		p(`	impl %s`, comp.name)
+		p(`	impl %s`, g.tset.genTypeString(comp.iface))
 		p(`	tracer %s`, g.trace().qualify("Tracer"))
 		p(`}`)
 		for _, m := range comp.methods {
@@ -822,7 +1138,7 @@ func (g *generator) generateClientStubs(p printFn) {
 
 	var b strings.Builder
 	for _, comp := range g.components {
-		stub := notExported(comp.name) + "_client_stub"
+		stub := notExported(comp.ident) + "_client_stub"
 		p(``)
 		p(`type %s struct{`, stub)
 		p(`	stub %s`, g.codegen().qualify("Stub"))
@@ -847,6 +1163,14 @@ func (g *generator) generateClientStubs(p printFn) {
 			p(``)
 			p(`func (s %s) %s(%s) (%s) {`, stub, m.Name(), g.args(mt), g.returns(mt))
 
+			// The stream argument of a streaming method, if any, is not
+			// encoded with the other arguments.
+			end := mt.Params().Len()
+			elem, isReader := streamArg(mt)
+			if elem != nil {
+				end--
+			}
+
 			// Update metrics.
 			p(`	// Update metrics.`)
 			p(`	start := %s()`, g.time().qualify("Now"))
@@ -883,10 +1207,10 @@ func (g *generator) generateClientStubs(p printFn) {
 			p(``)
 
 			preallocated := false
-			if mt.Params().Len() > 1 {
+			if end > 1 {
 				// Preallocate a perfectly sized buffer if possible.
 				canPreallocate := true
-				for i := 1; i < mt.Params().Len(); i++ { // Skip initial context.Context
+				for i := 1; i < end; i++ { // Skip initial context.Context
 					if !g.preallocatable(mt.Params().At(i).Type()) {
 						canPreallocate = false
 						break
@@ -896,7 +1220,7 @@ func (g *generator) generateClientStubs(p printFn) {
 					p("")
 					p("	// Preallocate a buffer of the right size.")
 					p("	size := 0")
-					for i := 1; i < mt.Params().Len(); i++ {
+					for i := 1; i < end; i++ {
 						at := mt.Params().At(i).Type()
 						p("	size += %s", g.size(fmt.Sprintf("a%d", i-1), at))
 					}
@@ -908,14 +1232,14 @@ func (g *generator) generateClientStubs(p printFn) {
 
 			// Invoke call.Encode.
 			b.Reset()
-			if mt.Params().Len() > 1 {
+			if end > 1 {
 				p(``)
 				p(`	// Encode arguments.`)
 				if !preallocated {
 					p("	enc := %s", g.codegen().qualify("NewEncoder()"))
 				}
 			}
-			for i := 1; i < mt.Params().Len(); i++ { // Skip initial context.Context
+			for i := 1; i < end; i++ { // Skip initial context.Context
 				at := mt.Params().At(i).Type()
 				arg := fmt.Sprintf("a%d", i-1)
 				p(`	%s`, g.encode("enc", arg, at))
@@ -932,7 +1256,7 @@ func (g *generator) generateClientStubs(p printFn) {
 				for i := 1; i < n; i++ {
 					args[i] = fmt.Sprintf("a%d", i-1)
 				}
-				p(`	shardKey := _hash%s(r.%s(%s))`, exported(comp.name), m.Name(), strings.Join(args, ", "))
+				p(`	shardKey := _hash%s(r.%s(%s))`, exported(comp.ident), m.Name(), strings.Join(args, ", "))
 			} else {
 				p(`	var shardKey uint64`)
 			}
@@ -941,14 +1265,37 @@ func (g *generator) generateClientStubs(p printFn) {
 			p(``)
 			p(`	// Call the remote method.`)
 			data := "nil"
-			if mt.Params().Len() > 1 {
+			if end > 1 {
 				data = "enc.Data()"
 				p(`	s.%sMetrics.BytesRequest.Put(float64(len(enc.Data())))`, notExported(m.Name()))
 			} else {
 				p(`	s.%sMetrics.BytesRequest.Put(0)`, notExported(m.Name()))
 			}
 			p(`	var results []byte`)
-			p(`	results, err = s.stub.Run(ctx, %d, %s, shardKey)`, methodIndex[m.Name()], data)
+			if elem == nil {
+				p(`	results, err = s.stub.Run(ctx, %d, %s, shardKey)`, methodIndex[m.Name()], data)
+			} else {
+				// Cancel the call if the caller's stream fails.
+				p(`	ctx, cancel := %s(ctx)`, g.tset.importPackage("context", "context").qualify("WithCancel"))
+				p(`	defer cancel()`)
+				p(`	var stream %s`, g.codegen().qualify("ClientStream"))
+				p(`	stream, err = s.stub.Stream(ctx, %d, %s, shardKey)`, methodIndex[m.Name()], data)
+				p(`	if err != nil {`)
+				p(`		err = %s(%s, err)`, g.errorsPackage().qualify("Join"), g.weaver().qualify("RemoteCallError"))
+				p(`		return`)
+				p(`	}`)
+				p(``)
+				p(`	// Stream the values.`)
+				arg := fmt.Sprintf("a%d", end-1)
+				if isReader {
+					p(`	if err = %s(stream, %s.Next, %s); err != nil {`, g.codegen().qualify("SendStream"), arg, g.streamEncoder(elem))
+				} else {
+					p(`	if err = %s(stream, %s.Send, %s); err != nil {`, g.codegen().qualify("RecvStream"), arg, g.streamDecoder(elem))
+				}
+				p(`		return`)
+				p(`	}`)
+				p(`	results, err = stream.Result()`)
+			}
 			p(`	if err != nil {`)
 			p(`		err = %s(%s, err)`, g.errorsPackage().qualify("Join"), g.weaver().qualify("RemoteCallError"))
 			p(`		return`)
@@ -984,6 +1331,44 @@ func (g *generator) generateClientStubs(p printFn) {
 	}
 }
 
+// streamArg returns the element type of the weaver.StreamReader or
+// weaver.StreamWriter argument of the provided signature, and whether the
+// argument is a weaver.StreamReader. If the signature doesn't have a stream
+// argument, streamArg returns a nil type. Note that a stream argument is
+// always the last argument.
+func streamArg(sig *types.Signature) (types.Type, bool) {
+	n := sig.Params().Len()
+	if n < 2 {
+		return nil, false
+	}
+	t := sig.Params().At(n - 1).Type()
+	if !isWeaverStreamReader(t) && !isWeaverStreamWriter(t) {
+		return nil, false
+	}
+	return t.(*types.Named).TypeArgs().At(0), isWeaverStreamReader(t)
+}
+
+// streamEncoder returns a function literal that encodes stream values of the
+// provided type.
+func (g *generator) streamEncoder(t types.Type) string {
+	return fmt.Sprintf("func(enc *%s, v %s) { %s }",
+		g.codegen().qualify("Encoder"), g.tset.genTypeString(t), g.encode("enc", "v", t))
+}
+
+// streamDecoder returns a function literal that decodes stream values of the
+// provided type.
+func (g *generator) streamDecoder(t types.Type) string {
+	dec := g.codegen().qualify("Decoder")
+	if x, ok := t.(*types.Pointer); ok && (g.tset.isProto(x) || g.tset.hasMarshalBinary(x)) {
+		// See generateClientStubs for why a pointer *t where t is a proto or
+		// BinaryUnmarshaler is decoded into a temporary value.
+		return fmt.Sprintf("func(dec *%s) %s { var tmp %s; %s; return &tmp }",
+			dec, g.tset.genTypeString(t), g.tset.genTypeString(x.Elem()), g.decode("dec", "&tmp", x.Elem()))
+	}
+	return fmt.Sprintf("func(dec *%s) (v %s) { %s; return }",
+		dec, g.tset.genTypeString(t), g.decode("dec", "&v", t))
+}
+
 // args returns a textual representation of the arguments of the provided
 // signature. The first argument must be a context.Context. The returned code
 // names the first argument ctx and all subsequent arguments a0, a1, and so on.
@@ -1068,6 +1453,9 @@ func (g *generator) isWeaverEncoded(t types.Type) bool {
 		return true
 
 	case *types.Named:
+		if _, ok := x.Underlying().(*types.Interface); ok {
+			return true
+		}
 		if s, ok := x.Underlying().(*types.Struct); ok {
 			for i := 0; i < s.NumFields(); i++ {
 				f := s.Field(i)
@@ -1299,17 +1687,22 @@ func (g *generator) generateServerStubs(p printFn) {
 	var b strings.Builder
 
 	for _, comp := range g.components {
-		stub := fmt.Sprintf("%s_server_stub", notExported(comp.name))
+		stub := fmt.Sprintf("%s_server_stub", notExported(comp.ident))
 		p(``)
 		p(`type %s struct{`, stub)
-		p(`	impl %s`, comp.name)
+		p(`	impl %s`, g.tset.genTypeString(comp.iface))
 		p(`	addLoad func(key uint64, load float64)`)
 		p(`}`)
 		p(``)
 		p(`// GetStubFn implements the stub.Server interface.`)
 		p(`func (s %s) GetStubFn(method string) func(ctx context.Context, args []byte) ([]byte, error) {`, stub)
 		p(`	switch method {`)
+		var streaming []*types.Func
 		for _, m := range comp.methods {
+			if elem, _ := streamArg(m.Type().(*types.Signature)); elem != nil {
+				streaming = append(streaming, m)
+				continue
+			}
 			p(`	case "%s":`, m.Name())
 			p(`		return s.%s`, notExported(m.Name()))
 		}
@@ -1318,13 +1711,38 @@ func (g *generator) generateServerStubs(p printFn) {
 		p(`	}`)
 		p(`}`)
 
+		if len(streaming) > 0 {
+			p(``)
+			p(`// GetStreamStubFn implements the codegen.StreamServer interface.`)
+			p(`func (s %s) GetStreamStubFn(method string) func(ctx context.Context, args []byte, stream %s) ([]byte, error) {`, stub, g.codegen().qualify("ServerStream"))
+			p(`	switch method {`)
+			for _, m := range streaming {
+				p(`	case "%s":`, m.Name())
+				p(`		return s.%s`, notExported(m.Name()))
+			}
+			p(`	default:`)
+			p(`		return nil`)
+			p(`	}`)
+			p(`}`)
+		}
+
 		// Generate server stub implementation for the methods exported by the component.
 		for _, m := range comp.methods {
 			mt := m.Type().(*types.Signature)
+			end := mt.Params().Len()
+			elem, isReader := streamArg(mt)
+			if elem != nil {
+				end--
+			}
 
 			p(``)
-			p(`func (s %s) %s(ctx context.Context, args []byte) (res []byte, err error) {`,
-				stub, notExported(m.Name()))
+			if elem == nil {
+				p(`func (s %s) %s(ctx context.Context, args []byte) (res []byte, err error) {`,
+					stub, notExported(m.Name()))
+			} else {
+				p(`func (s %s) %s(ctx context.Context, args []byte, stream %s) (res []byte, err error) {`,
+					stub, notExported(m.Name()), g.codegen().qualify("ServerStream"))
+			}
 
 			// Handle errors triggered during execution.
 			p(`	// Catch and return any panics detected during encoding/decoding/rpc.`)
@@ -1334,13 +1752,13 @@ func (g *generator) generateServerStubs(p printFn) {
 			p(`		}`)
 			p(`	}()`)
 
-			if mt.Params().Len() > 1 {
+			if end > 1 {
 				p(``)
 				p(`	// Decode arguments.`)
 				p(`	dec := %s(args)`, g.codegen().qualify("NewDecoder"))
 			}
 			b.Reset()
-			for i := 1; i < mt.Params().Len(); i++ { // Skip initial context.Context
+			for i := 1; i < end; i++ { // Skip initial context.Context
 				at := mt.Params().At(i).Type()
 				arg := fmt.Sprintf("a%d", i-1)
 				if x, ok := at.(*types.Pointer); ok && (g.tset.isProto(x) || g.tset.hasMarshalBinary(x)) {
@@ -1358,6 +1776,17 @@ func (g *generator) generateServerStubs(p printFn) {
 				}
 			}
 
+			if elem != nil {
+				p(``)
+				p(`	// Create the stream.`)
+				arg := fmt.Sprintf("a%d", end-1)
+				if isReader {
+					p(`	%s := %s(stream.Recv, %s)`, arg, g.codegen().qualify("NewStreamReader"), g.streamDecoder(elem))
+				} else {
+					p(`	%s := %s(stream.Send, %s)`, arg, g.codegen().qualify("NewStreamWriter"), g.streamEncoder(elem))
+				}
+			}
+
 			b.Reset()
 			fmt.Fprintf(&b, "ctx")
 			for i := 1; i < mt.Params().Len(); i++ {
@@ -1372,7 +1801,7 @@ func (g *generator) generateServerStubs(p printFn) {
 			// Add load, if needed.
 			if comp.routedMethods[m.Name()] {
 				p(`     var r %s`, g.tset.genTypeString(comp.router))
-				p(`	s.addLoad(_hash%s(r.%s(%s)), 1.0)`, exported(comp.name), m.Name(), argList)
+				p(`	s.addLoad(_hash%s(r.%s(%s)), 1.0)`, exported(comp.ident), m.Name(), argList)
 			}
 
 			b.Reset()
@@ -1436,8 +1865,19 @@ func (g *generator) generateAutoMarshalMethods(p printFn) {
 
 		// Generate AutoMarshal assertion.
 		p(``)
+		if s, ok := g.schemas[t.(*types.Named)]; ok {
+			p(`// Fields of %s, used by "weaver generate" to detect incompatible changes.`, t.(*types.Named).Obj().Name())
+			p(`%s%v`, schemaDirective, s)
+		}
 		p(`var _ %s = &%s{}`, g.codegen().qualify("AutoMarshal"), ts(t))
 
+		// Register error types, so that errors returned by remote method
+		// calls can be used with errors.As.
+		if implementsError(t) {
+			p(``)
+			p(`func init() { %s[%s]() }`, g.codegen().qualify("RegisterSerializable"), ts(t))
+		}
+
 		// Generate WeaverMarshal method.
 		fmt := g.tset.importPackage("fmt", "fmt")
 		p(``)
@@ -1445,12 +1885,28 @@ func (g *generator) generateAutoMarshalMethods(p printFn) {
 		p(`	if x == nil {`)
 		p(`		panic(%s("%s.WeaverMarshal: nil receiver"))`, fmt.qualify("Errorf"), ts(t))
 		p(`	}`)
+		if g.tset.isRecursive(t) {
+			// Guard against cyclic values.
+			p(`	enc.Nest()`)
+			p(`	defer enc.Unnest()`)
+		}
+		numbers, tagged := g.tset.fieldNumbers(t)
 		for i := 0; i < s.NumFields(); i++ {
 			fi := s.Field(i)
-			if !isWeaverAutoMarshal(fi.Type()) {
+			if isWeaverAutoMarshal(fi.Type()) {
+				continue
+			}
+			innerTypes = append(innerTypes, fi.Type())
+			if !tagged {
 				p(`	%s`, g.encode("enc", "x."+fi.Name(), fi.Type()))
-				innerTypes = append(innerTypes, fi.Type())
+				continue
 			}
+			p(`	f%d := enc.BeginField(%d)`, numbers[i], numbers[i])
+			p(`	%s`, g.encode("enc", "x."+fi.Name(), fi.Type()))
+			p(`	enc.EndField(f%d)`, numbers[i])
+		}
+		if tagged {
+			p(`	enc.EndFields()`)
 		}
 		p(`}`)
 
@@ -1460,11 +1916,36 @@ func (g *generator) generateAutoMarshalMethods(p printFn) {
 		p(`	if x == nil {`)
 		p(`		panic(%s("%s.WeaverUnmarshal: nil receiver"))`, fmt.qualify("Errorf"), ts(t))
 		p(`	}`)
-		for i := 0; i < s.NumFields(); i++ {
-			fi := s.Field(i)
-			if !isWeaverAutoMarshal(fi.Type()) {
-				p(`	%s`, g.decode("dec", "&x."+fi.Name(), fi.Type()))
+		if g.tset.isRecursive(t) {
+			// Guard against maliciously deep encodings.
+			p(`	dec.Nest()`)
+			p(`	defer dec.Unnest()`)
+		}
+		if !tagged {
+			for i := 0; i < s.NumFields(); i++ {
+				fi := s.Field(i)
+				if !isWeaverAutoMarshal(fi.Type()) {
+					p(`	%s`, g.decode("dec", "&x."+fi.Name(), fi.Type()))
+				}
 			}
+		} else {
+			// Missing fields have their zero values, and unknown fields are
+			// skipped.
+			p(`	*x = %s{}`, ts(t))
+			p(`	for {`)
+			p(`		n, fdec := dec.Field()`)
+			p(`		switch n {`)
+			p(`		case 0:`)
+			p(`			return`)
+			for i := 0; i < s.NumFields(); i++ {
+				fi := s.Field(i)
+				if !isWeaverAutoMarshal(fi.Type()) {
+					p(`		case %d:`, numbers[i])
+					p(`			%s`, g.decode("fdec", "&x."+fi.Name(), fi.Type()))
+				}
+			}
+			p(`		}`)
+			p(`	}`)
 		}
 		p(`}`)
 
@@ -1492,8 +1973,8 @@ func (g *generator) generateRouterMethods(p printFn) {
 
 // generateRouterMethodsFor generates router methods for the provided router type.
 func (g *generator) generateRouterMethodsFor(p printFn, comp *component, t types.Type) {
-	p(`// _hash%s returns a 64 bit hash of the provided value.`, exported(comp.name))
-	p(`func _hash%s(r %s) uint64 {`, exported(comp.name), g.tset.genTypeString(t))
+	p(`// _hash%s returns a 64 bit hash of the provided value.`, exported(comp.ident))
+	p(`func _hash%s(r %s) uint64 {`, exported(comp.ident), g.tset.genTypeString(t))
 	p(`	var h %s`, g.codegen().qualify("Hasher"))
 	if isPrimitiveRouter(t.Underlying()) {
 		tname := t.Underlying().String()
@@ -1510,8 +1991,8 @@ func (g *generator) generateRouterMethodsFor(p printFn, comp *component, t types
 	p(`}`)
 	p(``)
 
-	p(`// _orderedCode%s returns an order-preserving serialization of the provided value.`, exported(comp.name))
-	p(`func _orderedCode%s(r %s) %s {`, exported(comp.name), g.tset.genTypeString(t), g.codegen().qualify("OrderedCode"))
+	p(`// _orderedCode%s returns an order-preserving serialization of the provided value.`, exported(comp.ident))
+	p(`func _orderedCode%s(r %s) %s {`, exported(comp.ident), g.tset.genTypeString(t), g.codegen().qualify("OrderedCode"))
 	p(`	var enc %s`, g.codegen().qualify("OrderedEncoder"))
 	if isPrimitiveRouter(t.Underlying()) {
 		p(`	enc.Write%s(%s(r))`, exported(t.Underlying().String()), t.Underlying().String())
@@ -1570,6 +2051,7 @@ func (g *generator) encode(stub, e string, t types.Type) string {
 	// enc(stub, e: type t u) = stub.EncodeProto(&e)           // t implements proto.Message
 	// enc(stub, e: type t u) = (e).WeaverMarshal(stub)         // t implements AutoMarshal
 	// enc(stub, e: type t u) = stub.EncodeBinaryMarshaler(&e) // t implements BinaryMarshaler
+	// enc(stub, e: type t u) = stub.Interface(e)              // under(u) = interface{...}
 	// enc(stub, e: type t u) = serviceweaver_enc_[t](&stub, &e)       // under(u) = struct{...}
 	// enc(stub, e: type t u) = enc(&stub, under(t)(e))        // otherwise
 	switch x := t.(type) {
@@ -1612,6 +2094,9 @@ func (g *generator) encode(stub, e string, t types.Type) string {
 			return fmt.Sprintf("%s.EncodeBinaryMarshaler(%s)", stub, ref(e))
 		}
 		under := x.Underlying()
+		if _, ok := under.(*types.Interface); ok {
+			return fmt.Sprintf("%s.Interface(%s)", stub, e)
+		}
 		if _, ok := under.(*types.Struct); ok {
 			return fmt.Sprintf("%s(%s, %s)", f(x), stub, ref(e))
 		}
@@ -1643,6 +2128,7 @@ func (g *generator) decode(stub, v string, t types.Type) string {
 	// dec(stub, v: type t u) = stub.DecodeProto(v)             // t implements proto.Message
 	// dec(stub, v: type t u) = (v).WeaverUnmarshal(stub)        // t implements AutoMarshal
 	// dec(stub, v: type t u) = stub.DecodeBinaryUnmarshaler(v) // t implements BinaryUnmarshaler
+	// dec(stub, v: type t u) = stub.Interface(v)                // under(u) = interface{...}
 	// dec(stub, v: type t u) = serviceweaver_dec_[t](stub, v)          // under(u) = struct{...}
 	// dec(stub, v: type t u) = dec(stub, (*under(t))(v))       // otherwise
 	switch x := t.(type) {
@@ -1685,6 +2171,9 @@ func (g *generator) decode(stub, v string, t types.Type) string {
 			return fmt.Sprintf("%s.DecodeBinaryUnmarshaler(%s)", stub, v)
 		}
 		under := x.Underlying()
+		if _, ok := under.(*types.Interface); ok {
+			return fmt.Sprintf("%s.Interface(%s)", stub, v)
+		}
 		if _, ok := under.(*types.Struct); ok {
 			return fmt.Sprintf("%s(%s, %s)", f(x), stub, v)
 		}
@@ -1853,6 +2342,40 @@ func (g *generator) generateEncDecMethodsFor(p printFn, t types.Type) {
 			// enc.EncodeProto(x), dec.DecodeBinaryUnmarshaler(x)).
 			return
 		}
+		if _, ok := x.Underlying().(*types.Interface); ok {
+			// Interface types don't need encoding or decoding methods
+			// either. Instead, we call enc.Interface(x) and dec.Interface(x).
+			return
+		}
+		if s, ok := x.Underlying().(*types.Struct); ok {
+			// An instantiation of a generic AutoMarshal struct, e.g.,
+			// Pair[int], is encoded field by field.
+			var fields []*types.Var
+			for i := 0; i < s.NumFields(); i++ {
+				if f := s.Field(i); !isWeaverAutoMarshal(f.Type()) {
+					fields = append(fields, f)
+					g.generateEncDecMethodsFor(p, f.Type())
+				}
+			}
+
+			// Note that arg is never nil.
+			p(``)
+			p(`func serviceweaver_enc_%s(enc *%s, arg *%s) {`, sanitize(x), g.codegen().qualify("Encoder"), ts(x))
+			for _, f := range fields {
+				p(`	%s`, g.encode("enc", "arg."+f.Name(), f.Type()))
+			}
+			p(`}`)
+
+			// Note that res is never nil.
+			p(``)
+			p(`func serviceweaver_dec_%s(dec *%s, res *%s) {`, sanitize(x), g.codegen().qualify("Decoder"), ts(x))
+			for _, f := range fields {
+				p(`	%s`, g.decode("dec", "&res."+f.Name(), f.Type()))
+			}
+			p(`}`)
+			return
+		}
+
 		// If a named type t is not a struct, e.g. `type t int`, then we
 		// encode and decode values of type by casting it to its underlying
 		// type (e.g., enc.Int(int(x)) where x has type t).
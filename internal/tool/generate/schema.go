@@ -0,0 +1,273 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"go/types"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// # Field Tags
+//
+// By default, the fields of an AutoMarshal struct are encoded positionally.
+// Adding, removing, or reordering the fields of such a struct changes its
+// encoding, so two binaries with different versions of the struct can't
+// exchange values of it. Alternatively, every field of an AutoMarshal struct
+// can be given a number using a struct tag:
+//
+//     type Pair struct {
+//         weaver.AutoMarshal
+//         X int    `weaver:"1"`
+//         Y string `weaver:"2"`
+//     }
+//
+// The fields of such a struct are encoded along with their numbers, like
+// protocol buffers. A decoder skips fields with unknown numbers and leaves
+// missing fields with their zero values, so fields can be added and removed
+// without breaking compatibility, as long as the number of a field is never
+// reused for a field of a different type.
+//
+// To catch such mistakes, "weaver generate" records the fields of every
+// struct encoded with field tags, including removed ones, in a schema
+// directive in the generated code:
+//
+//     //weaver:schema Pair 1:X:int 2:Y:string
+//
+// When the code is regenerated, the new fields of the struct are checked
+// against the recorded schema.
+
+// schemaDirective is the prefix of the schema directives in generated code.
+const schemaDirective = "//weaver:schema "
+
+// schema is the schema of an AutoMarshal struct encoded with field tags.
+type schema struct {
+	name   string        // name of the struct
+	fields []schemaField // fields of the struct, sorted by number
+}
+
+// schemaField is a field of a struct encoded with field tags.
+type schemaField struct {
+	number  int    // the field's number
+	name    string // the field's name
+	typ     string // the field's fully qualified type
+	removed bool   // was the field removed?
+}
+
+// findFieldTags records the field numbers of the provided AutoMarshal struct
+// in g.tset, if it is encoded with field tags.
+func (g *generator) findFieldTags(t *types.Named) {
+	s := t.Underlying().(*types.Struct)
+	numbers := make([]int, s.NumFields())
+	tagged := false
+	for i := 0; i < s.NumFields(); i++ {
+		if _, ok := reflect.StructTag(s.Tag(i)).Lookup("weaver"); ok {
+			tagged = true
+		}
+	}
+	if !tagged {
+		return
+	}
+
+	ok := true
+	fields := map[int]string{}
+	for i := 0; i < s.NumFields(); i++ {
+		f := s.Field(i)
+		if isWeaverAutoMarshal(f.Type()) {
+			continue
+		}
+		tag, found := reflect.StructTag(s.Tag(i)).Lookup("weaver")
+		if !found {
+			g.errorf(f.Pos(), "field %s of %s has no weaver struct tag. Either every field of an AutoMarshal struct or none of them must have a field number.", f.Name(), t.Obj().Name())
+			ok = false
+			continue
+		}
+		number, err := strconv.ParseUint(tag, 10, 32)
+		if err != nil || number == 0 {
+			g.errorf(f.Pos(), "field %s of %s has invalid field number %q. Field numbers must be positive 32-bit integers.", f.Name(), t.Obj().Name(), tag)
+			ok = false
+			continue
+		}
+		n := int(number)
+		if other, dup := fields[n]; dup {
+			g.errorf(f.Pos(), "fields %s and %s of %s have the same field number %d", other, f.Name(), t.Obj().Name(), n)
+			ok = false
+			continue
+		}
+		fields[n] = f.Name()
+		numbers[i] = n
+	}
+	if ok {
+		g.tset.tagged.Set(t, numbers)
+	}
+}
+
+// newSchema returns the schema of the provided AutoMarshal struct encoded
+// with field tags.
+func (g *generator) newSchema(t *types.Named) schema {
+	numbers, _ := g.tset.fieldNumbers(t)
+	s := t.Underlying().(*types.Struct)
+	var fields []schemaField
+	for i := 0; i < s.NumFields(); i++ {
+		if numbers[i] == 0 {
+			continue
+		}
+		f := s.Field(i)
+		fields = append(fields, schemaField{
+			number: numbers[i],
+			name:   f.Name(),
+			typ:    fullTypeString(f.Type()),
+		})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].number < fields[j].number })
+	return schema{name: t.Obj().Name(), fields: fields}
+}
+
+// String returns the schema directive for the schema, without the
+// "//weaver:schema" prefix.
+func (s schema) String() string {
+	var b strings.Builder
+	b.WriteString(s.name)
+	for _, f := range s.fields {
+		b.WriteByte(' ')
+		if f.removed {
+			b.WriteByte('-')
+		}
+		fmt.Fprintf(&b, "%d:%s:%s", f.number, f.name, f.typ)
+	}
+	return b.String()
+}
+
+// parseSchema parses a schema formatted by schema.String.
+func parseSchema(s string) (schema, error) {
+	parts := strings.Fields(s)
+	if len(parts) == 0 {
+		return schema{}, fmt.Errorf("empty schema")
+	}
+	result := schema{name: parts[0]}
+	for _, part := range parts[1:] {
+		removed := strings.HasPrefix(part, "-")
+		fields := strings.SplitN(strings.TrimPrefix(part, "-"), ":", 3)
+		if len(fields) != 3 {
+			return schema{}, fmt.Errorf("invalid field %q in schema of %s", part, result.name)
+		}
+		n, err := strconv.Atoi(fields[0])
+		if err != nil || n <= 0 {
+			return schema{}, fmt.Errorf("invalid field number %q in schema of %s", fields[0], result.name)
+		}
+		result.fields = append(result.fields, schemaField{
+			number:  n,
+			name:    fields[1],
+			typ:     fields[2],
+			removed: removed,
+		})
+	}
+	return result, nil
+}
+
+// readSchemas returns the schemas recorded in the provided generated file,
+// keyed by struct name. It returns no schemas if the file doesn't exist.
+func readSchemas(filename string) (map[string]schema, error) {
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	schemas := map[string]schema{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, schemaDirective) {
+			continue
+		}
+		s, err := parseSchema(strings.TrimPrefix(line, schemaDirective))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
+		schemas[s.name] = s
+	}
+	return schemas, scanner.Err()
+}
+
+// evolve checks that the new schema of a struct is compatible with its old
+// schema, and returns the new schema along with the fields of the old schema
+// that were removed. It returns an error for every incompatible change.
+func evolve(old, new schema) (schema, []error) {
+	current := map[int]schemaField{}
+	for _, f := range new.fields {
+		current[f.number] = f
+	}
+
+	var errs []error
+	fields := new.fields
+	for _, o := range old.fields {
+		f, ok := current[o.number]
+		switch {
+		case !ok:
+			// The field was removed. Remember it, so that its number isn't
+			// reused for a field of a different type.
+			o.removed = true
+			fields = append(fields, o)
+		case f.typ != o.typ && o.removed:
+			errs = append(errs, fmt.Errorf("field %s of %s reuses the number %d of removed field %s with a different type (%s, was %s). Use a new field number.", f.name, new.name, f.number, o.name, f.typ, o.typ))
+		case f.typ != o.typ:
+			errs = append(errs, fmt.Errorf("field %s of %s changed type from %s to %s, which is not compatible with previously encoded values. Use a new field number.", f.name, new.name, o.typ, f.typ))
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].number < fields[j].number })
+	return schema{name: new.name, fields: fields}, errs
+}
+
+// checkSchemas checks the schemas of the structs encoded with field tags in
+// the package against the schemas recorded in the package's existing
+// generated code, if any. It returns the evolved schemas, keyed by struct.
+func (g *generator) checkSchemas(filename string) map[*types.Named]schema {
+	old, err := readSchemas(filename)
+	if err != nil {
+		g.errors = append(g.errors, err)
+		return nil
+	}
+
+	schemas := map[*types.Named]schema{}
+	for _, t := range g.tset.automarshalCandidates.Keys() {
+		n := t.(*types.Named)
+		o, hasOld := old[n.Obj().Name()]
+		if _, tagged := g.tset.fieldNumbers(n); !tagged {
+			if hasOld {
+				g.errorf(n.Obj().Pos(), "%s was encoded with field tags, but no longer is, which is not compatible with previously encoded values", n.Obj().Name())
+			}
+			continue
+		}
+		s := g.newSchema(n)
+		if hasOld {
+			var errs []error
+			s, errs = evolve(o, s)
+			for _, err := range errs {
+				g.addError(n.Obj().Pos(), err)
+			}
+		}
+		schemas[n] = s
+	}
+	return schemas
+}
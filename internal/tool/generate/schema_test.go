@@ -0,0 +1,113 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseSchema(t *testing.T) {
+	for _, want := range []string{
+		"Empty",
+		"Pair 1:X:int 2:Y:string",
+		"Pair 1:X:int -2:Y:string 3:Z:map[string][]example.com/foo.Bar",
+	} {
+		s, err := parseSchema(want)
+		if err != nil {
+			t.Fatalf("parseSchema(%q): %v", want, err)
+		}
+		if got := s.String(); got != want {
+			t.Fatalf("parseSchema(%q).String(): got %q", want, got)
+		}
+	}
+	for _, bad := range []string{"", "Pair X:int", "Pair 0:X:int", "Pair x:X:int"} {
+		if _, err := parseSchema(bad); err == nil {
+			t.Errorf("parseSchema(%q): unexpected success", bad)
+		}
+	}
+}
+
+func TestEvolve(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		old, new string
+		want     string // evolved schema, if no error
+		err      string // expected error, if any
+	}{
+		{"unchanged", "P 1:X:int", "P 1:X:int", "P 1:X:int", ""},
+		{"renamed", "P 1:X:int", "P 1:Y:int", "P 1:Y:int", ""},
+		{"added", "P 1:X:int", "P 1:X:int 2:Y:string", "P 1:X:int 2:Y:string", ""},
+		{"removed", "P 1:X:int 2:Y:string", "P 2:Y:string", "P -1:X:int 2:Y:string", ""},
+		{"still removed", "P -1:X:int 2:Y:string", "P 2:Y:string 3:Z:bool", "P -1:X:int 2:Y:string 3:Z:bool", ""},
+		{"restored", "P -1:X:int", "P 1:X:int", "P 1:X:int", ""},
+		{"changed type", "P 1:X:int", "P 1:X:int64", "", "changed type from int to int64"},
+		{"reused", "P -1:X:int", "P 1:Y:string", "", "reuses the number 1 of removed field X"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			old, err := parseSchema(c.old)
+			if err != nil {
+				t.Fatal(err)
+			}
+			new, err := parseSchema(c.new)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, errs := evolve(old, new)
+			if c.err != "" {
+				if len(errs) != 1 || !strings.Contains(errs[0].Error(), c.err) {
+					t.Fatalf("evolve: got errors %v, want %q", errs, c.err)
+				}
+				return
+			}
+			if len(errs) > 0 {
+				t.Fatalf("evolve: unexpected errors %v", errs)
+			}
+			if got.String() != c.want {
+				t.Fatalf("evolve: got %q, want %q", got.String(), c.want)
+			}
+		})
+	}
+}
+
+func TestReadSchemas(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), generatedCodeFile)
+
+	// A missing file has no schemas.
+	schemas, err := readSchemas(filename)
+	if err != nil || len(schemas) != 0 {
+		t.Fatalf("readSchemas: got %v, %v; want no schemas", schemas, err)
+	}
+
+	const contents = `package foo
+
+// Fields of Pair, used by "weaver generate" to detect incompatible changes.
+//
+//weaver:schema Pair 1:X:int -2:Y:string
+var _ codegen.AutoMarshal = &Pair{}
+`
+	if err := os.WriteFile(filename, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	schemas, err = readSchemas(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := schemas["Pair"].String(), "Pair 1:X:int -2:Y:string"; len(schemas) != 1 || got != want {
+		t.Fatalf("readSchemas: got %v, want %q", schemas, want)
+	}
+}
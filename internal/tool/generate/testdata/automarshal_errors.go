@@ -0,0 +1,58 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// func init() { codegen.RegisterSerializable[byValueError]() }
+// func init() { codegen.RegisterSerializable[byPointerError]() }
+
+// UNEXPECTED
+// codegen.RegisterSerializable[notAnError]
+
+// Verify that AutoMarshal types that implement the error interface are
+// registered, whether they implement it by value or by pointer.
+package foo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type byValueError struct {
+	weaver.AutoMarshal
+	Code int
+}
+
+func (e byValueError) Error() string { return fmt.Sprint(e.Code) }
+
+type byPointerError struct {
+	weaver.AutoMarshal
+	Msg string
+}
+
+func (e *byPointerError) Error() string { return e.Msg }
+
+type notAnError struct {
+	weaver.AutoMarshal
+	X int
+}
+
+type foo interface {
+	M(context.Context, notAnError) error
+}
+
+type impl struct{ weaver.Implements[foo] }
+
+func (impl) M(context.Context, notAnError) error { return byValueError{Code: 42} }
@@ -0,0 +1,56 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// //weaver:schema Pair 1:X:int 2:Y:string 5:Z:[]foo.Pair
+// f1 := enc.BeginField(1)
+// enc.EndField(f1)
+// f5 := enc.BeginField(5)
+// enc.EndFields()
+// n, fdec := dec.Field()
+// case 5:
+// fdec.String()
+
+// UNEXPECTED
+// //weaver:schema Untagged
+// serviceweaver_size_Pair
+
+// Verify that AutoMarshal structs with field tags are encoded with tags.
+package foo
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type Pair struct {
+	weaver.AutoMarshal
+	X int    `weaver:"1"`
+	Y string `weaver:"2"`
+	Z []Pair `weaver:"5"`
+}
+
+type Untagged struct {
+	weaver.AutoMarshal
+	X int `json:"x"`
+}
+
+type foo interface {
+	M(context.Context, Pair, Untagged) error
+}
+
+type impl struct{ weaver.Implements[foo] }
+
+func (impl) M(context.Context, Pair, Untagged) error { return nil }
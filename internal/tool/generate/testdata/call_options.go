@@ -0,0 +1,66 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// MethodOptions: map[string]codegen.MethodOptions{
+// {Timeout: 1000000000 /* 1s */, Retries: 3, Idempotent: true},
+// {Retries: 2, Backoff: 50000000 /* 50ms */},
+// {Idempotent: true, Hedge: 20000000 /* 20ms */},
+// {Idempotent: true, HedgeP95: true},
+// results, err = s.stub.Run(ctx, 0, enc.Data(), shardKey)
+
+// UNEXPECTED
+// "Put": {
+
+package foo
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type foo interface {
+	// Get returns the value associated with the provided key.
+	//
+	//weaver:idempotent
+	//weaver:timeout 1s
+	//weaver:retries 3
+	Get(context.Context, string) (string, error)
+
+	//weaver:retries 2
+	//weaver:backoff 50ms
+	Delete(context.Context, string) error
+
+	//weaver:idempotent
+	//weaver:hedge 20ms
+	Contains(context.Context, string) (bool, error)
+
+	//weaver:idempotent
+	//weaver:hedge p95
+	Len(context.Context) (int, error)
+
+	// Put is not annotated.
+	Put(context.Context, string, string) error
+}
+
+type impl struct {
+	weaver.Implements[foo]
+}
+
+func (l *impl) Get(context.Context, string) (string, error)    { return "", nil }
+func (l *impl) Delete(context.Context, string) error           { return nil }
+func (l *impl) Put(context.Context, string, string) error      { return nil }
+func (l *impl) Contains(context.Context, string) (bool, error) { return false, nil }
+func (l *impl) Len(context.Context) (int, error)               { return 0, nil }
@@ -1,4 +1,4 @@
-// Copyright 2022 Google LLC
+// Copyright 2023 Google LLC
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -12,18 +12,15 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// ERROR: A is not serializable
+// ERROR: have the same field number 1
 
+// AutoMarshal struct with duplicate field numbers.
 package foo
 
 import "github.com/ServiceWeaver/weaver"
 
-type A struct {
+type Pair struct {
 	weaver.AutoMarshal
-	*B
-}
-
-type B struct {
-	weaver.AutoMarshal
-	*A
+	X int    `weaver:"1"`
+	Y string `weaver:"1"`
 }
@@ -12,13 +12,13 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// ERROR: cannot embed weaver.AutoMarshal
+// ERROR: Generic structs cannot be encoded with field tags
 package foo
 
 import "github.com/ServiceWeaver/weaver"
 
 type option[T any] struct {
-	x int
 	weaver.AutoMarshal
-	y bool
+	x T    `weaver:"1"`
+	y bool `weaver:"2"`
 }
@@ -0,0 +1,96 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// Name:   "foo/Cache[foo.User]",
+// Iface:  reflect.TypeOf((*Cache[User])(nil)).Elem(),
+// Name:  "foo/Cache[int]",
+// Iface: reflect.TypeOf((*Cache[int])(nil)).Elem(),
+// Name:  "foo/Store[string,foo.User]",
+// impl.(Cache[User])
+// Cache[int].Get
+// var r keyRouter[User]
+// func serviceweaver_enc_Entry_User_
+// func serviceweaver_dec_Entry_User_
+// func serviceweaver_enc_Entry_Pair_string_User_
+// func serviceweaver_enc_Pair_string_User_
+
+// UNEXPECTED
+// Entry[T]
+// var _ codegen.AutoMarshal = &Entry
+
+// Verify that instantiations of generic component interfaces are components,
+// and that instantiations of generic AutoMarshal structs are serializable.
+package foo
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type User struct {
+	weaver.AutoMarshal
+	Name string
+}
+
+type Entry[T any] struct {
+	weaver.AutoMarshal
+	Value T
+	found bool
+}
+
+type Pair[K comparable, V any] struct {
+	weaver.AutoMarshal
+	Key   K
+	Value V
+}
+
+type Cache[T any] interface {
+	Get(ctx context.Context, key string) (Entry[T], error)
+	Put(ctx context.Context, key string, value T) error
+}
+
+type Store[K comparable, V any] interface {
+	Get(ctx context.Context, key K) (Entry[Pair[K, V]], error)
+}
+
+type keyRouter[T any] struct{}
+
+func (keyRouter[T]) Get(_ context.Context, key string) string      { return key }
+func (keyRouter[T]) Put(_ context.Context, key string, _ T) string { return key }
+
+type cache[T any] struct{}
+
+func (cache[T]) Get(context.Context, string) (Entry[T], error) { return Entry[T]{}, nil }
+func (cache[T]) Put(context.Context, string, T) error          { return nil }
+
+type userCache struct {
+	weaver.Implements[Cache[User]]
+	weaver.WithRouter[keyRouter[User]]
+	cache[User]
+}
+
+type intCache struct {
+	weaver.Implements[Cache[int]]
+	cache[int]
+}
+
+type store struct {
+	weaver.Implements[Store[string, User]]
+}
+
+func (store) Get(context.Context, string) (Entry[Pair[string, User]], error) {
+	return Entry[Pair[string, User]]{}, nil
+}
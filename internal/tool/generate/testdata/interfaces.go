@@ -0,0 +1,68 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// enc.Interface(a0)
+// dec.Interface(&r0)
+// enc.Interface(x.Shape)
+// dec.Interface(&x.Shape)
+// enc.Interface(arg[i])
+// dec.Interface(&res[i])
+
+// UNEXPECTED
+// serviceweaver_enc_Shape
+// serviceweaver_dec_Shape
+
+// Verify that named interface types are serializable.
+package foo
+
+import (
+	"context"
+	"math"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type Shape interface {
+	Area() float64
+}
+
+type Circle struct {
+	weaver.AutoMarshal
+	Radius float64
+}
+
+func (c Circle) Area() float64 { return math.Pi * c.Radius * c.Radius }
+
+type Labeled struct {
+	weaver.AutoMarshal
+	Label string
+	Shape Shape
+}
+
+func init() {
+	weaver.RegisterImpl[Shape, Circle]()
+}
+
+type foo interface {
+	Scale(context.Context, Shape, float64) (Shape, error)
+	Label(context.Context, Labeled) error
+	Total(context.Context, []Shape) (float64, error)
+}
+
+type impl struct{ weaver.Implements[foo] }
+
+func (impl) Scale(_ context.Context, s Shape, _ float64) (Shape, error) { return s, nil }
+func (impl) Label(context.Context, Labeled) error                       { return nil }
+func (impl) Total(context.Context, []Shape) (float64, error)            { return 0, nil }
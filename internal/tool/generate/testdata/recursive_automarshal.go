@@ -0,0 +1,59 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// func (x *A) WeaverMarshal(enc *codegen.Encoder) {
+// enc.Nest()
+// defer enc.Unnest()
+// dec.Nest()
+// defer dec.Unnest()
+// func serviceweaver_enc_ptr_B_
+// func serviceweaver_dec_ptr_B_
+// func serviceweaver_enc_slice_ptr_Tree_
+// func serviceweaver_enc_map_string_ptr_Tree_
+
+// Verify that self-referential and mutually recursive AutoMarshal structs
+// are serializable.
+package foo
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type A struct {
+	weaver.AutoMarshal
+	*B
+}
+
+type B struct {
+	weaver.AutoMarshal
+	*A
+}
+
+type Tree struct {
+	weaver.AutoMarshal
+	Value    int
+	Children []*Tree
+	Named    map[string]*Tree
+}
+
+type foo interface {
+	M(context.Context, A, *Tree) (Tree, error)
+}
+
+type impl struct{ weaver.Implements[foo] }
+
+func (impl) M(context.Context, A, *Tree) (Tree, error) { return Tree{}, nil }
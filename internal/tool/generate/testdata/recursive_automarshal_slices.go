@@ -0,0 +1,46 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// func (x *Node) WeaverMarshal(enc *codegen.Encoder) {
+// enc.Nest()
+// defer enc.Unnest()
+// dec.Nest()
+// defer dec.Unnest()
+
+// Verify that AutoMarshal structs that refer to themselves through a named
+// slice type are serializable.
+package foo
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type Node struct {
+	weaver.AutoMarshal
+	Value int
+	Kids  Nodes
+}
+
+type Nodes []Node
+
+type foo interface {
+	M(context.Context, Nodes) (Node, error)
+}
+
+type impl struct{ weaver.Implements[foo] }
+
+func (impl) M(context.Context, Nodes) (Node, error) { return Node{}, nil }
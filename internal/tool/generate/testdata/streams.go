@@ -0,0 +1,56 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// Upload(ctx context.Context, a0 string, a1 weaver.StreamReader[[]byte]) (r0 int, err error)
+// List(ctx context.Context, a0 string, a1 weaver.StreamWriter[pair]) (err error)
+// stream, err = s.stub.Stream(ctx, 1, enc.Data(), shardKey)
+// codegen.SendStream(stream, a1.Next,
+// codegen.RecvStream(stream, a1.Send,
+// results, err = stream.Result()
+// func (s foo_server_stub) GetStreamStubFn(method string) func(ctx context.Context, args []byte, stream codegen.ServerStream) ([]byte, error) {
+// func (s foo_server_stub) upload(ctx context.Context, args []byte, stream codegen.ServerStream) (res []byte, err error) {
+// a1 := codegen.NewStreamReader(stream.Recv,
+// a1 := codegen.NewStreamWriter(stream.Send,
+// func (x *pair) WeaverMarshal(enc *codegen.Encoder) {
+
+package foo
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type pair struct {
+	weaver.AutoMarshal
+	Key   string
+	Value int
+}
+
+type foo interface {
+	Upload(context.Context, string, weaver.StreamReader[[]byte]) (int, error)
+	List(context.Context, string, weaver.StreamWriter[pair]) error
+	Get(context.Context, string) (pair, error)
+}
+
+type impl struct {
+	weaver.Implements[foo]
+}
+
+func (l *impl) Upload(context.Context, string, weaver.StreamReader[[]byte]) (int, error) {
+	return 0, nil
+}
+func (l *impl) List(context.Context, string, weaver.StreamWriter[pair]) error { return nil }
+func (l *impl) Get(context.Context, string) (pair, error)                     { return pair{}, nil }
@@ -43,6 +43,16 @@ type typeSet struct {
 	// documentation for why checked shouldn't be a map[types.Type]bool.
 	checked typeutil.Map
 
+	// If recursive[t] != nil, then t is an AutoMarshal struct that refers to
+	// itself, directly or indirectly. Every cycle of types encountered by
+	// checkSerializable contains at least one type in recursive.
+	recursive typeutil.Map
+
+	// If tagged[t] != nil, then t is an AutoMarshal struct encoded with field
+	// tags, and tagged[t].([]int)[i] is the number of the ith field of t, or
+	// zero for the embedded weaver.AutoMarshal.
+	tagged typeutil.Map
+
 	// If sizes[t] != nil, then sizes[t] == sizeOfType(t).
 	sizes typeutil.Map
 
@@ -227,9 +237,18 @@ func (tset *typeSet) checkSerializable(t types.Type) []error {
 	//
 	// When performing the second check(A) call, stack includes A, struct { b:
 	// *B }, *B, B, struct { a: *A }, and *A. Because we called check on A and
-	// A is already in stack, we detect a recursive type and mark A as not
-	// serializable.
+	// A is already in stack, we detect a recursive type. If any type in the
+	// cycle, i.e., any type on the stack from A onwards, is a struct that
+	// embeds weaver.AutoMarshal, like A in this example, then A is
+	// serializable, and we record the first such struct in tset.recursive, so
+	// that the code generated for it can guard against unbounded recursion.
+	// Otherwise, A is not serializable, since there is no way to encode a
+	// value of type A (e.g., type A []A) without infinitely recursing.
+	//
+	// stack maps every type in it to its index in stackTypes, which holds the
+	// types in the order they were encountered.
 	var stack typeutil.Map
+	var stackTypes []types.Type
 
 	// check recursively checks whether a type t is serializable. See lineage
 	// above for a description of path. record is true if the current type
@@ -276,13 +295,25 @@ func (tset *typeSet) checkSerializable(t types.Type) []error {
 		}
 
 		// Check for recursive types.
-		if stack.At(t) != nil {
+		if i := stack.At(t); i != nil {
+			for _, u := range stackTypes[i.(int):] {
+				if tset.isAutoMarshalStruct(u) {
+					// The types in the cycle will be marked as serializable
+					// or not when we finish checking them.
+					tset.recursive.Set(u, true)
+					return true
+				}
+			}
 			addError(fmt.Errorf("serialization of recursive types not currently supported"))
 			tset.checked.Set(t, false)
 			return false
 		}
-		stack.Set(t, struct{}{})
-		defer func() { stack.Delete(t) }()
+		stack.Set(t, len(stackTypes))
+		stackTypes = append(stackTypes, t)
+		defer func() {
+			stack.Delete(t)
+			stackTypes = stackTypes[:len(stackTypes)-1]
+		}()
 
 		switch x := t.(type) {
 		case *types.Named:
@@ -295,6 +326,19 @@ func (tset *typeSet) checkSerializable(t types.Type) []error {
 				break
 			}
 
+			// Named interface types are serializable. Their values are
+			// encoded along with their concrete type, which must be
+			// registered using weaver.RegisterImpl.
+			if _, ok := x.Underlying().(*types.Interface); ok {
+				if isError(x) {
+					addError(fmt.Errorf("serialization of errors not currently supported"))
+					tset.checked.Set(t, false)
+					break
+				}
+				tset.checked.Set(t, true)
+				break
+			}
+
 			// If the underlying type is not a struct, then we simply recurse
 			// on the underlying type.
 			s, ok := x.Underlying().(*types.Struct)
@@ -303,6 +347,29 @@ func (tset *typeSet) checkSerializable(t types.Type) []error {
 				break
 			}
 
+			// An instantiation of a generic struct that embeds
+			// weaver.AutoMarshal is serializable if its fields are. Its fields
+			// are encoded by generated code, so they must be accessible from
+			// the current package.
+			if isGenericAutoMarshal(x) {
+				serializable := true
+				for i := 0; i < s.NumFields(); i++ {
+					f := s.Field(i)
+					if isWeaverAutoMarshal(f.Type()) {
+						continue
+					}
+					if !f.Exported() && f.Pkg() != tset.pkg.Types {
+						addError(fmt.Errorf("field %s of generic struct %s is unexported, so it can't be serialized outside of package %s", f.Name(), x.Obj().Name(), f.Pkg().Name()))
+						serializable = false
+						continue
+					}
+					b := check(f.Type(), path+"."+f.Name(), true)
+					serializable = serializable && b
+				}
+				tset.checked.Set(t, serializable)
+				break
+			}
+
 			// If the underlying type is a struct that has not been declared to
 			// implement the AutoMarshal interface, then it is not
 			// serializable.
@@ -329,9 +396,9 @@ func (tset *typeSet) checkSerializable(t types.Type) []error {
 			tset.checked.Set(t, serializable)
 
 		case *types.Interface:
-			// TODO(sanjay): Support types.Interface only if we can figure out
-			// a way to instantiate the type.
-			addError(fmt.Errorf("serialization of interfaces not currently supported"))
+			// Unlike named interface types, interface literals (e.g., any)
+			// are not serializable.
+			addError(fmt.Errorf("serialization of interface literals not currently supported. Consider using a named interface type and weaver.RegisterImpl."))
 			tset.checked.Set(t, false)
 
 		case *types.Struct:
@@ -385,6 +452,59 @@ func (tset *typeSet) checkSerializable(t types.Type) []error {
 	return errors
 }
 
+// isAutoMarshalStruct returns whether the provided type is a named struct
+// type that embeds weaver.AutoMarshal.
+func (tset *typeSet) isAutoMarshalStruct(t types.Type) bool {
+	n, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	if _, ok := n.Underlying().(*types.Struct); !ok {
+		return false
+	}
+	return tset.automarshalCandidates.At(t) != nil
+}
+
+// isGenericAutoMarshal returns whether the provided type is an instantiation
+// of a generic struct that embeds weaver.AutoMarshal, e.g., Pair[int].
+func isGenericAutoMarshal(t types.Type) bool {
+	n, ok := t.(*types.Named)
+	if !ok || n.TypeArgs().Len() == 0 {
+		return false
+	}
+	s, ok := n.Underlying().(*types.Struct)
+	return ok && embedsAutoMarshal(s)
+}
+
+// embedsAutoMarshal returns whether the provided struct embeds
+// weaver.AutoMarshal.
+func embedsAutoMarshal(s *types.Struct) bool {
+	for i := 0; i < s.NumFields(); i++ {
+		if f := s.Field(i); f.Embedded() && isWeaverAutoMarshal(f.Type()) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRecursive returns whether the provided type is an AutoMarshal struct
+// that refers to itself, directly or indirectly.
+//
+// REQUIRES: t has been checked using checkSerializable.
+func (tset *typeSet) isRecursive(t types.Type) bool {
+	return tset.recursive.At(t) != nil
+}
+
+// fieldNumbers returns the numbers of the fields of the provided type, if
+// it is an AutoMarshal struct encoded with field tags. See tagged.
+func (tset *typeSet) fieldNumbers(t types.Type) ([]int, bool) {
+	numbers := tset.tagged.At(t)
+	if numbers == nil {
+		return nil, false
+	}
+	return numbers.([]int), true
+}
+
 // isFixedSizeType returns whether the provided type has a fixed serialization
 // size. Here is a summary of which types are fixed sized:
 //
@@ -404,7 +524,7 @@ func (tset *typeSet) sizeOfType(t types.Type) int {
 	//   s(basic) = size of basic
 	//   s([N]t) = N * s(t), if t is fixed size
 	//   s(struct{..., fi:ti, ...}) = sum of s(ti), if every ti is fixed size
-	//   s(type t u) = s(u)
+	//   s(type t u) = s(u), if t is not encoded with field tags
 	//   s(_) = -1
 	if size := tset.sizes.At(t); size != nil {
 		return size.(int)
@@ -451,6 +571,12 @@ func (tset *typeSet) sizeOfType(t types.Type) int {
 		return size
 
 	case *types.Named:
+		if tset.tagged.At(x) != nil {
+			// The encoding of a struct with field tags includes the tags and
+			// lengths of its fields. For simplicity, we don't compute it.
+			tset.sizes.Set(t, -1)
+			return -1
+		}
 		size := tset.sizeOfType(x.Underlying())
 		tset.sizes.Set(t, size)
 		return size
@@ -538,7 +664,9 @@ func (tset *typeSet) isMeasurable(t types.Type) bool {
 	case *types.Named:
 		if isWeaverAutoMarshal(x) {
 			tset.measurable.Set(t, true)
-		} else if x.Obj().Pkg() != rootPkg {
+		} else if x.Obj().Pkg() != rootPkg || tset.isRecursive(x) || tset.tagged.At(x) != nil {
+			// The size of a value of a recursive type is unbounded. For
+			// simplicity, we don't measure types encoded with field tags.
 			tset.measurable.Set(t, false)
 		} else {
 			tset.measurable.Set(t, tset.isMeasurable(x.Underlying()))
@@ -868,6 +996,14 @@ func isWeaverAutoMarshal(t types.Type) bool {
 	return isWeaverType(t, "AutoMarshal", 0)
 }
 
+func isWeaverStreamReader(t types.Type) bool {
+	return isWeaverType(t, "StreamReader", 1)
+}
+
+func isWeaverStreamWriter(t types.Type) bool {
+	return isWeaverType(t, "StreamWriter", 1)
+}
+
 func isContext(t types.Type) bool {
 	n, ok := t.(*types.Named)
 	if !ok {
@@ -884,6 +1020,13 @@ func isError(t types.Type) bool {
 	return n.Obj().Pkg() == nil && n.Obj().Name() == "error"
 }
 
+// implementsError returns whether the provided type, or a pointer to it,
+// implements the error interface.
+func implementsError(t types.Type) bool {
+	errorIface := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	return types.Implements(t, errorIface) || types.Implements(types.NewPointer(t), errorIface)
+}
+
 // isPrimitiveRouter returns whether the provided type is a valid primitive
 // router type (i.e. an integer, a float, or a string).
 func isPrimitiveRouter(t types.Type) bool {
@@ -80,6 +80,16 @@ func (t *target) UnmarshalBinary([]byte) error { return nil }
 type target struct { next *target }
 func (t *target) MarshalBinary() ([]byte, error) { return nil, nil }
 func (t *target) UnmarshalBinary([]byte) error { return nil }
+`, ""},
+		{"interface", `
+type target interface{
+	MarshalBinary() ([]byte, error)
+	UnmarshalBinary([]byte) error
+}
+`, ""},
+		{"nested interface", `
+type Shape interface{ Area() float64 }
+type target map[string][]Shape
 `, ""},
 
 		// Non-serializable types:
@@ -99,12 +109,9 @@ type target struct{
 }
 func (t *target) UnmarshalBinary([]byte) error { return nil }
 `, "not serializable"},
-		{"interface", `
-type target interface{
-	MarshalBinary() ([]byte, error)
-	UnmarshalBinary([]byte) error
-}
-`, "not currently supported"},
+		{"interface literal", "type target []interface{ M() }", "not currently supported"},
+		{"any", "type target map[string]any", "not currently supported"},
+		{"error", "type target []error", "not currently supported"},
 		{"simple recursive", `
 type target *target
 `, "not currently supported"},
@@ -141,6 +148,36 @@ type target A
 	}
 }
 
+func TestRecursiveAutoMarshal(t *testing.T) {
+	// Node refers to itself through a named slice type. Checking the slice
+	// type first detects the cycle at the slice type rather than at Node.
+	tset, target := compile(t, `
+type Node struct {
+	Value int
+	Kids  target
+}
+type target []Node
+`)
+	node, err := findType(tset.pkg, "Node")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Pretend that Node embeds weaver.AutoMarshal.
+	tset.automarshalCandidates.Set(node, struct{}{})
+
+	for _, typ := range []types.Type{target, node} {
+		if errs := tset.checkSerializable(typ); len(errs) != 0 {
+			t.Fatalf("checkSerializable(%v): unexpected errors %v", typ, errs)
+		}
+	}
+	if !tset.isRecursive(node) {
+		t.Errorf("isRecursive(Node): got false, want true")
+	}
+	if tset.isRecursive(target) {
+		t.Errorf("isRecursive(target): got true, want false")
+	}
+}
+
 func TestSizeOf(t *testing.T) {
 	type testCase struct {
 		label    string
@@ -0,0 +1,156 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// How often the autoscaler adjusts the number of replicas of co-location
+// groups.
+const autoscaleInterval = 10 * time.Second
+
+// autoscale periodically adjusts the number of replicas of every autoscaled
+// co-location group to match the group's load. autoscale returns when the
+// deployer is stopped.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) autoscale() error {
+	ticker := time.NewTicker(autoscaleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.ctx.Done():
+			return d.ctx.Err()
+		case <-ticker.C:
+			d.mu.Lock()
+			groups := maps.Values(d.groups)
+			d.mu.Unlock()
+			for _, g := range groups {
+				if err := d.scale(g); err != nil {
+					d.logger.Error("autoscale", "err", err, "group", g.name)
+				}
+			}
+		}
+	}
+}
+
+// scale adjusts the number of replicas of the provided co-location group, so
+// that every replica handles about the group's target load. The group is
+// scaled up by as many replicas as needed, but it is scaled down by at most
+// one replica at a time, to avoid overreacting to a transient drop in load.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) scale(g *group) error {
+	d.mu.Lock()
+	config := d.replicas(g)
+	if !g.started || config.Min == config.Max {
+		d.mu.Unlock()
+		return nil
+	}
+	envelopes := slices.Clone(g.envelopes)
+	d.mu.Unlock()
+
+	// Compute the load of the group. Note that GetLoad returns the calls
+	// received since the previous call to GetLoad, so the load is measured
+	// over the last autoscaleInterval.
+	var calls uint64
+	for _, e := range envelopes {
+		report, err := e.GetLoad()
+		if err != nil {
+			return err
+		}
+		calls += totalCalls(report)
+	}
+	load := float64(calls) / autoscaleInterval.Seconds()
+
+	d.mu.Lock()
+	if d.err != nil {
+		// The deployer has been stopped, and no further envelopes should be
+		// started.
+		d.mu.Unlock()
+		return nil
+	}
+
+	// Note that weavelets that are being restarted still count as replicas.
+	current := len(g.envelopes) + g.restarting
+	desired := desiredReplicas(config, current, load)
+	if desired > current {
+		d.mu.Unlock()
+		d.logger.Info("Scaling up", "group", g.name, "load", load, "from", current, "to", desired)
+		for r := current; r < desired; r++ {
+			e, err := d.startWeavelet(g)
+			if err != nil {
+				return err
+			}
+			d.running.Go(func() error {
+				return d.supervise(g, e)
+			})
+		}
+		return nil
+	}
+	if desired == current || len(g.envelopes) == 0 {
+		d.mu.Unlock()
+		return nil
+	}
+
+	// Remove the most recently started weavelet from the group, and then shut
+	// it down gracefully.
+	d.logger.Info("Scaling down", "group", g.name, "load", load, "from", current, "to", desired)
+	e := g.envelopes[len(g.envelopes)-1]
+	err := d.unregisterReplica(g, e)
+	d.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(d.ctx, shutdownGracePeriod)
+	defer cancel()
+	return e.Shutdown(ctx)
+}
+
+// desiredReplicas returns the number of replicas that a co-location group
+// with the provided config and number of replicas should have, given its
+// load in requests per second. The result is clamped to [config.Min,
+// config.Max], and is at most one less than current.
+func desiredReplicas(config replicaConfig, current int, load float64) int {
+	desired := int(math.Ceil(load / config.TargetLoad))
+	if desired < config.Min {
+		desired = config.Min
+	}
+	if desired > config.Max {
+		desired = config.Max
+	}
+	if desired < current-1 {
+		desired = current - 1
+	}
+	return desired
+}
+
+// totalCalls returns the total number of method calls in a load report.
+func totalCalls(report *protos.LoadReport) uint64 {
+	var calls uint64
+	for _, component := range report.Calls {
+		for _, n := range component.Calls {
+			calls += n
+		}
+	}
+	return calls
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+)
+
+func TestDesiredReplicas(t *testing.T) {
+	config := replicaConfig{Min: 2, Max: 5, TargetLoad: 100}
+	for _, test := range []struct {
+		name    string
+		current int
+		load    float64
+		want    int
+	}{
+		{"Steady", 3, 300, 3},
+		{"ScaleUp", 2, 350, 4},
+		{"ScaleUpByMany", 2, 500, 5},
+		{"ScaleDown", 4, 300, 3},
+		{"ScaleDownByOne", 5, 100, 4},
+		{"ClampToMin", 3, 0, 2},
+		{"ClampToMax", 2, 10000, 5},
+		{"AboveMax", 7, 10000, 6},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := desiredReplicas(config, test.current, test.load); got != test.want {
+				t.Fatalf("desiredReplicas(%v, %d, %v): got %d, want %d", config, test.current, test.load, got, test.want)
+			}
+		})
+	}
+}
+
+func TestTotalCalls(t *testing.T) {
+	report := &protos.LoadReport{
+		Calls: map[string]*protos.LoadReport_MethodCalls{
+			"A": {Calls: map[string]uint64{"Get": 1, "Put": 2}},
+			"B": {Calls: map[string]uint64{"Get": 3}},
+			"C": {},
+		},
+	}
+	if got, want := totalCalls(report), uint64(6); got != want {
+		t.Fatalf("totalCalls: got %d, want %d", got, want)
+	}
+}
+
+func TestScale(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		calls int // calls reported by every replica in every load report
+		start int // initial number of replicas
+		want  []int
+	}{
+		// Every replica reports 25 requests per second.
+		{"ScaleUp", 250, 1, []int{3, 4, 4}},
+		// Every replica reports 0 requests per second.
+		{"ScaleDown", 0, 4, []int{3, 2, 1, 1}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			config := &multiConfig{
+				MaxRestarts: 1,
+				Replicas:    map[string]*replicaConfig{"A": {Min: 1, Max: 4, TargetLoad: 10}},
+			}
+			d := newTestDeployer(t, config, "load", strconv.Itoa(test.calls))
+			g := startGroup(t, d)
+			for i := 1; i < test.start; i++ {
+				e, err := d.startWeavelet(g)
+				if err != nil {
+					t.Fatal(err)
+				}
+				d.running.Go(func() error { return d.supervise(g, e) })
+			}
+
+			for i, want := range test.want {
+				if err := d.scale(g); err != nil {
+					t.Fatalf("scale %d: %v", i, err)
+				}
+				d.mu.Lock()
+				got := len(g.envelopes)
+				replicas := len(g.routing("A").Replicas)
+				d.mu.Unlock()
+				if got != want || replicas != want {
+					t.Fatalf("scale %d: got %d envelopes and %d replicas, want %d", i, got, replicas, want)
+				}
+			}
+		})
+	}
+}
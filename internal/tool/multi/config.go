@@ -0,0 +1,151 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"fmt"
+
+	"github.com/ServiceWeaver/weaver/runtime"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+)
+
+const (
+	// multiKey is the key of the multi deployer section in a config file.
+	multiKey      = "github.com/ServiceWeaver/weaver/multi"
+	shortMultiKey = "multi"
+
+	// The default maximum number of consecutive restarts of a weavelet.
+	defaultMaxRestarts = 5
+
+	// The default load, in requests per second, that the autoscaler targets
+	// for every replica of a co-location group.
+	defaultTargetLoad = 100.0
+)
+
+// multiConfig is the multi deployer config, as found in the [multi] section
+// of a config file. For example:
+//
+//	[multi]
+//	max_restarts = 10
+//	mtls = true
+//
+//	[multi.replicas."github.com/example/app/Cache"]
+//	min = 1
+//	max = 5
+//	target_load = 50
+type multiConfig struct {
+	// MaxRestarts is the number of times in a row a crashing weavelet is
+	// restarted before the whole deployment is failed. A weavelet that stays
+	// up for at least healthyUptime is no longer considered to be crash
+	// looping, and its count of restarts is reset. If MaxRestarts is zero,
+	// defaultMaxRestarts is used. If MaxRestarts is negative, crashing
+	// weavelets are never restarted.
+	MaxRestarts int `toml:"max_restarts"`
+
+	// Replicas configures the number of replicas of a co-location group,
+	// keyed by the name of a component in the group. Groups without an entry
+	// are run with defaultReplication replicas.
+	Replicas map[string]*replicaConfig `toml:"replicas"`
+
+	// MTLS, if true, makes weavelets use mutual TLS to communicate with each
+	// other, with certificates issued by a per-deployment certificate
+	// authority.
+	MTLS bool `toml:"mtls"`
+}
+
+// replicaConfig configures the number of replicas of a co-location group.
+// If Min is less than Max, the group is autoscaled: the number of replicas is
+// adjusted so that every replica handles about TargetLoad requests per second,
+// as measured by the load reports of the group's weavelets. The group that
+// hosts main is never autoscaled.
+type replicaConfig struct {
+	Min        int     `toml:"min"`         // if zero, 1
+	Max        int     `toml:"max"`         // if zero, Min
+	TargetLoad float64 `toml:"target_load"` // if zero, defaultTargetLoad
+}
+
+// Validate validates the multi deployer config.
+func (c *multiConfig) Validate() error {
+	for component, r := range c.Replicas {
+		if r.Min < 0 || r.Max < 0 || r.TargetLoad < 0 {
+			return fmt.Errorf("replicas for %q: negative min, max, or target_load", component)
+		}
+		if r.Max != 0 && r.Max < r.Min {
+			return fmt.Errorf("replicas for %q: max %d is less than min %d", component, r.Max, r.Min)
+		}
+		if component == "main" && r.Max > r.Min && r.Max > 1 {
+			return fmt.Errorf("replicas for %q: main can't be autoscaled; set max equal to min", component)
+		}
+	}
+	return nil
+}
+
+// parseMultiConfig parses the [multi] section of the provided config,
+// filling in default values for missing fields.
+func parseMultiConfig(app *protos.AppConfig) (*multiConfig, error) {
+	parsed := &multiConfig{}
+	if err := runtime.ParseConfigSection(multiKey, shortMultiKey, app.Sections, parsed); err != nil {
+		return nil, fmt.Errorf("unable to parse multi config: %w", err)
+	}
+	if parsed.MaxRestarts == 0 {
+		parsed.MaxRestarts = defaultMaxRestarts
+	}
+	for _, r := range parsed.Replicas {
+		if r.Min == 0 {
+			r.Min = 1
+		}
+		if r.Max == 0 {
+			r.Max = r.Min
+		}
+		if r.TargetLoad == 0 {
+			r.TargetLoad = defaultTargetLoad
+		}
+	}
+	return parsed, nil
+}
+
+// replicas returns the replica config for a co-location group with the
+// provided components. If more than one component in the group has an entry
+// in the config, the bounds are intersected: the group gets the largest of
+// the minimums and the smallest of the maximums.
+func (c *multiConfig) replicas(components []string) replicaConfig {
+	var result *replicaConfig
+	for _, component := range components {
+		r, ok := c.Replicas[component]
+		if !ok {
+			continue
+		}
+		if result == nil {
+			result = &replicaConfig{Min: r.Min, Max: r.Max, TargetLoad: r.TargetLoad}
+			continue
+		}
+		if r.Min > result.Min {
+			result.Min = r.Min
+		}
+		if r.Max < result.Max {
+			result.Max = r.Max
+		}
+		if r.TargetLoad < result.TargetLoad {
+			result.TargetLoad = r.TargetLoad
+		}
+	}
+	if result == nil {
+		return replicaConfig{Min: defaultReplication, Max: defaultReplication, TargetLoad: defaultTargetLoad}
+	}
+	if result.Max < result.Min {
+		result.Max = result.Min
+	}
+	return *result
+}
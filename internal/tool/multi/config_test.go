@@ -0,0 +1,117 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseMultiConfig(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		section string
+		want    *multiConfig
+	}{
+		{
+			name: "Defaults",
+			want: &multiConfig{MaxRestarts: defaultMaxRestarts},
+		},
+		{
+			name: "Replicas",
+			section: `
+max_restarts = -1
+mtls = true
+[replicas.A]
+min = 2
+[replicas.B]
+min = 1
+max = 5
+target_load = 50
+`,
+			want: &multiConfig{
+				MaxRestarts: -1,
+				MTLS:        true,
+				Replicas: map[string]*replicaConfig{
+					"A": {Min: 2, Max: 2, TargetLoad: defaultTargetLoad},
+					"B": {Min: 1, Max: 5, TargetLoad: 50},
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			app := &protos.AppConfig{Sections: map[string]string{multiKey: test.section}}
+			got, err := parseMultiConfig(app)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Fatalf("parseMultiConfig (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseInvalidMultiConfig(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		section string
+		want    string
+	}{
+		{"NegativeMin", "[replicas.A]\nmin = -1", "negative"},
+		{"NegativeTargetLoad", "[replicas.A]\ntarget_load = -1.0", "negative"},
+		{"MaxLessThanMin", "[replicas.A]\nmin = 3\nmax = 2", "max 2 is less than min 3"},
+		{"AutoscaledMain", "[replicas.main]\nmin = 1\nmax = 3", "can't be autoscaled"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			app := &protos.AppConfig{Sections: map[string]string{multiKey: test.section}}
+			_, err := parseMultiConfig(app)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("parseMultiConfig: got %v, want error containing %q", err, test.want)
+			}
+		})
+	}
+}
+
+func TestReplicas(t *testing.T) {
+	config := &multiConfig{
+		Replicas: map[string]*replicaConfig{
+			"A": {Min: 1, Max: 5, TargetLoad: 100},
+			"B": {Min: 2, Max: 10, TargetLoad: 50},
+			"C": {Min: 3, Max: 3, TargetLoad: 100},
+			"D": {Min: 6, Max: 8, TargetLoad: 100},
+		},
+	}
+	for _, test := range []struct {
+		name       string
+		components []string
+		want       replicaConfig
+	}{
+		{"Default", []string{"X"}, replicaConfig{Min: defaultReplication, Max: defaultReplication, TargetLoad: defaultTargetLoad}},
+		{"Single", []string{"A", "X"}, replicaConfig{Min: 1, Max: 5, TargetLoad: 100}},
+		{"Intersection", []string{"A", "B"}, replicaConfig{Min: 2, Max: 5, TargetLoad: 50}},
+		{"Fixed", []string{"B", "C"}, replicaConfig{Min: 3, Max: 3, TargetLoad: 50}},
+		{"Disjoint", []string{"A", "D"}, replicaConfig{Min: 6, Max: 6, TargetLoad: 100}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := config.replicas(test.components); got != test.want {
+				t.Fatalf("replicas(%v): got %+v, want %+v", test.components, got, test.want)
+			}
+		})
+	}
+}
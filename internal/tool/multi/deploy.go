@@ -79,6 +79,7 @@ func deploy(ctx context.Context, args []string) error {
 	if err != nil {
 		return fmt.Errorf("create deployer: %w", err)
 	}
+	defer os.RemoveAll(d.socketDir) //nolint:errcheck // best effort
 
 	// Run a status server.
 	lis, err := net.Listen("tcp", "localhost:0")
@@ -135,12 +136,18 @@ func deploy(ctx context.Context, args []string) error {
 		select {
 		case <-userDone:
 			fmt.Fprintf(os.Stderr, "Application %s terminated by the user\n", config.Name)
+			ctx, cancel := context.WithTimeout(ctx, shutdownGracePeriod)
+			d.shutdown(ctx)
+			cancel()
 		case err := <-deployerDone:
 			fmt.Fprintf(os.Stderr, "Application %s error: %v\n", config.Name, err)
 		}
 		if err := registry.Unregister(ctx, deploymentId); err != nil {
 			fmt.Fprintf(os.Stderr, "unregister deployment: %v\n", err)
 		}
+		if err := os.RemoveAll(d.socketDir); err != nil {
+			fmt.Fprintf(os.Stderr, "remove Unix sockets: %v\n", err)
+		}
 		os.Exit(1)
 	}()
 
@@ -173,6 +180,18 @@ func defaultRegistryDir() (string, error) {
 	return filepath.Join(dir, "multi_registry"), nil
 }
 
+// defaultSocketDir returns $XDG_DATA_HOME/serviceweaver/multi_sockets, or
+// ~/.local/share/serviceweaver/multi_sockets if XDG_DATA_HOME is not set.
+// Every deployment creates the Unix sockets of its weavelets in its own
+// subdirectory.
+func defaultSocketDir() (string, error) {
+	dir, err := files.DefaultDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "multi_sockets"), nil
+}
+
 // defaultRegistry returns a registry in defaultRegistryDir().
 func defaultRegistry(ctx context.Context) (*status.Registry, error) {
 	dir, err := defaultRegistryDir()
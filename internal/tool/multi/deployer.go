@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
 	"sync"
 	"syscall"
 	"time"
@@ -32,9 +34,11 @@ import (
 	"github.com/ServiceWeaver/weaver/runtime/envelope"
 	"github.com/ServiceWeaver/weaver/runtime/logging"
 	"github.com/ServiceWeaver/weaver/runtime/metrics"
+	"github.com/ServiceWeaver/weaver/runtime/mtls"
 	"github.com/ServiceWeaver/weaver/runtime/perfetto"
 	"github.com/ServiceWeaver/weaver/runtime/profiling"
 	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"github.com/ServiceWeaver/weaver/runtime/retry"
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"golang.org/x/exp/maps"
@@ -47,18 +51,48 @@ import (
 // The default number of times a component is replicated.
 const defaultReplication = 2
 
+// The amount of time weavelets are given to shut down gracefully when the
+// deployment is terminated by the user.
+const shutdownGracePeriod = 10 * time.Second
+
+// The amount of time a restarted weavelet must stay up before it is no longer
+// considered to be crash looping. It is a variable so that tests can shorten
+// it.
+var healthyUptime = time.Minute
+
+// maxUnixSocketPath is the maximum length of the path of a Unix socket. The
+// limit is 108 bytes on Linux and 104 bytes on macOS, including a trailing
+// null byte.
+const maxUnixSocketPath = 103
+
+// restartBackoff configures the exponential backoff between consecutive
+// restarts of a crashing weavelet.
+var restartBackoff = retry.Options{
+	BackoffMultiplier:  2,
+	BackoffMinDuration: 100 * time.Millisecond,
+	BackoffMaxDuration: 30 * time.Second,
+}
+
 // A deployer manages an application deployment.
 type deployer struct {
 	ctx          context.Context
 	ctxCancel    context.CancelFunc
 	deploymentId string
 	config       *protos.AppConfig
+	multiConfig  *multiConfig
+	ca           *mtls.CA // if not nil, issues the weavelets' mTLS certificates
 	started      time.Time
 	logger       *slog.Logger
 	running      errgroup.Group
 	logsDB       *logging.FileStore
 	traceDB      *perfetto.DB
 
+	// Every weavelet listens on a Unix socket in socketDir, in addition to its
+	// TCP address, so that the other weavelets, which are all on the same
+	// machine, can call it with lower latency and without using up ephemeral
+	// ports. If socketDir is empty, weavelets only listen on TCP.
+	socketDir string
+
 	// statsProcessor tracks and computes stats to be rendered on the /statusz page.
 	statsProcessor *imetrics.StatsProcessor
 
@@ -93,8 +127,14 @@ type group struct {
 	pids        []int64                         // weavelet pids
 	components  map[string]bool                 // started components
 	addresses   map[string]bool                 // weavelet addresses
+	unhealthy   map[string]bool                 // unhealthy weavelet addresses
+	unixAddrs   map[string]string               // Unix socket addresses, by weavelet address
+	backends    map[string][]backend            // proxy backends, by weavelet address
 	assignments map[string]*protos.Assignment   // assignment, by component
 	subscribers map[string][]*envelope.Envelope // routing info subscribers, by component
+	started     bool                            // has the group been started?
+	restarts    int                             // number of weavelet restarts
+	restarting  int                             // number of weavelets being restarted
 }
 
 // A proxyInfo contains information about a proxy.
@@ -104,6 +144,13 @@ type proxyInfo struct {
 	addr     string       // dialable address of the proxy
 }
 
+// A backend is the address of a listener of a weavelet, which is a backend of
+// the proxy of the listener.
+type backend struct {
+	proxy *proxy.Proxy // the proxy
+	addr  string       // address of the listener
+}
+
 // handler handles a connection to a weavelet.
 type handler struct {
 	*deployer
@@ -117,6 +164,11 @@ var _ envelope.EnvelopeHandler = &handler{}
 // newDeployer creates a new deployer. The deployer can be stopped at any
 // time by canceling the passed-in context.
 func newDeployer(ctx context.Context, deploymentId string, config *protos.AppConfig) (*deployer, error) {
+	multiConfig, err := parseMultiConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the log saver.
 	logsDB, err := logging.NewFileStore(logdir)
 	if err != nil {
@@ -146,6 +198,27 @@ func newDeployer(ctx context.Context, deploymentId string, config *protos.AppCon
 		}
 	}
 
+	// Create the deployment's certificate authority, if needed.
+	var ca *mtls.CA
+	if multiConfig.MTLS {
+		ca, err = mtls.NewCA(deploymentId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Create the directory of the weavelets' Unix sockets. If we can't, the
+	// weavelets communicate over TCP only.
+	socketDir, err := defaultSocketDir()
+	if err == nil {
+		socketDir = filepath.Join(socketDir, logging.Shorten(deploymentId))
+		err = os.MkdirAll(socketDir, 0700)
+	}
+	if err != nil {
+		logger.Error("Cannot create Unix socket directory; using TCP only", "err", err)
+		socketDir = ""
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	d := &deployer{
 		ctx:            ctx,
@@ -153,9 +226,12 @@ func newDeployer(ctx context.Context, deploymentId string, config *protos.AppCon
 		logger:         logger,
 		logsDB:         logsDB,
 		traceDB:        traceDB,
+		socketDir:      socketDir,
 		statsProcessor: imetrics.NewStatsProcessor(),
 		deploymentId:   deploymentId,
 		config:         config,
+		multiConfig:    multiConfig,
+		ca:             ca,
 		started:        time.Now(),
 		colocation:     colocation,
 		groups:         map[string]*group{},
@@ -169,6 +245,20 @@ func newDeployer(ctx context.Context, deploymentId string, config *protos.AppCon
 		return err
 	})
 
+	// Start a goroutine that autoscales co-location groups.
+	d.running.Go(func() error {
+		err := d.autoscale()
+		d.stop(err)
+		return err
+	})
+
+	// Start a goroutine that checks the health of weavelets.
+	d.running.Go(func() error {
+		err := d.checkHealth()
+		d.stop(err)
+		return err
+	})
+
 	// Start a goroutine that watches for context cancelation.
 	d.running.Go(func() error {
 		<-d.ctx.Done()
@@ -206,6 +296,40 @@ func (d *deployer) stop(err error) {
 	d.ctxCancel()
 }
 
+// shutdown gracefully shuts down all weavelets. The weavelets hosting main
+// are shut down first, since main is the root of the component graph,
+// followed by the weavelets in all other co-location groups. Weavelets that
+// haven't shut down by the time ctx is done are killed.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) shutdown(ctx context.Context) {
+	d.mu.Lock()
+	var main, others []*envelope.Envelope
+	for name, g := range d.groups {
+		if name == "main" {
+			main = append(main, g.envelopes...)
+		} else {
+			others = append(others, g.envelopes...)
+		}
+	}
+	d.mu.Unlock()
+
+	for _, envelopes := range [][]*envelope.Envelope{main, others} {
+		var wait sync.WaitGroup
+		for _, e := range envelopes {
+			e := e
+			wait.Add(1)
+			go func() {
+				defer wait.Done()
+				if err := e.Shutdown(ctx); err != nil {
+					d.logger.Error("shutdown", "err", err, "addr", e.WeaveletInfo().DialAddr)
+				}
+			}()
+		}
+		wait.Wait()
+	}
+}
+
 // group returns the co-location group containing the provided component.
 //
 // REQUIRES: d.mu is held.
@@ -221,6 +345,9 @@ func (d *deployer) group(component string) *group {
 			name:        name,
 			components:  map[string]bool{},
 			addresses:   map[string]bool{},
+			unhealthy:   map[string]bool{},
+			unixAddrs:   map[string]string{},
+			backends:    map[string][]backend{},
 			assignments: map[string]*protos.Assignment{},
 			subscribers: map[string][]*envelope.Envelope{},
 		}
@@ -229,77 +356,295 @@ func (d *deployer) group(component string) *group {
 	return g
 }
 
+// replicas returns the addresses of the healthy weavelets in the group. If
+// every weavelet is unhealthy, replicas returns the addresses of all of them,
+// since an unhealthy weavelet may still be able to serve some calls.
+//
+// REQUIRES: d.mu is held.
+func (g *group) replicas() []string {
+	var healthy []string
+	for addr := range g.addresses {
+		if !g.unhealthy[addr] {
+			healthy = append(healthy, addr)
+		}
+	}
+	if len(healthy) == 0 {
+		return maps.Keys(g.addresses)
+	}
+	return healthy
+}
+
 // routing returns the RoutingInfo for the provided component.
 //
 // REQUIRES: d.mu is held.
 func (g *group) routing(component string) *protos.RoutingInfo {
 	return &protos.RoutingInfo{
-		Component:  component,
-		Replicas:   maps.Keys(g.addresses),
-		Assignment: g.assignments[component],
+		Component:    component,
+		Replicas:     g.replicas(),
+		Assignment:   g.assignments[component],
+		UnixReplicas: maps.Clone(g.unixAddrs),
 	}
 }
 
+// notify sends the latest routing info for the provided component to all of
+// the component's subscribers. The routing info of a routed component is also
+// sent to the weavelets hosting the component, so that they report load with
+// respect to the latest assignment.
+//
+// REQUIRES: d.mu is held.
+func (g *group) notify(component string) error {
+	routing := g.routing(component)
+	for _, sub := range g.subscribers[component] {
+		if err := sub.UpdateRoutingInfo(routing); err != nil {
+			return err
+		}
+	}
+	if routing.Assignment == nil {
+		return nil
+	}
+	for _, e := range g.envelopes {
+		if slices.Contains(g.subscribers[component], e) {
+			// Already notified.
+			continue
+		}
+		if err := e.UpdateRoutingInfo(routing); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // startColocationGroup starts the colocation group hosting the provided
 // component, if it hasn't been started already.
 //
-// REQUIRES: d.mu is held.
+// REQUIRES: d.mu is NOT held.
 func (d *deployer) startColocationGroup(g *group) error {
+	d.mu.Lock()
 	// Check if the deployer has already been stopped. The cleanup protocol
 	// requires that no further envelopes be started after the deployer
 	// has been stopped.
 	if d.err != nil {
-		return d.err
+		err := d.err
+		d.mu.Unlock()
+		return err
 	}
-	if len(g.envelopes) == defaultReplication {
-		// Already started.
+	if g.started {
+		// Already started. Note that crashed weavelets are restarted by
+		// supervise.
+		d.mu.Unlock()
 		return nil
 	}
+	g.started = true
+	replicas := d.replicas(g).Min
+	d.mu.Unlock()
 
+	for r := 0; r < replicas; r++ {
+		e, err := d.startWeavelet(g)
+		if err != nil {
+			return err
+		}
+		d.running.Go(func() error {
+			return d.supervise(g, e)
+		})
+	}
+	return nil
+}
+
+// replicas returns the replica config of the provided co-location group.
+// The group that hosts main is never autoscaled, since every replica of it
+// runs the application's main function.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) replicas(g *group) replicaConfig {
+	members := d.members(g)
+	config := d.multiConfig.replicas(members)
+	if slices.Contains(members, "main") {
+		config.Max = config.Min
+	}
+	return config
+}
+
+// members returns the components that the provided co-location group may
+// host, whether or not they have been started.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) members(g *group) []string {
 	components := maps.Keys(g.components)
-	for r := 0; r < defaultReplication; r++ {
-		// Start the weavelet and capture its logs, traces, and metrics.
-		info := &protos.EnvelopeInfo{
-			App:           d.config.Name,
-			DeploymentId:  d.deploymentId,
-			Id:            uuid.New().String(),
-			Sections:      d.config.Sections,
-			SingleProcess: false,
-			SingleMachine: true,
-			RunMain:       g.components["main"],
+	for component, name := range d.colocation {
+		if name == g.name && !g.components[component] {
+			components = append(components, component)
+		}
+	}
+	if !slices.Contains(components, g.name) {
+		components = append(components, g.name)
+	}
+	return components
+}
+
+// startWeavelet starts a new weavelet in the provided co-location group and
+// registers it as a replica of the group. The weavelet process is started
+// without holding d.mu. If the weavelet can't be registered, it is killed.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) startWeavelet(g *group) (_ *envelope.Envelope, err error) {
+	d.mu.Lock()
+	if d.err != nil {
+		// The deployer has been stopped, and no further envelopes should be
+		// started.
+		err := d.err
+		d.mu.Unlock()
+		return nil, err
+	}
+	info := &protos.EnvelopeInfo{
+		App:           d.config.Name,
+		DeploymentId:  d.deploymentId,
+		Id:            uuid.New().String(),
+		Sections:      d.config.Sections,
+		SingleProcess: false,
+		SingleMachine: true,
+		RunMain:       g.components["main"],
+	}
+	info.UnixSocket = d.unixSocket(info.Id)
+	if d.ca != nil {
+		creds, err := d.ca.Issue(info.Id, d.members(g))
+		if err != nil {
+			d.mu.Unlock()
+			return nil, err
 		}
-		e, err := envelope.NewEnvelope(d.ctx, info, d.config)
+		info.Mtls = creds
+	}
+	d.mu.Unlock()
+
+	// Start the weavelet and capture its logs, traces, and metrics. Canceling
+	// ctx kills the weavelet process, which we do if anything below fails.
+	ctx, cancel := context.WithCancel(d.ctx)
+	defer func() {
 		if err != nil {
-			return err
+			cancel()
 		}
+	}()
+	e, err := envelope.NewEnvelope(ctx, info, d.config)
+	if err != nil {
+		return nil, err
+	}
 
-		// Make sure the version of the deployer matches the version of the
-		// compiled binary.
-		wlet := e.WeaveletInfo()
-		if err := checkVersion(wlet.Version); err != nil {
-			return err
+	// Make sure the version of the deployer matches the version of the
+	// compiled binary.
+	wlet := e.WeaveletInfo()
+	if err = checkVersion(wlet.Version); err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.err != nil {
+		// The deployer was stopped while the weavelet was starting.
+		return nil, d.err
+	}
+	if err = e.UpdateComponents(maps.Keys(g.components)); err != nil {
+		return nil, err
+	}
+	g.envelopes = append(g.envelopes, e)
+	if err = d.registerReplica(g, wlet); err != nil {
+		if uerr := d.unregisterReplica(g, e); uerr != nil {
+			d.logger.Error("unregister replica", "err", uerr, "group", g.name)
 		}
+		return nil, err
+	}
+	return e, nil
+}
 
-		d.running.Go(func() error {
-			h := &handler{
-				deployer:   d,
-				g:          g,
-				subscribed: map[string]bool{},
-				envelope:   e,
-			}
-			err := e.Serve(h)
+// unixSocket returns the path of the Unix socket for the weavelet with the
+// provided id, or the empty string if the weavelet should only listen on TCP.
+func (d *deployer) unixSocket(id string) string {
+	if d.socketDir == "" {
+		return ""
+	}
+	path := filepath.Join(d.socketDir, logging.Shorten(id)+".sock")
+	if len(path) > maxUnixSocketPath {
+		return ""
+	}
+	return path
+}
+
+// supervise serves the provided weavelet. If the weavelet crashes, supervise
+// restarts it with exponential backoff. If the weavelet crash loops, i.e. it
+// is restarted more than MaxRestarts times in a row without staying up for
+// healthyUptime, the deployment is stopped. Weavelets that host main are
+// never restarted; when main exits, the deployment is stopped.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) supervise(g *group, e *envelope.Envelope) error {
+	r := retry.BeginWithOptions(restartBackoff)
+	r.Continue(d.ctx) // The first call to Continue doesn't sleep.
+	restarts := 0
+	for {
+		started := time.Now()
+		h := &handler{
+			deployer:   d,
+			g:          g,
+			subscribed: map[string]bool{},
+			envelope:   e,
+		}
+		err := e.Serve(h)
+		if err == nil {
+			// Serve returns nil if the weavelet was shut down gracefully.
+			return nil
+		}
+		if d.ctx.Err() != nil || g.name == "main" || d.multiConfig.MaxRestarts < 0 {
 			d.stop(err)
 			return err
-		})
-		if err := d.registerReplica(g, wlet); err != nil {
-			return err
 		}
-		if err := e.UpdateComponents(components); err != nil {
+		d.logger.Error("Weavelet crashed", "err", err, "group", g.name, "pid", e.WeaveletInfo().Pid)
+
+		// Remove the crashed weavelet.
+		d.mu.Lock()
+		err = d.unregisterReplica(g, e)
+		g.restarting++
+		d.mu.Unlock()
+		if err != nil {
+			d.stop(err)
 			return err
 		}
-		g.envelopes = append(g.envelopes, e)
+
+		// Restart the weavelet, with backoff.
+		if time.Since(started) >= healthyUptime {
+			restarts = 0
+			r.Reset()
+			r.Continue(d.ctx)
+		}
+		for {
+			restarts++
+			if restarts > d.multiConfig.MaxRestarts {
+				err := fmt.Errorf("weavelet in group %q is crash looping: restarted %d times in a row", g.name, restarts-1)
+				d.stop(err)
+				return err
+			}
+			if !r.Continue(d.ctx) {
+				err := d.ctx.Err()
+				d.stop(err)
+				return err
+			}
+
+			e, err = d.startWeavelet(g)
+			d.mu.Lock()
+			if err == nil {
+				g.restarts++
+				g.restarting--
+				d.mu.Unlock()
+				break
+			}
+			stopped := d.err
+			d.mu.Unlock()
+			if stopped != nil {
+				// The deployer has been stopped, and no further envelopes
+				// should be started.
+				return stopped
+			}
+			d.logger.Error("Weavelet restart failed", "err", err, "group", g.name)
+		}
+		d.logger.Info("Weavelet restarted", "group", g.name, "pid", e.WeaveletInfo().Pid, "restarts", restarts)
 	}
-	return nil
 }
 
 // checkVersion checks that the deployer API version the deployer was built
@@ -355,6 +700,20 @@ func (h *handler) subscribeTo(req *protos.ActivateComponentRequest) error {
 }
 
 func (d *deployer) activateComponent(req *protos.ActivateComponentRequest) error {
+	target, err := d.addComponent(req)
+	if err != nil {
+		return err
+	}
+
+	// Start the co-location group, if it hasn't started already.
+	return d.startColocationGroup(target)
+}
+
+// addComponent adds the requested component to its co-location group, if it
+// isn't there already, and returns the group.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) addComponent(req *protos.ActivateComponentRequest) (*group, error) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
@@ -367,29 +726,23 @@ func (d *deployer) activateComponent(req *protos.ActivateComponentRequest) error
 		components := maps.Keys(target.components)
 		for _, envelope := range target.envelopes {
 			if err := envelope.UpdateComponents(components); err != nil {
-				return err
+				return nil, err
 			}
 		}
 
 		// Create an initial assignment.
 		if req.Routed {
-			replicas := maps.Keys(target.addresses)
-			assignment := routingAlgo(&protos.Assignment{}, replicas)
+			assignment := routingAlgo(&protos.Assignment{}, target.replicas())
 			target.assignments[req.Component] = assignment
 			d.logger.Debug(fmt.Sprintf("Initial assignment for component %s:\n%s", req.Component, routing.FormatAssignment(assignment)))
 		}
 
 		// Notify the subscribers.
-		routing := target.routing(req.Component)
-		for _, sub := range target.subscribers[req.Component] {
-			if err := sub.UpdateRoutingInfo(routing); err != nil {
-				return err
-			}
+		if err := target.notify(req.Component); err != nil {
+			return nil, err
 		}
 	}
-
-	// Start the co-location group, if it hasn't started already.
-	return d.startColocationGroup(target)
+	return target, nil
 }
 
 // registerReplica registers the information about a colocation group replica
@@ -401,10 +754,42 @@ func (d *deployer) registerReplica(g *group, info *protos.WeaveletInfo) error {
 		return nil
 	}
 	g.addresses[info.DialAddr] = true
+	if info.UnixDialAddr != "" {
+		g.unixAddrs[info.DialAddr] = info.UnixDialAddr
+	}
 	g.pids = append(g.pids, info.Pid)
+	return d.updateRouting(g)
+}
 
+// unregisterReplica removes a stopped weavelet from a colocation group, and
+// from the set of routing info subscribers of every other group.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) unregisterReplica(g *group, e *envelope.Envelope) error {
+	// Update envelopes, addresses, and pids.
+	info := e.WeaveletInfo()
+	g.envelopes = remove(g.envelopes, e)
+	g.pids = remove(g.pids, info.Pid)
+	delete(g.addresses, info.DialAddr)
+	delete(g.unhealthy, info.DialAddr)
+	delete(g.unixAddrs, info.DialAddr)
+	delete(g.backends, info.DialAddr)
+	for _, other := range d.groups {
+		for component, subs := range other.subscribers {
+			other.subscribers[component] = remove(subs, e)
+		}
+	}
+	return d.updateRouting(g)
+}
+
+// updateRouting updates the assignments of the routed components in the
+// provided group to reflect the group's replicas, and sends the latest routing
+// info of every component in the group to its subscribers.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) updateRouting(g *group) error {
 	// Update all assignments.
-	replicas := maps.Keys(g.addresses)
+	replicas := g.replicas()
 	for component, assignment := range g.assignments {
 		assignment = routingAlgo(assignment, replicas)
 		g.assignments[component] = assignment
@@ -413,14 +798,10 @@ func (d *deployer) registerReplica(g *group, info *protos.WeaveletInfo) error {
 
 	// Notify subscribers.
 	for component := range g.components {
-		routing := g.routing(component)
-		for _, sub := range g.subscribers[component] {
-			if err := sub.UpdateRoutingInfo(routing); err != nil {
-				return err
-			}
+		if err := g.notify(component); err != nil {
+			return err
 		}
 	}
-
 	return nil
 }
 
@@ -441,23 +822,39 @@ func (d *deployer) GetListenerAddress(context.Context, *protos.GetListenerAddres
 }
 
 // ExportListener implements the envelope.EnvelopeHandler interface.
-func (d *deployer) ExportListener(_ context.Context, req *protos.ExportListenerRequest) (*protos.ExportListenerReply, error) {
-	d.mu.Lock()
-	defer d.mu.Unlock()
+func (h *handler) ExportListener(_ context.Context, req *protos.ExportListenerRequest) (*protos.ExportListenerReply, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	reply, p, err := h.exportListener(req)
+	if p != nil {
+		// Remember the backend, so that it can be marked unhealthy along
+		// with the weavelet.
+		addr := h.envelope.WeaveletInfo().DialAddr
+		h.g.backends[addr] = append(h.g.backends[addr], backend{p, req.Address})
+		p.SetHealthy(req.Address, !h.g.unhealthy[addr])
+	}
+	return reply, err
+}
 
+// exportListener adds the provided listener address to the proxy of the
+// listener, creating the proxy if needed. It returns the proxy, or nil if the
+// address wasn't added to a proxy.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) exportListener(req *protos.ExportListenerRequest) (*protos.ExportListenerReply, *proxy.Proxy, error) {
 	// Update the proxy.
 	if p, ok := d.proxies[req.Listener]; ok {
 		p.proxy.AddBackend(req.Address)
-		return &protos.ExportListenerReply{ProxyAddress: p.addr}, nil
+		return &protos.ExportListenerReply{ProxyAddress: p.addr}, p.proxy, nil
 	}
 
 	lis, err := net.Listen("tcp", req.LocalAddress)
 	if errors.Is(err, syscall.EADDRINUSE) {
 		// Don't retry if this address is already in use.
-		return &protos.ExportListenerReply{Error: err.Error()}, nil
+		return &protos.ExportListenerReply{Error: err.Error()}, nil, nil
 	}
 	if err != nil {
-		return nil, fmt.Errorf("proxy listen: %w", err)
+		return nil, nil, fmt.Errorf("proxy listen: %w", err)
 	}
 	addr := lis.Addr().String()
 	d.logger.Info("Proxy listening", "address", addr)
@@ -473,7 +870,7 @@ func (d *deployer) ExportListener(_ context.Context, req *protos.ExportListenerR
 			d.logger.Error("proxy", "err", err)
 		}
 	}()
-	return &protos.ExportListenerReply{ProxyAddress: addr}, nil
+	return &protos.ExportListenerReply{ProxyAddress: addr}, proxy, nil
 }
 
 func (d *deployer) readMetrics() []*metrics.MetricSnapshot {
@@ -521,9 +918,10 @@ func (d *deployer) Status(context.Context) (*status.Status, error) {
 	for _, group := range d.groups {
 		for component := range group.components {
 			c := &status.Component{
-				Name:  component,
-				Group: group.name,
-				Pids:  slices.Clone(group.pids),
+				Name:     component,
+				Group:    group.name,
+				Pids:     slices.Clone(group.pids),
+				Restarts: int64(group.restarts),
 			}
 			components = append(components, c)
 
@@ -585,6 +983,14 @@ func (d *deployer) Metrics(context.Context) (*status.Metrics, error) {
 	return m, nil
 }
 
+// remove removes the first occurrence of x from xs, if any.
+func remove[T comparable](xs []T, x T) []T {
+	if i := slices.Index(xs, x); i >= 0 {
+		return slices.Delete(xs, i, i+1)
+	}
+	return xs
+}
+
 func routingAlgo(currAssignment *protos.Assignment, candidates []string) *protos.Assignment {
 	assignment := routing.EqualSlices(candidates)
 	assignment.Version = currAssignment.Version + 1
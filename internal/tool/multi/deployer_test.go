@@ -0,0 +1,344 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/internal/envelope/conn"
+	"github.com/ServiceWeaver/weaver/runtime"
+	"github.com/ServiceWeaver/weaver/runtime/envelope"
+	"github.com/ServiceWeaver/weaver/runtime/logging"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"github.com/ServiceWeaver/weaver/runtime/retry"
+	"github.com/google/uuid"
+)
+
+// The result of running os.Executable(). Populated by TestMain.
+var executable = ""
+
+func TestMain(m *testing.M) {
+	// The tests in this package run the test binary as fake weavelets, with
+	// a subcommand (e.g., "serve", "crash"). When run as a fake weavelet, the
+	// test binary doesn't run any of the tests.
+	flag.Parse()
+	if cmd := flag.Arg(0); cmd != "" {
+		if err := fakeWeavelet(cmd, flag.Args()[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "fake weavelet: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	var err error
+	executable, err = os.Executable()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+// fakeWeavelet runs a fake weavelet that speaks the envelope protocol but
+// hosts no components. The provided command determines its behavior:
+//
+//   - "serve": serve until shut down.
+//   - "crash <duration>": serve for the provided duration, then crash.
+//   - "crash_once <file> <duration>": like "crash" if the provided file
+//     doesn't exist, in which case it is created, and like "serve" otherwise.
+//   - "load <calls>": like "serve", but report the provided number of calls
+//     in every load report.
+func fakeWeavelet(cmd string, args []string) error {
+	h := &fakeHandler{}
+	switch cmd {
+	case "serve":
+	case "crash":
+		d, err := time.ParseDuration(args[0])
+		if err != nil {
+			return err
+		}
+		time.AfterFunc(d, func() { os.Exit(1) })
+	case "crash_once":
+		if _, err := os.Stat(args[0]); err == nil {
+			break
+		}
+		if err := os.WriteFile(args[0], nil, 0o600); err != nil {
+			return err
+		}
+		d, err := time.ParseDuration(args[1])
+		if err != nil {
+			return err
+		}
+		time.AfterFunc(d, func() { os.Exit(1) })
+	case "load":
+		calls, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return err
+		}
+		h.calls = calls
+	default:
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+
+	bootstrap, err := runtime.GetBootstrap(context.Background())
+	if err != nil {
+		return err
+	}
+	toWeavelet, toEnvelope, err := bootstrap.MakePipes()
+	if err != nil {
+		return err
+	}
+	c, err := conn.NewWeaveletConn(toWeavelet, toEnvelope, h)
+	if err != nil {
+		return err
+	}
+	return c.Serve()
+}
+
+// fakeHandler is the conn.WeaveletHandler of a fake weavelet.
+type fakeHandler struct {
+	calls uint64 // calls reported in every load report
+}
+
+var _ conn.WeaveletHandler = &fakeHandler{}
+
+func (h *fakeHandler) GetLoad(*protos.GetLoadRequest) (*protos.GetLoadReply, error) {
+	report := &protos.LoadReport{}
+	if h.calls > 0 {
+		report.Calls = map[string]*protos.LoadReport_MethodCalls{
+			"A": {Calls: map[string]uint64{"Get": h.calls}},
+		}
+	}
+	return &protos.GetLoadReply{Load: report}, nil
+}
+
+func (h *fakeHandler) UpdateComponents(*protos.UpdateComponentsRequest) (*protos.UpdateComponentsReply, error) {
+	return &protos.UpdateComponentsReply{}, nil
+}
+
+func (h *fakeHandler) UpdateRoutingInfo(*protos.UpdateRoutingInfoRequest) (*protos.UpdateRoutingInfoReply, error) {
+	return &protos.UpdateRoutingInfoReply{}, nil
+}
+
+func (h *fakeHandler) GetHealth(*protos.GetHealthRequest) (*protos.GetHealthReply, error) {
+	return &protos.GetHealthReply{Status: protos.HealthStatus_HEALTHY}, nil
+}
+
+func (h *fakeHandler) Shutdown(*protos.ShutdownRequest) (*protos.ShutdownReply, error) {
+	// Exit after replying, like a real weavelet.
+	time.AfterFunc(10*time.Millisecond, func() { os.Exit(0) })
+	return &protos.ShutdownReply{}, nil
+}
+
+// newTestDeployer returns a deployer that runs the provided fake weavelet
+// command (see fakeWeavelet) in every weavelet. The deployer is stopped when
+// the test ends.
+func newTestDeployer(t *testing.T, config *multiConfig, args ...string) *deployer {
+	t.Helper()
+	logsDB, err := logging.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	d := &deployer{
+		ctx:          ctx,
+		ctxCancel:    cancel,
+		deploymentId: uuid.New().String(),
+		config:       &protos.AppConfig{Name: "app", Binary: executable, Args: args},
+		multiConfig:  config,
+		logger:       logging.NewTestLogger(t),
+		logsDB:       logsDB,
+		started:      time.Now(),
+		colocation:   map[string]string{},
+		groups:       map[string]*group{},
+		proxies:      map[string]*proxyInfo{},
+	}
+	t.Cleanup(func() {
+		d.stop(context.Canceled)
+		d.running.Wait() //nolint:errcheck // checked by the tests
+	})
+	return d
+}
+
+// startGroup starts a co-location group with the single component "A".
+func startGroup(t *testing.T, d *deployer) *group {
+	t.Helper()
+	d.mu.Lock()
+	g := d.group("A")
+	g.components["A"] = true
+	d.mu.Unlock()
+	if err := d.startColocationGroup(g); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// setRestartOptions sets restartBackoff and healthyUptime for the duration of
+// the test.
+func setRestartOptions(t *testing.T, backoff time.Duration, uptime time.Duration) {
+	oldBackoff, oldUptime := restartBackoff, healthyUptime
+	t.Cleanup(func() { restartBackoff, healthyUptime = oldBackoff, oldUptime })
+	restartBackoff = retry.Options{BackoffMultiplier: 2, BackoffMinDuration: backoff}
+	healthyUptime = uptime
+}
+
+// waitFor waits until f returns true while holding d.mu.
+func waitFor(t *testing.T, d *deployer, what string, f func() bool) {
+	t.Helper()
+	for start := time.Now(); time.Since(start) < 10*time.Second; time.Sleep(5 * time.Millisecond) {
+		d.mu.Lock()
+		ok := f()
+		d.mu.Unlock()
+		if ok {
+			return
+		}
+	}
+	t.Fatalf("timed out waiting for %s", what)
+}
+
+// addresses returns the addresses of the weavelets of a group.
+//
+// REQUIRES: d.mu is held.
+func addresses(g *group) []string {
+	var addrs []string
+	for _, e := range g.envelopes {
+		addrs = append(addrs, e.WeaveletInfo().DialAddr)
+	}
+	return addrs
+}
+
+func TestRestartCrashedWeavelet(t *testing.T) {
+	setRestartOptions(t, 10*time.Millisecond, time.Minute)
+	marker := filepath.Join(t.TempDir(), "crashed")
+	config := &multiConfig{MaxRestarts: 1, Replicas: map[string]*replicaConfig{"A": {Min: 1, Max: 1}}}
+	d := newTestDeployer(t, config, "crash_once", marker, "50ms")
+	g := startGroup(t, d)
+
+	d.mu.Lock()
+	crashed := addresses(g)[0]
+	d.mu.Unlock()
+
+	// The crashed weavelet is replaced by a new one.
+	waitFor(t, d, "restart", func() bool { return g.restarts == 1 && len(g.envelopes) == 1 })
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if g.addresses[crashed] {
+		t.Errorf("crashed weavelet %s still registered", crashed)
+	}
+	if restarted := addresses(g)[0]; !g.addresses[restarted] {
+		t.Errorf("restarted weavelet %s not registered", restarted)
+	}
+	if g.restarting != 0 {
+		t.Errorf("restarting: got %d, want 0", g.restarting)
+	}
+	if d.err != nil {
+		t.Errorf("deployer stopped: %v", d.err)
+	}
+}
+
+func TestCrashLoop(t *testing.T) {
+	for _, test := range []struct {
+		name         string
+		maxRestarts  int
+		wantRestarts int
+		wantErr      string // if empty, any error
+	}{
+		{"NoRestarts", -1, 0, ""},
+		{"MaxRestarts", 2, 2, "crash looping: restarted 2 times in a row"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			setRestartOptions(t, 10*time.Millisecond, time.Minute)
+			config := &multiConfig{MaxRestarts: test.maxRestarts, Replicas: map[string]*replicaConfig{"A": {Min: 1, Max: 1}}}
+			d := newTestDeployer(t, config, "crash", "50ms")
+			g := startGroup(t, d)
+
+			// A crash looping weavelet stops the deployment.
+			if err := d.wait(); err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Fatalf("wait: got %v, want error containing %q", err, test.wantErr)
+			}
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			if g.restarts != test.wantRestarts {
+				t.Fatalf("restarts: got %d, want %d", g.restarts, test.wantRestarts)
+			}
+		})
+	}
+}
+
+func TestHealthyUptimeResetsRestarts(t *testing.T) {
+	// Every weavelet stays up for longer than healthyUptime before crashing,
+	// so it is never considered to be crash looping, even though it is
+	// restarted more than MaxRestarts times.
+	setRestartOptions(t, 10*time.Millisecond, 100*time.Millisecond)
+	config := &multiConfig{MaxRestarts: 1, Replicas: map[string]*replicaConfig{"A": {Min: 1, Max: 1}}}
+	d := newTestDeployer(t, config, "crash", "200ms")
+	g := startGroup(t, d)
+
+	waitFor(t, d, "three restarts", func() bool { return g.restarts >= 3 || d.err != nil })
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.err != nil {
+		t.Fatalf("deployer stopped: %v", d.err)
+	}
+}
+
+func TestUnregisterWhileRestarting(t *testing.T) {
+	// Use a long backoff, so that the test can observe the group while the
+	// crashed weavelet is being restarted.
+	setRestartOptions(t, 500*time.Millisecond, time.Minute)
+	marker := filepath.Join(t.TempDir(), "crashed")
+	config := &multiConfig{MaxRestarts: 1, Replicas: map[string]*replicaConfig{"A": {Min: 1, Max: 1}}}
+	d := newTestDeployer(t, config, "crash_once", marker, "50ms")
+	g := startGroup(t, d)
+
+	// Subscribe the weavelet to the routing info of a component in another
+	// group.
+	d.mu.Lock()
+	e := g.envelopes[0]
+	other := d.group("B")
+	other.subscribers["B"] = []*envelope.Envelope{e}
+	d.mu.Unlock()
+
+	// While the crashed weavelet is being restarted, it is no longer a
+	// replica of its group, nor a subscriber of the other group.
+	waitFor(t, d, "crash", func() bool { return g.restarting == 1 })
+	d.mu.Lock()
+	if got := g.routing("A").Replicas; len(got) != 0 {
+		t.Errorf("replicas while restarting: got %v, want none", got)
+	}
+	if got := len(g.envelopes); got != 0 {
+		t.Errorf("envelopes while restarting: got %d, want 0", got)
+	}
+	if got := other.subscribers["B"]; len(got) != 0 {
+		t.Errorf("subscribers while restarting: got %d, want 0", len(got))
+	}
+	d.mu.Unlock()
+
+	// Once restarted, the new weavelet is a replica again.
+	waitFor(t, d, "restart", func() bool { return g.restarts == 1 })
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if got := g.routing("A").Replicas; len(got) != 1 || got[0] != addresses(g)[0] {
+		t.Errorf("replicas after restart: got %v, want %v", got, addresses(g))
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime/envelope"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// How often the deployer checks the health of weavelets.
+const healthCheckInterval = 5 * time.Second
+
+// checkHealth periodically checks the health of every weavelet. Unhealthy
+// weavelets are removed from the routing info of their co-location group and
+// from the proxies of their listeners, until they become healthy again.
+// checkHealth returns when the deployer is stopped.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) checkHealth() error {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.ctx.Done():
+			return d.ctx.Err()
+		case <-ticker.C:
+			d.mu.Lock()
+			groups := maps.Values(d.groups)
+			d.mu.Unlock()
+			for _, g := range groups {
+				if err := d.checkGroupHealth(g); err != nil {
+					d.logger.Error("health check", "err", err, "group", g.name)
+				}
+			}
+		}
+	}
+}
+
+// checkGroupHealth checks the health of the weavelets in the provided
+// co-location group, and updates the group's routing info and proxy backends
+// if the health of any weavelet changed.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) checkGroupHealth(g *group) error {
+	d.mu.Lock()
+	envelopes := slices.Clone(g.envelopes)
+	d.mu.Unlock()
+
+	// Check the health of the weavelets without holding the lock, since the
+	// Health methods of components may be slow.
+	problems := make(map[*envelope.Envelope][]string, len(envelopes))
+	for _, e := range envelopes {
+		reply, err := e.GetComponentHealth()
+		if err != nil {
+			problems[e] = []string{err.Error()}
+			continue
+		}
+		problems[e] = nil
+		for _, c := range reply.Components {
+			if c.Status != protos.HealthStatus_HEALTHY {
+				problems[e] = append(problems[e], fmt.Sprintf("%s: %s", c.Component, c.Error))
+			}
+		}
+		if reply.Status != protos.HealthStatus_HEALTHY && len(problems[e]) == 0 {
+			problems[e] = []string{reply.Status.String()}
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	changed := false
+	for e, problems := range problems {
+		addr := e.WeaveletInfo().DialAddr
+		healthy := len(problems) == 0
+		if !g.addresses[addr] || g.unhealthy[addr] == !healthy {
+			// The weavelet was removed, or its health didn't change.
+			continue
+		}
+		changed = true
+		if healthy {
+			d.logger.Info("Weavelet healthy", "group", g.name, "pid", e.WeaveletInfo().Pid)
+			delete(g.unhealthy, addr)
+		} else {
+			d.logger.Error("Weavelet unhealthy", "group", g.name, "pid", e.WeaveletInfo().Pid, "problems", problems)
+			g.unhealthy[addr] = true
+		}
+		for _, b := range g.backends[addr] {
+			b.proxy.SetHealthy(b.addr, healthy)
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return d.updateRouting(g)
+}
@@ -48,6 +48,7 @@ var (
 		Paths: []string{
 			logdir,
 			must.Must(defaultRegistryDir()),
+			must.Must(defaultSocketDir()),
 		},
 	}
 
@@ -26,6 +26,7 @@ import (
 	"os/signal"
 	"os/user"
 	"path/filepath"
+	"regexp"
 	"syscall"
 
 	"github.com/google/uuid"
@@ -157,21 +158,47 @@ func copyBinaries(locs []string, dep *protos.Deployment) error {
 	return nil
 }
 
-// terminateDeployment terminates all the processes corresponding to the deployment
-// at all locations.
+// terminateDeployment terminates the deployment at all locations. The
+// babysitters are sent a SIGTERM, which they handle by gracefully shutting
+// down their weavelets, and terminateDeployment waits for them to exit.
+// Weavelets are not signaled directly, so that they get a chance to drain
+// their calls and run the Shutdown methods of their components.
 //
 // TODO(rgrandl): Find a different way to kill the deployment if the pkill command
 // is not installed.
 func terminateDeployment(locs []string, dep *protos.Deployment) error {
+	pattern := babysitterPattern(dep)
+	// Give the babysitters a bit longer than their shutdown grace period.
+	const polls = 150 // polls, 100ms apart
+	script := fmt.Sprintf(
+		"pkill -TERM -f '%[1]s'; i=0; while pgrep -f '%[1]s' > /dev/null && [ $i -lt %[2]d ]; do sleep 0.1; i=$((i+1)); done",
+		pattern, polls)
 	for _, loc := range locs {
-		cmd := exec.Command("ssh", loc, "pkill", "-f", dep.Id)
-		if err := cmd.Run(); err != nil {
+		if err := remoteCommand(loc, script).Run(); err != nil {
 			return fmt.Errorf("unable to terminate deployment at location %s: %w", loc, err)
 		}
 	}
 	return nil
 }
 
+// babysitterPattern returns a pkill/pgrep pattern that matches the command
+// line of the babysitters of the provided deployment, but not the command
+// lines of their weavelets, nor the command line of the shell that runs
+// pkill or pgrep with the pattern.
+func babysitterPattern(dep *protos.Deployment) string {
+	// See manager.startBabysitter.
+	cmd := filepath.Join(os.TempDir(), dep.Id, "weaver") + " ssh babysitter"
+	// Putting the first character in brackets keeps the pattern from
+	// matching its own text.
+	return "[" + cmd[:1] + "]" + regexp.QuoteMeta(cmd[1:])
+}
+
+// remoteCommand returns a command that runs the provided shell command at the
+// provided location. It is a variable so that tests can run commands locally.
+var remoteCommand = func(loc, command string) *exec.Cmd {
+	return exec.Command("ssh", loc, command)
+}
+
 // getLocations returns the list of locations at which to deploy the application.
 func getLocations(app *protos.AppConfig) ([]string, error) {
 	// SSH config as found in TOML config file.
@@ -0,0 +1,104 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"github.com/google/uuid"
+)
+
+// TestTerminateDeployment tests that terminateDeployment signals the
+// babysitters of a deployment, but not their weavelets, and waits for the
+// babysitters to shut down.
+func TestTerminateDeployment(t *testing.T) {
+	if _, err := exec.LookPath("pkill"); err != nil {
+		t.Skip("pkill not installed")
+	}
+
+	// Run "remote" commands locally.
+	defer func(f func(string, string) *exec.Cmd) { remoteCommand = f }(remoteCommand)
+	remoteCommand = func(_, command string) *exec.Cmd {
+		return exec.Command("sh", "-c", command)
+	}
+
+	// Lay out the deployment directory like copyBinaries does. The fake
+	// babysitter and weavelet are shell scripts, so that their command lines
+	// look like the command lines of the real processes.
+	dep := &protos.Deployment{Id: uuid.New().String()}
+	dir := filepath.Join(os.TempDir(), dep.Id)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	drained := filepath.Join(dir, "drained")
+	write := func(name, script string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(script), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	babysitterScript := write("weaver", `
+trap 'sleep 0.5; touch `+drained+`; exit 0' TERM
+while true; do sleep 0.1; done
+`)
+	weaveletScript := write("app", "while true; do sleep 0.1; done\n")
+
+	start := func(args ...string) <-chan error {
+		t.Helper()
+		cmd := exec.Command("sh", args...)
+		if err := cmd.Start(); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { cmd.Process.Kill() })
+		exited := make(chan error, 1)
+		go func() { exited <- cmd.Wait() }()
+		return exited
+	}
+	babysitter := start(babysitterScript, "ssh", "babysitter")
+	weavelet := start(weaveletScript)
+	time.Sleep(100 * time.Millisecond) // let the traps be installed
+
+	if err := terminateDeployment([]string{"localhost"}, dep); err != nil {
+		t.Fatal(err)
+	}
+
+	// The babysitter was given time to shut down gracefully.
+	select {
+	case err := <-babysitter:
+		if err != nil {
+			t.Fatalf("babysitter: %v", err)
+		}
+	default:
+		t.Fatal("babysitter still running")
+	}
+	if _, err := os.Stat(drained); err != nil {
+		t.Fatalf("babysitter didn't shut down gracefully: %v", err)
+	}
+
+	// The weavelet wasn't signaled; it is shut down by its babysitter.
+	select {
+	case err := <-weavelet:
+		t.Fatalf("weavelet exited: %v", err)
+	default:
+	}
+}
@@ -17,9 +17,12 @@ package impl
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"os/signal"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/ServiceWeaver/weaver/internal/proto"
@@ -36,6 +39,10 @@ import (
 	"golang.org/x/exp/slog"
 )
 
+// The amount of time a weavelet is given to shut down gracefully when the
+// babysitter is terminated.
+const shutdownGracePeriod = 10 * time.Second
+
 // babysitter starts and manages weavelets belonging to a single colocation
 // group for a single application version, on the local machine.
 type babysitter struct {
@@ -54,9 +61,13 @@ var _ envelope.EnvelopeHandler = &babysitter{}
 // RunBabysitter creates and runs an envelope.Envelope and a metrics collector for a
 // weavelet deployed with SSH.
 func RunBabysitter(ctx context.Context) error {
-	// Retrieve the deployment information.
+	// Retrieve the deployment information, which the manager passes on stdin.
+	input, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("unable to read deployment info: %w", err)
+	}
 	info := &BabysitterInfo{}
-	if err := proto.FromEnv(os.Getenv(babysitterInfoKey), info); err != nil {
+	if err := proto.FromEnv(string(input), info); err != nil {
 		return fmt.Errorf("unable to retrieve deployment info: %w", err)
 	}
 
@@ -67,7 +78,7 @@ func RunBabysitter(ctx context.Context) error {
 	}
 	logSaver := fs.Add
 
-	id := uuid.New().String()
+	id := info.WeaveletId
 	b := &babysitter{
 		ctx:  ctx,
 		info: info,
@@ -100,6 +111,7 @@ func RunBabysitter(ctx context.Context) error {
 		Sections:      info.Deployment.App.Sections,
 		SingleProcess: info.Deployment.SingleProcess,
 		RunMain:       info.RunMain,
+		Mtls:          info.Mtls,
 	}
 	e, err := envelope.NewEnvelope(ctx, wlet, info.Deployment.App)
 	if err != nil {
@@ -121,6 +133,18 @@ func RunBabysitter(ctx context.Context) error {
 	}
 	c := metricsCollector{logger: b.logger, envelope: e, info: info}
 	go c.run(ctx)
+
+	// Gracefully shut down the weavelet when the babysitter is terminated.
+	terminated := make(chan os.Signal, 1)
+	signal.Notify(terminated, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-terminated
+		ctx, cancel := context.WithTimeout(ctx, shutdownGracePeriod)
+		defer cancel()
+		if err := e.Shutdown(ctx); err != nil {
+			b.logger.Error("shutdown", "err", err)
+		}
+	}()
 	return e.Serve(b)
 }
 
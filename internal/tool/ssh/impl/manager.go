@@ -23,6 +23,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -44,6 +45,7 @@ import (
 	"github.com/ServiceWeaver/weaver/internal/traceio"
 	"github.com/ServiceWeaver/weaver/internal/versioned"
 	"github.com/ServiceWeaver/weaver/runtime/logging"
+	"github.com/ServiceWeaver/weaver/runtime/mtls"
 	"github.com/ServiceWeaver/weaver/runtime/protomsg"
 	"github.com/ServiceWeaver/weaver/runtime/retry"
 	"github.com/google/uuid"
@@ -60,10 +62,6 @@ const (
 	recvLogEntryURL         = "/manager/recv_log_entry"
 	recvTraceSpansURL       = "/manager/recv_trace_spans"
 	recvMetricsURL          = "/manager/recv_metrics"
-
-	// babysitterInfoKey is the name of the env variable that contains deployment
-	// information for a babysitter deployed using SSH.
-	babysitterInfoKey = "SERVICEWEAVER_BABYSITTER_INFO"
 )
 
 // manager manages an application version deployment across a set of locations,
@@ -79,6 +77,7 @@ type manager struct {
 	logDir     string
 	locations  []string // addresses of the locations
 	mgrAddress string   // manager address
+	ca         *mtls.CA // issues the weavelets' mTLS certificates
 	registry   *status.Registry
 	started    time.Time
 
@@ -176,6 +175,13 @@ func RunManager(ctx context.Context, dep *protos.Deployment, locations []string,
 		}
 	}
 
+	// Create the deployment's certificate authority, which issues the
+	// certificates that weavelets use to authenticate each other.
+	ca, err := mtls.NewCA(dep.Id)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the manager.
 	m := &manager{
 		ctx:            ctx,
@@ -183,6 +189,7 @@ func RunManager(ctx context.Context, dep *protos.Deployment, locations []string,
 		locations:      locations,
 		logger:         logger,
 		logDir:         logDir,
+		ca:             ca,
 		logSaver:       logSaver,
 		traceSaver:     traceSaver,
 		statsProcessor: imetrics.NewStatsProcessor(),
@@ -410,6 +417,17 @@ func (m *manager) group(component string) *group {
 	return g
 }
 
+// members returns the components that the named co-location group may host.
+func (m *manager) members(group string) []string {
+	components := []string{group}
+	for component, name := range m.colocation {
+		if name == group && component != group {
+			components = append(components, component)
+		}
+	}
+	return components
+}
+
 // allAddresses returns a copy of all current addresses in the group.
 //
 // REQUIRES: g.mu is NOT held.
@@ -571,6 +589,11 @@ func (m *manager) startColocationGroup(g *group, runMain bool) error {
 	// TODO(rgrandl): Implement some smarter logic to determine the number of
 	// replicas for each group.
 	for replicaId, loc := range m.locations {
+		id := uuid.New().String()
+		creds, err := m.ca.Issue(id, m.members(g.name))
+		if err != nil {
+			return fmt.Errorf("unable to issue certificate for group %s: %w", g.name, err)
+		}
 		info := &BabysitterInfo{
 			ManagerAddr: m.mgrAddress,
 			Deployment:  m.dep,
@@ -578,6 +601,8 @@ func (m *manager) startColocationGroup(g *group, runMain bool) error {
 			ReplicaId:   int32(replicaId),
 			LogDir:      m.logDir,
 			RunMain:     runMain,
+			WeaveletId:  id,
+			Mtls:        creds,
 		}
 		if err := m.startBabysitter(loc, info); err != nil {
 			return fmt.Errorf("unable to start babysitter for group %s at location %s: %w\n", g.name, loc, err)
@@ -613,9 +638,11 @@ func (m *manager) startBabysitter(loc string, info *BabysitterInfo) error {
 		return err
 	}
 
-	env := fmt.Sprintf("%s=%s", babysitterInfoKey, input)
+	// The info is passed on stdin, rather than on the command line, because
+	// it contains the weavelet's private key.
 	binaryPath := filepath.Join(os.TempDir(), m.dep.Id, "weaver")
-	cmd := exec.Command("ssh", loc, env, binaryPath, "ssh", "babysitter")
+	cmd := exec.Command("ssh", loc, binaryPath, "ssh", "babysitter")
+	cmd.Stdin = strings.NewReader(input)
 	return cmd.Start()
 }
 
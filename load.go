@@ -20,6 +20,7 @@ import (
 	"math/rand"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/DataDog/hyperloglog"
@@ -386,3 +387,43 @@ func (ind index) find(key uint64) (slice, bool) {
 	}
 	return ind[i], true
 }
+
+// callCounter counts the calls received by the methods of a component. Unlike
+// a loadCollector, a callCounter counts the calls of every component, routed
+// or not.
+type callCounter struct {
+	calls map[string]*atomic.Uint64 // keyed by method name
+}
+
+// newCallCounter returns a new call counter for the provided methods.
+func newCallCounter(methods []string) *callCounter {
+	calls := make(map[string]*atomic.Uint64, len(methods))
+	for _, method := range methods {
+		calls[method] = &atomic.Uint64{}
+	}
+	return &callCounter{calls: calls}
+}
+
+// add counts a call to the provided method.
+func (c *callCounter) add(method string) {
+	if n, ok := c.calls[method]; ok {
+		n.Add(1)
+	}
+}
+
+// report returns the number of calls received by every method since the
+// previous call to report, or nil if no calls were received.
+func (c *callCounter) report() *protos.LoadReport_MethodCalls {
+	var report *protos.LoadReport_MethodCalls
+	for method, n := range c.calls {
+		calls := n.Swap(0)
+		if calls == 0 {
+			continue
+		}
+		if report == nil {
+			report = &protos.LoadReport_MethodCalls{Calls: map[string]uint64{}}
+		}
+		report.Calls[method] = calls
+	}
+	return report
+}
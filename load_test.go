@@ -230,6 +230,30 @@ func TestLoadCollectorSizeAndSplitEstimates(t *testing.T) {
 	}
 }
 
+func TestCallCounter(t *testing.T) {
+	c := newCallCounter([]string{"Get", "Put", "Delete"})
+	if got := c.report(); got != nil {
+		t.Fatalf("report before any calls: got %v, want nil", got)
+	}
+
+	for i := 0; i < 3; i++ {
+		c.add("Get")
+	}
+	c.add("Put")
+	c.add("Unknown")
+	want := &protos.LoadReport_MethodCalls{Calls: map[string]uint64{"Get": 3, "Put": 1}}
+	if diff := cmp.Diff(want, c.report(), protocmp.Transform()); diff != "" {
+		t.Fatalf("report (-want +got):\n%s", diff)
+	}
+
+	// Calls are counted since the previous report.
+	c.add("Delete")
+	want = &protos.LoadReport_MethodCalls{Calls: map[string]uint64{"Delete": 1}}
+	if diff := cmp.Diff(want, c.report(), protocmp.Transform()); diff != "" {
+		t.Fatalf("report (-want +got):\n%s", diff)
+	}
+}
+
 func TestSubslices(t *testing.T) {
 	for _, test := range []struct {
 		load float64
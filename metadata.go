@@ -0,0 +1,97 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaver
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver/internal/net/call"
+)
+
+// WithMetadata returns a copy of ctx with the provided key/value metadata
+// attached, in addition to any metadata already attached to ctx. Entries in
+// md override existing entries with the same key.
+//
+// Metadata is propagated across component method calls: a component method
+// called with a context that carries metadata sees the same metadata in its
+// own context, whether the method is called locally or remotely. This is
+// useful for request-scoped values like a tenant or request ID. For example:
+//
+//	ctx = weaver.WithMetadata(ctx, map[string]string{"tenant": "acme"})
+//	err := store.Put(ctx, key, value)
+//
+//	func (s *store) Put(ctx context.Context, key, value string) error {
+//	    tenant := weaver.Metadata(ctx)["tenant"]
+//	    ...
+//	}
+//
+// Metadata is sent with every remote call, so keep it small.
+func WithMetadata(ctx context.Context, md map[string]string) context.Context {
+	return call.ContextWithMetadata(ctx, md)
+}
+
+// Metadata returns a copy of the metadata attached to ctx. See WithMetadata.
+func Metadata(ctx context.Context) map[string]string {
+	md := call.MetadataFromContext(ctx)
+	copied := make(map[string]string, len(md))
+	for k, v := range md {
+		copied[k] = v
+	}
+	return copied
+}
+
+// Criticality is the criticality of a component method call. See
+// WithCriticality.
+type Criticality = call.Criticality
+
+const (
+	// Sheddable calls, like background batch work, are the first to be shed
+	// by an overloaded component.
+	Sheddable Criticality = call.Sheddable
+
+	// DefaultCriticality is the criticality of calls by default.
+	DefaultCriticality Criticality = call.DefaultCriticality
+
+	// Critical calls, like user-facing requests, are the last to be shed by
+	// an overloaded component.
+	Critical Criticality = call.Critical
+)
+
+// WithCriticality returns a copy of ctx with the provided criticality
+// attached. Component method calls made with the returned context have the
+// provided criticality. For example:
+//
+//	ctx = weaver.WithCriticality(ctx, weaver.Sheddable)
+//	err := indexer.Reindex(ctx)
+//
+// Like metadata, the criticality of a call is propagated across component
+// method calls, so that the calls made by a method have the criticality of the
+// call to the method, unless the method attaches a different criticality.
+//
+// A component with a concurrency limit sheds less critical calls first when it
+// is overloaded: sheddable calls in excess of the limit are rejected rather
+// than queued, and queued calls run in order of criticality. A client sends
+// more critical calls first when calls queue up to be sent to a component. The
+// criticality of a call is recorded in the serviceweaver.criticality attribute
+// of its trace span.
+func WithCriticality(ctx context.Context, c Criticality) context.Context {
+	return call.ContextWithCriticality(ctx, c)
+}
+
+// CriticalityOf returns the criticality attached to ctx, or DefaultCriticality
+// if there is none. See WithCriticality.
+func CriticalityOf(ctx context.Context) Criticality {
+	return call.CriticalityFromContext(ctx)
+}
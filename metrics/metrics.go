@@ -131,6 +131,13 @@ func (g *GaugeMap[L]) Get(labels L) *Gauge {
 	return &Gauge{g.impl.Get(labels)}
 }
 
+// Delete deletes the Gauge with the provided labels, if it exists, so that it
+// is no longer exported. A later call to Get with the same labels returns a
+// new Gauge.
+func (g *GaugeMap[L]) Delete(labels L) {
+	g.impl.Delete(labels)
+}
+
 // A Histogram is a metric that counts the number of values that fall in
 // specified ranges (i.e. buckets). For example, you can use a Histogram to
 // measure the distribution of request latencies.
@@ -129,14 +129,24 @@ func (e *remoteEnv) CreateTraceExporter() sdktrace.SpanExporter {
 }
 
 // parseEndpoints parses a list of endpoint addresses into a list of
-// call.Endpoints.
-func parseEndpoints(addrs []string) ([]call.Endpoint, error) {
+// call.Endpoints. unixAddrs holds the Unix socket addresses of endpoints on
+// the same machine, keyed by endpoint address. Such endpoints are dialed at
+// their Unix socket address, if possible.
+func parseEndpoints(addrs []string, unixAddrs map[string]string) ([]call.Endpoint, error) {
 	var endpoints []call.Endpoint
 	for _, addr := range addrs {
-		endpoint, err := call.ParseNetEndpoint(addr)
+		ne, err := call.ParseNetEndpoint(addr)
 		if err != nil {
 			return nil, err
 		}
+		var endpoint call.Endpoint = ne
+		if unixAddr, ok := unixAddrs[addr]; ok {
+			local, err := call.ParseNetEndpoint(unixAddr)
+			if err != nil {
+				return nil, err
+			}
+			endpoint = call.PreferLocal(endpoint, local)
+		}
 		endpoints = append(endpoints, endpoint)
 	}
 	return endpoints, nil
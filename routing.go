@@ -18,30 +18,159 @@ import (
 	"context"
 	"math/rand"
 	"sync"
+	"time"
 
 	"github.com/ServiceWeaver/weaver/internal/cond"
 	"github.com/ServiceWeaver/weaver/internal/net/call"
+	"github.com/ServiceWeaver/weaver/metrics"
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
 	"github.com/ServiceWeaver/weaver/runtime/protos"
 	"github.com/google/uuid"
 )
 
 // routingBalancer balances requests according to a routing assignment.
+//
+// If the default balancer is a call.LoadBalancer, the routingBalancer informs
+// it of the start and end of every call, and exports its view of the load of
+// every endpoint as metrics.
 type routingBalancer struct {
 	balancer call.Balancer // default balancer
 
+	// The endpoints passed to Update, keyed by address. A routed call is sent
+	// to the endpoint with the address of a replica in the assignment, so
+	// that the replica is dialed the same way as by the default balancer
+	// (e.g., over a Unix socket). endpoints is guarded by the client that
+	// uses rb.
+	endpoints map[string]call.Endpoint
+
+	// The following fields label and hold the exported load of every
+	// endpoint, keyed by endpoint address. They are only used if balancer is
+	// a call.LoadBalancer, and they are guarded by the client that uses rb.
+	component string
+	name      string
+	loads     map[string]*loadMetrics
+
 	mu         sync.RWMutex
 	assignment *protos.Assignment
 	index      index
 }
 
+// loadMetrics holds the metrics that export the load of an endpoint.
+type loadMetrics struct {
+	outstanding *metrics.Gauge // see codegen.BalancerOutstandingCalls
+	latency     *metrics.Gauge // see codegen.BalancerLatencies
+}
+
+var _ call.LoadBalancer = &routingBalancer{}
+
 // newRoutingBalancer returns a new routingBalancer.
 func newRoutingBalancer() *routingBalancer {
 	return &routingBalancer{balancer: call.RoundRobin()}
 }
 
+// setBalancer sets the default balancer, with the provided name, that
+// balances calls to the provided component.
+//
+// REQUIRES: rb is not yet used by a client.
+func (rb *routingBalancer) setBalancer(component, name string, balancer call.Balancer) {
+	rb.component = component
+	rb.name = name
+	rb.balancer = balancer
+}
+
 // Update implements the call.Balancer interface.
 func (rb *routingBalancer) Update(endpoints []call.Endpoint) {
 	rb.balancer.Update(endpoints)
+	if !sameAddresses(rb.endpoints, endpoints) {
+		rb.endpoints = make(map[string]call.Endpoint, len(endpoints))
+		for _, endpoint := range endpoints {
+			rb.endpoints[endpoint.Address()] = endpoint
+		}
+	}
+	if _, ok := rb.balancer.(call.LoadBalancer); ok {
+		rb.updateLoads(endpoints)
+	}
+}
+
+// updateLoads updates the set of endpoints whose load is exported.
+func (rb *routingBalancer) updateLoads(endpoints []call.Endpoint) {
+	if sameAddresses(rb.loads, endpoints) {
+		return
+	}
+
+	loads := make(map[string]*loadMetrics, len(endpoints))
+	for _, endpoint := range endpoints {
+		addr := endpoint.Address()
+		if m, ok := rb.loads[addr]; ok {
+			loads[addr] = m
+			delete(rb.loads, addr)
+			continue
+		}
+		labels := codegen.BalancerLabels{Component: rb.component, Balancer: rb.name, Endpoint: addr}
+		loads[addr] = &loadMetrics{
+			outstanding: codegen.BalancerOutstandingCalls.Get(labels),
+			latency:     codegen.BalancerLatencies.Get(labels),
+		}
+	}
+	// Stop exporting the load of removed endpoints.
+	for addr := range rb.loads {
+		labels := codegen.BalancerLabels{Component: rb.component, Balancer: rb.name, Endpoint: addr}
+		codegen.BalancerOutstandingCalls.Delete(labels)
+		codegen.BalancerLatencies.Delete(labels)
+	}
+	rb.loads = loads
+}
+
+// sameAddresses returns true if the addresses of the provided endpoints are
+// the keys of m. Update is called for every call to a routed component,
+// usually with an unchanged set of endpoints, so that state derived from the
+// endpoints is only recomputed when the endpoints change.
+func sameAddresses[V any](m map[string]V, endpoints []call.Endpoint) bool {
+	if len(endpoints) != len(m) {
+		return false
+	}
+	for _, endpoint := range endpoints {
+		if _, ok := m[endpoint.Address()]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Begin implements the call.LoadBalancer interface.
+func (rb *routingBalancer) Begin(endpoint call.Endpoint) {
+	if lb, ok := rb.balancer.(call.LoadBalancer); ok {
+		lb.Begin(endpoint)
+		rb.export(lb, endpoint)
+	}
+}
+
+// End implements the call.LoadBalancer interface.
+func (rb *routingBalancer) End(endpoint call.Endpoint, latency time.Duration) {
+	if lb, ok := rb.balancer.(call.LoadBalancer); ok {
+		lb.End(endpoint, latency)
+		rb.export(lb, endpoint)
+	}
+}
+
+// Load implements the call.LoadBalancer interface.
+func (rb *routingBalancer) Load(endpoint call.Endpoint) call.Load {
+	if lb, ok := rb.balancer.(call.LoadBalancer); ok {
+		return lb.Load(endpoint)
+	}
+	return call.Load{}
+}
+
+// export exports the load of the provided endpoint, as tracked by lb.
+func (rb *routingBalancer) export(lb call.LoadBalancer, endpoint call.Endpoint) {
+	m, ok := rb.loads[endpoint.Address()]
+	if !ok {
+		// The endpoint was removed.
+		return
+	}
+	load := lb.Load(endpoint)
+	m.outstanding.Set(float64(load.Outstanding))
+	m.latency.Set(float64(load.Latency.Microseconds()))
 }
 
 // update updates the balancer with the provided assignment
@@ -92,7 +221,10 @@ func (rb *routingBalancer) Pick(opts call.CallOptions) (call.Endpoint, error) {
 	// TODO(mwhittaker): Parse the endpoints when an assignment is received,
 	// rather than once per call.
 	addr := slice.replicas[rand.Intn(len(slice.replicas))]
-	endpoints, err := parseEndpoints([]string{addr})
+	if endpoint, ok := rb.endpoints[addr]; ok {
+		return endpoint, nil
+	}
+	endpoints, err := parseEndpoints([]string{addr}, nil)
 	if err != nil {
 		return nil, err
 	}
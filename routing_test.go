@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/ServiceWeaver/weaver/internal/net/call"
+	"github.com/ServiceWeaver/weaver/runtime/metrics"
 	"github.com/ServiceWeaver/weaver/runtime/protos"
 	"github.com/google/go-cmp/cmp"
 )
@@ -103,6 +104,42 @@ func TestRoutingBalancer(t *testing.T) {
 	}
 }
 
+// TestRoutingBalancerUnixReplicas tests that a routingBalancer picks replicas
+// on the same machine at their Unix socket address.
+func TestRoutingBalancerUnixReplicas(t *testing.T) {
+	endpoints, err := parseEndpoints([]string{"tcp://a", "tcp://b"}, map[string]string{"tcp://a": "unix:///a.sock"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := call.BalancerFunc(func([]call.Endpoint, call.CallOptions) (call.Endpoint, error) {
+		return nil, fmt.Errorf("default balancer called")
+	})
+	rb := routingBalancer{balancer: b}
+	rb.Update(endpoints)
+	rb.update(&protos.Assignment{
+		Slices: []*protos.Assignment_Slice{
+			{Start: 0, Replicas: []string{"tcp://a"}},
+			{Start: 100, Replicas: []string{"tcp://b"}},
+		},
+	})
+
+	for _, test := range []struct {
+		shardKey uint64
+		want     call.Endpoint
+	}{
+		{20, call.PreferLocal(call.TCP("a"), call.Unix("/a.sock"))},
+		{120, call.TCP("b")},
+	} {
+		got, err := rb.Pick(call.CallOptions{ShardKey: test.shardKey})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Fatalf("rb.Pick(%d): got %#v, want %#v", test.shardKey, got, test.want)
+		}
+	}
+}
+
 // TestRoutingResolverInitialResolve tests that the first Resolve invocation on
 // a routingResolver returns a nil set of endpoints but a non-nil version.
 func TestRoutingResolverInitialResolve(t *testing.T) {
@@ -152,3 +189,56 @@ func TestRoutingResolverUpdate(t *testing.T) {
 		t.Fatalf("endpoints (-want +got):\n%s", diff)
 	}
 }
+
+// TestRoutingBalancerLoad tests that a routingBalancer exports the load of
+// every endpoint tracked by its default balancer.
+func TestRoutingBalancerLoad(t *testing.T) {
+	const component = "TestRoutingBalancerLoad"
+	find := func(name, addr string) (float64, bool) {
+		t.Helper()
+		for _, m := range metrics.Snapshot() {
+			if m.Name == name && m.Labels["component"] == component && m.Labels["endpoint"] == addr {
+				if got, want := m.Labels["balancer"], "least_loaded"; got != want {
+					t.Fatalf("balancer label: got %q, want %q", got, want)
+				}
+				return m.Value, true
+			}
+		}
+		return 0, false
+	}
+	load := func(name, addr string) float64 {
+		t.Helper()
+		value, ok := find(name, addr)
+		if !ok {
+			t.Fatalf("metric %s for %s not found", name, addr)
+		}
+		return value
+	}
+	const outstanding = "serviceweaver_balancer_outstanding_calls"
+	const latency = "serviceweaver_balancer_latency_micros"
+
+	rb := newRoutingBalancer()
+	rb.setBalancer(component, "least_loaded", call.LeastLoaded())
+	a, b := nilEndpoint{"a"}, nilEndpoint{"b"}
+	rb.Update([]call.Endpoint{a, b})
+	rb.Begin(a)
+	if got, want := load(outstanding, "a"), 1.0; got != want {
+		t.Fatalf("outstanding calls to a: got %v, want %v", got, want)
+	}
+	rb.End(a, time.Millisecond)
+	if got, want := load(outstanding, "a"), 0.0; got != want {
+		t.Fatalf("outstanding calls to a: got %v, want %v", got, want)
+	}
+	if got := load(latency, "a"); got <= 0 || got > 1000 {
+		t.Fatalf("latency of a: got %v, want in (0, 1000]", got)
+	}
+
+	// The load of removed endpoints is no longer exported.
+	rb.Update([]call.Endpoint{b})
+	for _, name := range []string{outstanding, latency} {
+		if _, ok := find(name, "a"); ok {
+			t.Fatalf("metric %s for a not deleted", name)
+		}
+	}
+	load(outstanding, "b")
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"fmt"
+
+	"github.com/ServiceWeaver/weaver/runtime"
+)
+
+const (
+	// Keys of the config section that holds the authorization policy.
+	authorizationKey      = "github.com/ServiceWeaver/weaver/authorization"
+	shortAuthorizationKey = "authorization"
+)
+
+// authorizationConfig holds the authorization policy found in the
+// [authorization] section of a config file, keyed by component name. The
+// policy of a component lists the components allowed to call its methods, and
+// can be overridden for individual methods. For example:
+//
+//	[authorization."github.com/example/app/Cache"]
+//	callers = ["main", "github.com/example/app/Frontend"]
+//
+//	[authorization."github.com/example/app/Cache".methods.Put]
+//	callers = ["github.com/example/app/Writer"]
+//
+// The methods of a component that is not listed, or for which no callers are
+// listed, may be called by any component.
+type authorizationConfig map[string]*componentAuthorizationConfig
+
+// componentAuthorizationConfig holds the authorization policy for a component.
+type componentAuthorizationConfig struct {
+	Callers []string
+	Methods map[string]*methodAuthorizationConfig
+}
+
+// methodAuthorizationConfig holds the authorization policy for a method.
+type methodAuthorizationConfig struct {
+	Callers []string
+}
+
+// Validate validates the policy.
+func (c authorizationConfig) Validate() error {
+	for component, cc := range c {
+		if err := validateCallers(cc.Callers); err != nil {
+			return fmt.Errorf("component %q: %w", component, err)
+		}
+		for method, mc := range cc.Methods {
+			if err := validateCallers(mc.Callers); err != nil {
+				return fmt.Errorf("component %q method %q: %w", component, method, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateCallers(callers []string) error {
+	for _, caller := range callers {
+		if caller == "" {
+			return fmt.Errorf("empty caller")
+		}
+	}
+	return nil
+}
+
+// isAuthorizationSection returns true if key is the key of the
+// [authorization] section.
+func isAuthorizationSection(key string) bool {
+	return key == authorizationKey || key == shortAuthorizationKey
+}
+
+// AllowedCallers returns the set of components allowed to call every method of
+// the provided component, keyed by method name, as specified by the
+// [authorization] section found in sections, if any. A method that is missing
+// from the returned map may be called by any component.
+func AllowedCallers(reg *Registration, sections map[string]string) (map[string]map[string]bool, error) {
+	var config authorizationConfig
+	if err := runtime.ParseConfigSection(authorizationKey, shortAuthorizationKey, sections, &config); err != nil {
+		return nil, err
+	}
+	cc := config[reg.Name]
+	if cc == nil {
+		return nil, nil
+	}
+
+	n := reg.Iface.NumMethod()
+	allowed := make(map[string]map[string]bool, n)
+	for i := 0; i < n; i++ {
+		name := reg.Iface.Method(i).Name
+		callers := cc.Callers
+		if mc, ok := cc.Methods[name]; ok {
+			callers = mc.Callers
+		}
+		if callers == nil {
+			// Neither the component nor the method list callers.
+			continue
+		}
+		set := make(map[string]bool, len(callers))
+		for _, caller := range callers {
+			set[caller] = true
+		}
+		allowed[name] = set
+	}
+	for name := range cc.Methods {
+		if _, ok := reg.Iface.MethodByName(name); !ok {
+			return nil, fmt.Errorf("section %q: component %q has no method %q", shortAuthorizationKey, reg.Name, name)
+		}
+	}
+	return allowed, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAllowedCallers(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		config string
+		want   map[string]map[string]bool
+	}{
+		{
+			name: "NoConfig",
+		},
+		{
+			name: "Component",
+			config: `
+[authorization."codegen_test/cache"]
+callers = ["main", "codegen_test/frontend"]
+`,
+			want: map[string]map[string]bool{
+				"Get": {"main": true, "codegen_test/frontend": true},
+				"Put": {"main": true, "codegen_test/frontend": true},
+			},
+		},
+		{
+			name: "Method",
+			config: `
+[authorization."codegen_test/cache"]
+callers = ["main"]
+
+[authorization."codegen_test/cache".methods.Put]
+callers = []
+`,
+			want: map[string]map[string]bool{
+				"Get": {"main": true},
+				"Put": {},
+			},
+		},
+		{
+			name: "MethodOnly",
+			config: `
+[authorization."codegen_test/cache".methods.Put]
+callers = ["codegen_test/writer"]
+`,
+			want: map[string]map[string]bool{
+				"Put": {"codegen_test/writer": true},
+			},
+		},
+		{
+			name: "OtherComponent",
+			config: `
+[authorization."codegen_test/other"]
+callers = ["main"]
+`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			sections := parseSections(t, test.config)
+			got, err := codegen.AllowedCallers(cacheRegistration, sections)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Fatalf("AllowedCallers (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAllowedCallersErrors(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		config string
+		want   string
+		syntax bool // is the error also caught by ComponentConfigValidator?
+	}{
+		{
+			name: "UnknownMethod",
+			config: `
+[authorization."codegen_test/cache".methods.Delete]
+callers = ["main"]
+`,
+			want: "no method",
+		},
+		{
+			name: "UnknownKey",
+			config: `
+[authorization."codegen_test/cache"]
+caller = ["main"]
+`,
+			want:   "unknown keys",
+			syntax: true,
+		},
+		{
+			name: "EmptyCaller",
+			config: `
+[authorization."codegen_test/cache"]
+callers = [""]
+`,
+			want:   "empty caller",
+			syntax: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			sections := parseSections(t, test.config)
+			_, err := codegen.AllowedCallers(cacheRegistration, sections)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("AllowedCallers: got error %v, want %q", err, test.want)
+			}
+			if !test.syntax {
+				return
+			}
+			err = codegen.ComponentConfigValidator("authorization", sections["authorization"])
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("ComponentConfigValidator: got error %v, want %q", err, test.want)
+			}
+		})
+	}
+}
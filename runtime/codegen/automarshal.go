@@ -14,9 +14,122 @@
 
 package codegen
 
+import (
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sync"
+)
+
 // AutoMarshal is the interface implemented by structs with weaver.AutoMarshal
 // declarations.
 type AutoMarshal interface {
 	WeaverMarshal(enc *Encoder)
 	WeaverUnmarshal(dec *Decoder)
 }
+
+// serializable contains all types registered with RegisterSerializable, keyed
+// by their fully qualified type name.
+var (
+	serializableMu sync.Mutex
+	serializable   = map[string]reflect.Type{}
+)
+
+// RegisterSerializable registers the type T, whose pointer type implements
+// AutoMarshal. Errors of type T or *T returned by a component method are
+// serialized along with their concrete type, which allows the caller of the
+// method to use errors.As on the returned error, even if the method was
+// executed remotely.
+//
+// RegisterSerializable is called by code generated by "weaver generate" for
+// every AutoMarshal type that implements the error interface.
+func RegisterSerializable[T any, PT interface {
+	*T
+	AutoMarshal
+}]() {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	serializableMu.Lock()
+	defer serializableMu.Unlock()
+	serializable[typeName(t)] = t
+}
+
+// serializableType returns the registered type with the provided name, or nil
+// if no such type has been registered.
+func serializableType(name string) reflect.Type {
+	serializableMu.Lock()
+	defer serializableMu.Unlock()
+	return serializable[name]
+}
+
+// typeName returns the fully qualified name of the provided type.
+func typeName(t reflect.Type) string {
+	return t.PkgPath() + "." + t.Name()
+}
+
+// impls contains all types registered with RegisterImpl, keyed by their type
+// tag, along with the reverse mapping.
+var (
+	implsMu  sync.Mutex
+	impls    = map[uint64]reflect.Type{}
+	implTags = map[reflect.Type]uint64{}
+)
+
+// RegisterImpl registers the type T, whose pointer type implements
+// AutoMarshal, as an implementation of the interface I. Values of type T or
+// *T stored in an interface-typed argument or result of a component method,
+// or in an interface-typed field of an AutoMarshal struct, are serialized
+// along with a compact tag that identifies their concrete type, which allows
+// the receiver to rebuild a value of the right type.
+//
+// RegisterImpl panics if neither T nor *T implements I, or if the tag of T
+// collides with the tag of another registered type.
+func RegisterImpl[I any, T any, PT interface {
+	*T
+	AutoMarshal
+}]() {
+	iface := reflect.TypeOf((*I)(nil)).Elem()
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if iface.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("RegisterImpl: %v is not an interface", iface))
+	}
+	if !t.Implements(iface) && !reflect.PointerTo(t).Implements(iface) {
+		panic(fmt.Sprintf("RegisterImpl: neither %v nor *%v implements %v", t, t, iface))
+	}
+
+	tag := typeTag(t)
+	implsMu.Lock()
+	defer implsMu.Unlock()
+	if existing, ok := impls[tag]; ok && existing != t {
+		panic(fmt.Sprintf("RegisterImpl: types %v and %v have the same tag", existing, t))
+	}
+	impls[tag] = t
+	implTags[t] = tag
+}
+
+// implTag returns the tag of the provided registered type, or false if the
+// type hasn't been registered with RegisterImpl.
+func implTag(t reflect.Type) (uint64, bool) {
+	implsMu.Lock()
+	defer implsMu.Unlock()
+	tag, ok := implTags[t]
+	return tag, ok
+}
+
+// implType returns the registered type with the provided tag, or nil if no
+// such type has been registered.
+func implType(tag uint64) reflect.Type {
+	implsMu.Lock()
+	defer implsMu.Unlock()
+	return impls[tag]
+}
+
+// typeTag returns the tag of the provided type, a hash of its fully qualified
+// name. Tags are never zero, since zero encodes a nil interface value.
+func typeTag(t reflect.Type) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(typeName(t))) //nolint:errcheck // never returns an error
+	if tag := h.Sum64(); tag != 0 {
+		return tag
+	}
+	return 1
+}
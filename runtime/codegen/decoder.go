@@ -42,12 +42,32 @@ func (d decoderError) Unwrap() error {
 
 // Decoder deserializes data from a byte slice data in the expected results.
 type Decoder struct {
-	data []byte
+	data  []byte
+	depth int // Nesting depth of values of recursive types.
 }
 
 // NewDecoder instantiates a new Decoder for a given byte slice.
 func NewDecoder(data []byte) *Decoder {
-	return &Decoder{data}
+	return &Decoder{data: data}
+}
+
+// Nest records that a value of a recursive type is about to be decoded. It
+// panics if too many such values are nested inside each other. Every call to
+// Nest must be followed by a call to Unnest once the value is decoded.
+//
+// NOTE that this method should be called only in the generated code.
+func (d *Decoder) Nest() {
+	d.depth++
+	if d.depth > maxNestingDepth {
+		panic(makeDecodeError("values nested more than %d levels deep", maxNestingDepth))
+	}
+}
+
+// Unnest records that a value of a recursive type has been decoded.
+//
+// NOTE that this method should be called only in the generated code.
+func (d *Decoder) Unnest() {
+	d.depth--
 }
 
 // Empty returns true iff all bytes in d have been consumed.
@@ -223,18 +243,118 @@ func (d *Decoder) Error() error {
 	for i := 0; i < n; i++ {
 		msg := d.String()
 		f := d.String()
-		err = append(err, decodedErrorEntry{msg, f})
+		err = append(err, decodedErrorEntry{msg: msg, fmt: f})
+	}
+	if !d.Empty() {
+		// The error was encoded by a version of Encoder.Error that serializes
+		// errors with registered types. See Encoder.Error.
+		for i := range err {
+			err[i].value = d.serializableError()
+		}
 	}
 	// Note that we intentionally return nil when n==0 so that the deserialization
 	// of a serialized nil error remains nil
 	return err
 }
 
+// Field decodes the next field of a struct encoded with field tags. It
+// returns the number of the field and a decoder for its value, or zero and
+// nil if there are no more fields. Fields with unknown numbers can be skipped
+// by ignoring the returned decoder.
+//
+// NOTE that this method should be called only in the generated code.
+func (d *Decoder) Field() (uint32, *Decoder) {
+	n := d.Uint32()
+	if n == 0 {
+		return 0, nil
+	}
+	size := d.Uint32()
+	if uint64(size) > uint64(len(d.data)) {
+		panic(makeDecodeError("unable to decode field %d; length %d exceeds remaining %d bytes", n, size, len(d.data)))
+	}
+	return n, &Decoder{data: d.Read(int(size)), depth: d.depth}
+}
+
+// Interface decodes an interface value encoded by Encoder.Interface into v,
+// which must be a non-nil pointer to a variable of interface type.
+func (d *Decoder) Interface(v any) {
+	dst := reflect.ValueOf(v).Elem()
+	tag := d.Uint64()
+	if tag == 0 {
+		dst.Set(reflect.Zero(dst.Type()))
+		return
+	}
+	t := implType(tag)
+	if t == nil {
+		panic(makeDecodeError("unknown type tag %x; use weaver.RegisterImpl to register the type", tag))
+	}
+	var value reflect.Value
+	d.Nest()
+	defer d.Unnest()
+	switch kind := d.Uint8(); kind {
+	case implValue:
+		ptr := reflect.New(t)
+		ptr.Interface().(AutoMarshal).WeaverUnmarshal(d)
+		value = ptr.Elem()
+	case implPointer:
+		value = reflect.New(t)
+		value.Interface().(AutoMarshal).WeaverUnmarshal(d)
+	case implNilPointer:
+		value = reflect.Zero(reflect.PointerTo(t))
+	default:
+		panic(makeDecodeError("invalid interface value kind %d", kind))
+	}
+	if !value.Type().AssignableTo(dst.Type()) {
+		panic(makeDecodeError("%v does not implement %v", value.Type(), dst.Type()))
+	}
+	dst.Set(value)
+}
+
+// serializableError decodes an error encoded by Encoder.serializableError. It
+// returns nil if the error can't be decoded into a value of its concrete type,
+// in which case the caller falls back to the flattened error (i.e., its
+// message and formatted value).
+func (d *Decoder) serializableError() (result error) {
+	name := d.String()
+	if name == "" {
+		return nil
+	}
+	isPtr := d.Bool()
+	data := d.Bytes()
+	t := serializableType(name)
+	if t == nil {
+		// The type isn't registered in this process, which may be running a
+		// different version of the application than the encoder.
+		return nil
+	}
+
+	// The encoded value may have been produced by a different version of the
+	// type. Since the value is length prefixed, failing to decode it doesn't
+	// affect the rest of d.
+	defer func() {
+		if err := CatchPanics(recover()); err != nil {
+			result = nil
+		}
+	}()
+	ptr := reflect.New(t)
+	ptr.Interface().(AutoMarshal).WeaverUnmarshal(NewDecoder(data))
+	var value any = ptr.Interface()
+	if !isPtr {
+		value = ptr.Elem().Interface()
+	}
+	err, ok := value.(error)
+	if !ok {
+		return nil
+	}
+	return err
+}
+
 type decodedErrorStack []decodedErrorEntry
 
 type decodedErrorEntry struct {
-	msg string // Error() result
-	fmt string // Result of fmtError
+	msg   string // Error() result
+	fmt   string // Result of fmtError
+	value error  // Decoded error value, for errors with registered types
 }
 
 // Error implements error.Error.
@@ -250,9 +370,34 @@ func (e decodedErrorStack) Unwrap() error {
 
 // Is returns true if either e or an error it wraps has the same type as target.
 func (e decodedErrorStack) Is(target error) bool {
+	if x, ok := e[0].value.(interface{ Is(error) bool }); ok && x.Is(target) {
+		return true
+	}
 	return e[0].fmt == fmtError(target)
 }
 
+// As finds the first error in e's chain that matches target, and if one is
+// found, sets target to that error value and returns true. Only errors whose
+// type was registered using RegisterSerializable can match target.
+func (e decodedErrorStack) As(target any) bool {
+	value := e[0].value
+	if value == nil {
+		return false
+	}
+	if x, ok := value.(interface{ As(any) bool }); ok && x.As(target) {
+		return true
+	}
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return false
+	}
+	if reflect.TypeOf(value).AssignableTo(v.Type().Elem()) {
+		v.Elem().Set(reflect.ValueOf(value))
+		return true
+	}
+	return false
+}
+
 // fmtError serializes an error value including its type info using fmt.Sprintf.
 func fmtError(v error) string {
 	// Include package and type info explicitly since %#v uses a shortened path.
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"reflect"
 
 	"google.golang.org/protobuf/proto"
 )
@@ -44,8 +45,16 @@ func (e encoderError) Unwrap() error {
 type Encoder struct {
 	data  []byte    // Contains the serialized arguments.
 	space [100]byte // Prellocated buffer to avoid allocations for small size arguments.
+	depth int       // Nesting depth of values of recursive types.
 }
 
+// maxNestingDepth is the maximum nesting depth of values of recursive types
+// (e.g., the nodes of a tree) that can be encoded or decoded. It guards
+// against cyclic values, which would otherwise be encoded forever, and
+// against maliciously deep encodings, which would otherwise exhaust the stack
+// of the decoding goroutine.
+const maxNestingDepth = 10000
+
 func NewEncoder() *Encoder {
 	var enc Encoder
 	enc.data = enc.space[:0] // Arrange to use builtin buffer
@@ -63,6 +72,27 @@ func (e *Encoder) Reset(n int) {
 	} else {
 		e.data = make([]byte, 0, n)
 	}
+	e.depth = 0
+}
+
+// Nest records that a value of a recursive type is about to be encoded. It
+// panics if too many such values are nested inside each other, which is the
+// case if the value being encoded is cyclic. Every call to Nest must be
+// followed by a call to Unnest once the value is encoded.
+//
+// NOTE that this method should be called only in the generated code.
+func (e *Encoder) Nest() {
+	e.depth++
+	if e.depth > maxNestingDepth {
+		panic(makeEncodeError("values nested more than %d levels deep; is the value cyclic?", maxNestingDepth))
+	}
+}
+
+// Unnest records that a value of a recursive type has been encoded.
+//
+// NOTE that this method should be called only in the generated code.
+func (e *Encoder) Unnest() {
+	e.depth--
 }
 
 // makeEncodeError creates and returns an encoder error.
@@ -251,7 +281,15 @@ func (e *Encoder) Len(l int) {
 }
 
 // Error encodes an arg of type error. We save enough type information
-// to allow errors.Unwrap() and errors.Is() to work correctly.
+// to allow errors.Unwrap() and errors.Is() to work correctly. Errors whose
+// type was registered using RegisterSerializable are also serialized along
+// with their concrete type, which allows errors.As() to work correctly.
+//
+// The serialized errors are encoded after the stack of wrapped errors, which
+// is encoded the same way older versions of Error encode it. Because an error
+// is always the last value encoded in a reply, decoders that predate
+// serializable errors ignore them, and Decoder.Error can tell whether they
+// are present.
 func (e *Encoder) Error(err error) {
 	// Get the stack of wrapped errors.
 	stack := make([]error, 0, 4)
@@ -264,9 +302,120 @@ func (e *Encoder) Error(err error) {
 	for _, err := range stack {
 		e.String(err.Error())
 		e.String(fmtError(err))
+	}
+	for _, err := range stack {
+		e.serializableError(err)
+	}
+}
+
+// BeginField begins the encoding of the field with the provided number of a
+// struct encoded with field tags. It returns an offset that must be passed to
+// EndField once the field has been encoded. A field is encoded as its number,
+// followed by the length of its encoding, followed by its encoding. This lets
+// a decoder skip fields it doesn't know about.
+//
+// NOTE that this method should be called only in the generated code.
+func (e *Encoder) BeginField(n uint32) int {
+	if n == 0 {
+		panic(makeEncodeError("invalid field number 0"))
+	}
+	e.Uint32(n)
+	offset := len(e.data)
+	e.Uint32(0) // Placeholder for the length of the field, set by EndField.
+	return offset
+}
+
+// EndField ends the encoding of a field begun by BeginField.
+//
+// NOTE that this method should be called only in the generated code.
+func (e *Encoder) EndField(offset int) {
+	n := len(e.data) - offset - 4
+	if n > math.MaxUint32 {
+		panic(makeEncodeError("unable to encode field; length doesn't fit in 4 bytes"))
+	}
+	binary.LittleEndian.PutUint32(e.data[offset:], uint32(n))
+}
+
+// EndFields ends the encoding of a struct encoded with field tags.
+//
+// NOTE that this method should be called only in the generated code.
+func (e *Encoder) EndFields() {
+	e.Uint32(0)
+}
+
+// Kinds of concrete values stored in an interface value.
+const (
+	implValue      uint8 = iota // a value of a registered type T
+	implPointer                 // a non-nil *T
+	implNilPointer              // a nil *T
+)
+
+// Interface encodes an interface value v, whose concrete type, or the type
+// pointed to by its concrete type, was registered using RegisterImpl. The
+// value is encoded as a compact tag identifying its concrete type, followed
+// by the value itself. A nil interface value is encoded as a zero tag.
+func (e *Encoder) Interface(v any) {
+	if v == nil {
+		e.Uint64(0)
+		return
+	}
+	val := reflect.ValueOf(v)
+	t := val.Type()
+	kind := implValue
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		kind = implPointer
+		if val.IsNil() {
+			kind = implNilPointer
+		}
+	}
+	tag, ok := implTag(t)
+	if !ok {
+		panic(makeEncodeError("type %v not registered; use weaver.RegisterImpl to register it", t))
+	}
+	e.Uint64(tag)
+	e.Uint8(kind)
+
+	// The concrete value may itself hold interface values, possibly of the
+	// same type, so count it towards the nesting depth.
+	e.Nest()
+	defer e.Unnest()
+	switch kind {
+	case implValue:
+		// The registered type implements AutoMarshal using pointer receivers.
+		ptr := reflect.New(t)
+		ptr.Elem().Set(val)
+		ptr.Interface().(AutoMarshal).WeaverMarshal(e)
+	case implPointer:
+		v.(AutoMarshal).WeaverMarshal(e)
+	}
+}
+
+// serializableError encodes the type name and value of an error whose type
+// was registered using RegisterSerializable. If the type of the error wasn't
+// registered, an empty type name is encoded.
+func (e *Encoder) serializableError(err error) {
+	v := reflect.ValueOf(err)
+	t := v.Type()
+	isPtr := t.Kind() == reflect.Pointer
+	if isPtr {
+		t = t.Elem()
+	}
+	name := typeName(t)
+	if serializableType(name) != t || (isPtr && v.IsNil()) {
+		e.String("")
+		return
+	}
 
-		// TODO(sanjay): If a wrapped errors can be serialized using Gob, consider
-		// saving that serialization. This may allow us to implement the As() method
-		// that reconstructs an error value with the original type at the receiver.
+	// The registered type implements AutoMarshal using pointer receivers.
+	ptr := v
+	if !isPtr {
+		ptr = reflect.New(t)
+		ptr.Elem().Set(v)
 	}
+	enc := NewEncoder()
+	ptr.Interface().(AutoMarshal).WeaverMarshal(enc)
+	e.String(name)
+	e.Bool(isPtr)
+	e.Bytes(enc.Data())
 }
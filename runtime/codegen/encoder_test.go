@@ -139,7 +139,7 @@ func TestErrorDecUnableToRead(t *testing.T) {
 		enc := newEncoder()
 		enc.Int(12345)
 
-		dec := Decoder{enc.data}
+		dec := Decoder{data: enc.data}
 		dec.Int()
 		dec.Bool()
 	})
@@ -156,7 +156,7 @@ func TestErrorUnableToDecBool(t *testing.T) {
 		enc := newEncoder()
 		enc.Int(123)
 
-		dec := Decoder{enc.data}
+		dec := Decoder{data: enc.data}
 		dec.Bool()
 	})
 	if !strings.Contains(err.Error(), "unable to decode bool") {
@@ -172,7 +172,7 @@ func TestErrorUnableToDecBytes(t *testing.T) {
 		enc := newEncoder()
 		enc.Int(-10)
 
-		dec := Decoder{enc.data}
+		dec := Decoder{data: enc.data}
 		dec.Bytes()
 	})
 	if !strings.Contains(err.Error(), "unable to decode bytes; expected length") {
@@ -184,6 +184,509 @@ type customTestError struct{ f string }
 
 func (c customTestError) Error() string { return fmt.Sprintf("custom(%s)", c.f) }
 
+// serializableTestError is an error type registered with RegisterSerializable.
+type serializableTestError struct {
+	code int
+	msg  string
+}
+
+var _ AutoMarshal = &serializableTestError{}
+
+func (s serializableTestError) Error() string {
+	return fmt.Sprintf("serializable(%d, %s)", s.code, s.msg)
+}
+
+func (s *serializableTestError) WeaverMarshal(enc *Encoder) {
+	enc.Int(s.code)
+	enc.String(s.msg)
+}
+
+func (s *serializableTestError) WeaverUnmarshal(dec *Decoder) {
+	s.code = dec.Int()
+	s.msg = dec.String()
+}
+
+func init() {
+	RegisterSerializable[serializableTestError]()
+}
+
+func TestErrorAs(t *testing.T) {
+	want := serializableTestError{42, "hello"}
+	for _, c := range []struct {
+		name string
+		val  error
+	}{
+		{"value", want},
+		{"pointer", &want},
+		{"wrapped-value", fmt.Errorf("wrapped: %w", want)},
+		{"wrapped-pointer", fmt.Errorf("wrapped: %w", &want)},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			enc := NewEncoder()
+			enc.Error(c.val)
+			dec := NewDecoder(enc.Data())
+			got := dec.Error()
+			if !dec.Empty() {
+				t.Fatalf("leftover bytes in decoder")
+			}
+
+			var value serializableTestError
+			var ptr *serializableTestError
+			srcValue, srcPtr := errors.As(c.val, &value), errors.As(c.val, &ptr)
+			if a, b := errors.As(got, &value), errors.As(got, &ptr); a != srcValue || b != srcPtr {
+				t.Fatalf("errors.As(%v) = %v, %v; want %v, %v", got, a, b, srcValue, srcPtr)
+			}
+			if srcValue && value != want {
+				t.Errorf("errors.As(%v): got %v, want %v", got, value, want)
+			}
+			if srcPtr && *ptr != want {
+				t.Errorf("errors.As(%v): got %v, want %v", got, *ptr, want)
+			}
+
+			var custom customTestError
+			if errors.As(got, &custom) {
+				t.Errorf("errors.As(%v, customTestError): unexpected match", got)
+			}
+		})
+	}
+}
+
+func TestErrorCompatibility(t *testing.T) {
+	// Errors encoded by older versions of Encoder.Error, which only encode
+	// the stack of wrapped errors, can be decoded, and vice versa.
+	src := fmt.Errorf("wrapped: %w", serializableTestError{42, "hello"})
+	var stack []error
+	for err := src; err != nil; err = errors.Unwrap(err) {
+		stack = append(stack, err)
+	}
+
+	t.Run("old-encoder", func(t *testing.T) {
+		enc := NewEncoder()
+		enc.Int(len(stack))
+		for _, err := range stack {
+			enc.String(err.Error())
+			enc.String(fmtError(err))
+		}
+		dec := NewDecoder(enc.Data())
+		got := dec.Error()
+		if !dec.Empty() {
+			t.Fatalf("leftover bytes in decoder")
+		}
+		if got.Error() != src.Error() {
+			t.Fatalf("Error(): got %q, want %q", got.Error(), src.Error())
+		}
+		if !errors.Is(got, serializableTestError{42, "hello"}) {
+			t.Fatalf("errors.Is(%v, serializableTestError): got false, want true", got)
+		}
+	})
+
+	t.Run("old-decoder", func(t *testing.T) {
+		enc := NewEncoder()
+		enc.Error(src)
+		dec := NewDecoder(enc.Data())
+		if n := dec.Int(); n != len(stack) {
+			t.Fatalf("stack length: got %d, want %d", n, len(stack))
+		}
+		for _, err := range stack {
+			if got, want := dec.String(), err.Error(); got != want {
+				t.Errorf("message: got %q, want %q", got, want)
+			}
+			if got, want := dec.String(), fmtError(err); got != want {
+				t.Errorf("formatted error: got %q, want %q", got, want)
+			}
+		}
+	})
+}
+
+func TestErrorUnregistered(t *testing.T) {
+	// An error whose type isn't registered in the decoding process falls back
+	// to its flattened form.
+	src := fmt.Errorf("wrapped: %w", serializableTestError{42, "hello"})
+	enc := NewEncoder()
+	enc.Error(src)
+
+	name := typeName(reflect.TypeOf(serializableTestError{}))
+	serializableMu.Lock()
+	delete(serializable, name)
+	serializableMu.Unlock()
+	defer RegisterSerializable[serializableTestError]()
+
+	dec := NewDecoder(enc.Data())
+	got := dec.Error()
+	if !dec.Empty() {
+		t.Fatalf("leftover bytes in decoder")
+	}
+	if got.Error() != src.Error() {
+		t.Fatalf("Error(): got %q, want %q", got.Error(), src.Error())
+	}
+	wrapped := errors.Unwrap(got)
+	if wrapped == nil || wrapped.Error() != "serializable(42, hello)" {
+		t.Fatalf("Unwrap(%v): got %v, want serializable(42, hello)", got, wrapped)
+	}
+	if !errors.Is(got, serializableTestError{42, "hello"}) {
+		t.Fatalf("errors.Is(%v, serializableTestError): got false, want true", got)
+	}
+	var value serializableTestError
+	if errors.As(got, &value) {
+		t.Fatalf("errors.As(%v, serializableTestError): unexpected match", got)
+	}
+}
+
+// testShape is an interface whose implementations are registered with
+// RegisterImpl.
+type testShape interface {
+	Area() int
+}
+
+// testSquare implements testShape by value.
+type testSquare struct{ side int }
+
+func (s testSquare) Area() int                     { return s.side * s.side }
+func (s *testSquare) WeaverMarshal(enc *Encoder)   { enc.Int(s.side) }
+func (s *testSquare) WeaverUnmarshal(dec *Decoder) { s.side = dec.Int() }
+
+// testRect implements testShape by pointer.
+type testRect struct{ w, h int }
+
+func (r *testRect) Area() int { return r.w * r.h }
+func (r *testRect) WeaverMarshal(enc *Encoder) {
+	enc.Int(r.w)
+	enc.Int(r.h)
+}
+func (r *testRect) WeaverUnmarshal(dec *Decoder) {
+	r.w = dec.Int()
+	r.h = dec.Int()
+}
+
+// testGroup implements testShape by holding other shapes, possibly other
+// groups.
+type testGroup struct{ shapes []testShape }
+
+func (g testGroup) Area() int {
+	var area int
+	for _, s := range g.shapes {
+		area += s.Area()
+	}
+	return area
+}
+func (g *testGroup) WeaverMarshal(enc *Encoder) {
+	enc.Len(len(g.shapes))
+	for _, s := range g.shapes {
+		enc.Interface(s)
+	}
+}
+func (g *testGroup) WeaverUnmarshal(dec *Decoder) {
+	g.shapes = nil
+	for n := dec.Len(); n > 0; n-- {
+		var s testShape
+		dec.Interface(&s)
+		g.shapes = append(g.shapes, s)
+	}
+}
+
+// testCircle implements testShape, but isn't registered.
+type testCircle struct{ r int }
+
+func (c testCircle) Area() int                     { return 3 * c.r * c.r }
+func (c *testCircle) WeaverMarshal(enc *Encoder)   { enc.Int(c.r) }
+func (c *testCircle) WeaverUnmarshal(dec *Decoder) { c.r = dec.Int() }
+
+func init() {
+	RegisterImpl[testShape, testSquare]()
+	RegisterImpl[testShape, testRect]()
+	RegisterImpl[testShape, testGroup]()
+}
+
+func TestInterface(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		val  testShape
+	}{
+		{"nil", nil},
+		{"value", testSquare{3}},
+		{"pointer-to-value", &testSquare{4}},
+		{"pointer", &testRect{2, 5}},
+		{"nil-pointer", (*testRect)(nil)},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			enc := NewEncoder()
+			enc.Interface(c.val)
+			enc.Int(42) // Check that the value is fully decoded.
+			dec := NewDecoder(enc.Data())
+			var got testShape
+			dec.Interface(&got)
+			if n := dec.Int(); n != 42 || !dec.Empty() {
+				t.Fatalf("bad trailing data %d", n)
+			}
+			if diff := cmp.Diff(c.val, got, cmp.AllowUnexported(testSquare{}, testRect{})); diff != "" {
+				t.Fatalf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInterfaceErrors(t *testing.T) {
+	// Values of unregistered types can't be encoded.
+	err := func() (err error) {
+		defer func() { err = CatchPanics(recover()) }()
+		NewEncoder().Interface(testCircle{1})
+		return nil
+	}()
+	if err == nil || !strings.Contains(err.Error(), "not registered") {
+		t.Fatalf("encoding an unregistered type: got %v, want not registered error", err)
+	}
+
+	// Values can't be decoded into interfaces they don't implement.
+	enc := NewEncoder()
+	enc.Interface(testSquare{1})
+	err = func() (err error) {
+		defer func() { err = CatchPanics(recover()) }()
+		var s fmt.Stringer
+		NewDecoder(enc.Data()).Interface(&s)
+		return nil
+	}()
+	if err == nil || !strings.Contains(err.Error(), "does not implement") {
+		t.Fatalf("decoding into the wrong interface: got %v, want does not implement error", err)
+	}
+}
+
+// testList is a recursive type, with WeaverMarshal and WeaverUnmarshal
+// methods like the ones generated by "weaver generate".
+type testList struct {
+	value int
+	next  *testList
+}
+
+func (l *testList) WeaverMarshal(enc *Encoder) {
+	enc.Nest()
+	defer enc.Unnest()
+	enc.Int(l.value)
+	enc.Bool(l.next != nil)
+	if l.next != nil {
+		l.next.WeaverMarshal(enc)
+	}
+}
+
+func (l *testList) WeaverUnmarshal(dec *Decoder) {
+	dec.Nest()
+	defer dec.Unnest()
+	l.value = dec.Int()
+	if dec.Bool() {
+		l.next = &testList{}
+		l.next.WeaverUnmarshal(dec)
+	}
+}
+
+// newTestList returns a list with n elements.
+func newTestList(n int) *testList {
+	var l *testList
+	for i := 0; i < n; i++ {
+		l = &testList{value: i, next: l}
+	}
+	return l
+}
+
+func TestNesting(t *testing.T) {
+	// Lists with up to maxNestingDepth elements can be encoded and decoded.
+	enc := NewEncoder()
+	in := newTestList(maxNestingDepth)
+	in.WeaverMarshal(enc)
+	var out testList
+	dec := NewDecoder(enc.Data())
+	out.WeaverUnmarshal(dec)
+	if !dec.Empty() {
+		t.Fatalf("leftover bytes in decoder")
+	}
+	if diff := cmp.Diff(in, &out, cmp.AllowUnexported(testList{})); diff != "" {
+		t.Fatalf("(-want +got):\n%s", diff)
+	}
+
+	// Cyclic lists can't be encoded.
+	cyclic := &testList{}
+	cyclic.next = cyclic
+	err := func() (err error) {
+		defer func() { err = CatchPanics(recover()) }()
+		cyclic.WeaverMarshal(NewEncoder())
+		return nil
+	}()
+	if err == nil || !strings.Contains(err.Error(), "cyclic") {
+		t.Fatalf("encoding a cyclic list: got %v, want nesting error", err)
+	}
+
+	// Lists nested too deep can't be decoded.
+	enc = NewEncoder()
+	for i := 0; i <= maxNestingDepth; i++ {
+		enc.Int(i)
+		enc.Bool(i < maxNestingDepth)
+	}
+	err = func() (err error) {
+		defer func() { err = CatchPanics(recover()) }()
+		var l testList
+		l.WeaverUnmarshal(NewDecoder(enc.Data()))
+		return nil
+	}()
+	if err == nil || !strings.Contains(err.Error(), "levels deep") {
+		t.Fatalf("decoding a deep list: got %v, want nesting error", err)
+	}
+}
+
+func TestInterfaceNesting(t *testing.T) {
+	// newGroup returns n nested groups around a square.
+	newGroup := func(n int) *testGroup {
+		g := &testGroup{shapes: []testShape{testSquare{1}}}
+		for i := 1; i < n; i++ {
+			g = &testGroup{shapes: []testShape{g}}
+		}
+		return g
+	}
+
+	// Shapes nested up to maxNestingDepth levels deep can be encoded and
+	// decoded.
+	enc := NewEncoder()
+	in := newGroup(maxNestingDepth - 1)
+	enc.Interface(in)
+	var out testShape
+	dec := NewDecoder(enc.Data())
+	dec.Interface(&out)
+	if !dec.Empty() {
+		t.Fatalf("leftover bytes in decoder")
+	}
+	if got, want := out.Area(), in.Area(); got != want {
+		t.Fatalf("area: got %d, want %d", got, want)
+	}
+
+	// Cyclic groups can't be encoded.
+	cyclic := &testGroup{}
+	cyclic.shapes = []testShape{cyclic}
+	err := func() (err error) {
+		defer func() { err = CatchPanics(recover()) }()
+		NewEncoder().Interface(cyclic)
+		return nil
+	}()
+	if err == nil || !strings.Contains(err.Error(), "cyclic") {
+		t.Fatalf("encoding a cyclic group: got %v, want nesting error", err)
+	}
+
+	// Groups nested too deep can't be decoded.
+	enc = NewEncoder()
+	tag, _ := implTag(reflect.TypeOf(testGroup{}))
+	for i := 0; i <= maxNestingDepth; i++ {
+		enc.Uint64(tag)
+		enc.Uint8(implPointer)
+		enc.Len(1)
+	}
+	enc.Uint64(0)
+	err = func() (err error) {
+		defer func() { err = CatchPanics(recover()) }()
+		var s testShape
+		NewDecoder(enc.Data()).Interface(&s)
+		return nil
+	}()
+	if err == nil || !strings.Contains(err.Error(), "levels deep") {
+		t.Fatalf("decoding a deep group: got %v, want nesting error", err)
+	}
+}
+
+// testPairV1 and testPairV2 are two versions of a struct encoded with field
+// tags, with WeaverMarshal and WeaverUnmarshal methods like the ones
+// generated by "weaver generate". testPairV2 removed field 2 and added
+// field 3.
+type testPairV1 struct {
+	x int    // field 1
+	y string // field 2
+}
+
+type testPairV2 struct {
+	x int     // field 1
+	z []int32 // field 3
+}
+
+func (p *testPairV1) WeaverMarshal(enc *Encoder) {
+	f1 := enc.BeginField(1)
+	enc.Int(p.x)
+	enc.EndField(f1)
+	f2 := enc.BeginField(2)
+	enc.String(p.y)
+	enc.EndField(f2)
+	enc.EndFields()
+}
+
+func (p *testPairV1) WeaverUnmarshal(dec *Decoder) {
+	*p = testPairV1{}
+	for {
+		n, fdec := dec.Field()
+		switch n {
+		case 0:
+			return
+		case 1:
+			p.x = fdec.Int()
+		case 2:
+			p.y = fdec.String()
+		}
+	}
+}
+
+func (p *testPairV2) WeaverMarshal(enc *Encoder) {
+	f1 := enc.BeginField(1)
+	enc.Int(p.x)
+	enc.EndField(f1)
+	f3 := enc.BeginField(3)
+	enc.Len(len(p.z))
+	for _, x := range p.z {
+		enc.Int32(x)
+	}
+	enc.EndField(f3)
+	enc.EndFields()
+}
+
+func (p *testPairV2) WeaverUnmarshal(dec *Decoder) {
+	*p = testPairV2{}
+	for {
+		n, fdec := dec.Field()
+		switch n {
+		case 0:
+			return
+		case 1:
+			p.x = fdec.Int()
+		case 3:
+			p.z = make([]int32, fdec.Len())
+			for i := range p.z {
+				p.z[i] = fdec.Int32()
+			}
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	// Unknown fields are skipped, and missing fields have their zero values.
+	for _, c := range []struct {
+		name string
+		in   AutoMarshal
+		out  AutoMarshal
+		want AutoMarshal
+	}{
+		{"v1-v1", &testPairV1{1, "a"}, &testPairV1{}, &testPairV1{1, "a"}},
+		{"v2-v2", &testPairV2{1, []int32{2, 3}}, &testPairV2{}, &testPairV2{1, []int32{2, 3}}},
+		{"v1-v2", &testPairV1{1, "a"}, &testPairV2{9, []int32{9}}, &testPairV2{x: 1}},
+		{"v2-v1", &testPairV2{1, []int32{2, 3}}, &testPairV1{9, "z"}, &testPairV1{x: 1}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			enc := NewEncoder()
+			c.in.WeaverMarshal(enc)
+			enc.Int(42) // Check that the value is fully decoded.
+			dec := NewDecoder(enc.Data())
+			c.out.WeaverUnmarshal(dec)
+			if n := dec.Int(); n != 42 || !dec.Empty() {
+				t.Fatalf("bad trailing data %d", n)
+			}
+			opts := cmp.AllowUnexported(testPairV1{}, testPairV2{})
+			if diff := cmp.Diff(c.want, c.out, opts); diff != "" {
+				t.Fatalf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestErrorValues(t *testing.T) {
 	type testCase struct {
 		name string
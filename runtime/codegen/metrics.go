@@ -34,16 +34,40 @@ var (
 		"Duration, in microseconds, of Service Weaver component method execution",
 		metrics.NonNegativeBuckets,
 	)
-	MethodBytesRequest = metrics.NewHistogramMap[MethodLabels](
+	MethodBytesRequest = metrics.NewHistogramMap[MethodBytesLabels](
 		"serviceweaver_remote_method_bytes_request",
 		"Number of bytes in Service Weaver component method requests",
 		metrics.NonNegativeBuckets,
 	)
-	MethodBytesReply = metrics.NewHistogramMap[MethodLabels](
+	MethodBytesReply = metrics.NewHistogramMap[MethodBytesLabels](
 		"serviceweaver_remote_method_bytes_reply",
 		"Number of bytes in Service Weaver component method replies",
 		metrics.NonNegativeBuckets,
 	)
+	MethodHedges = metrics.NewCounterMap[MethodLabels](
+		"serviceweaver_remote_method_hedge_count",
+		"Count of Service Weaver component method invocations for which a hedged copy was sent to a second replica",
+	)
+	MethodHedgeWins = metrics.NewCounterMap[MethodLabels](
+		"serviceweaver_remote_method_hedge_win_count",
+		"Count of hedged Service Weaver component method invocations whose result came from the hedged copy",
+	)
+	MethodDenials = metrics.NewCounterMap[MethodLabels](
+		"serviceweaver_remote_method_denied_count",
+		"Count of Service Weaver component method invocations rejected by the authorization policy",
+	)
+	BalancerOutstandingCalls = metrics.NewGaugeMap[BalancerLabels](
+		"serviceweaver_balancer_outstanding_calls",
+		"Number of outstanding Service Weaver component method invocations sent to an endpoint by a load balancer",
+	)
+	BalancerLatencies = metrics.NewGaugeMap[BalancerLabels](
+		"serviceweaver_balancer_latency_micros",
+		"Moving average duration, in microseconds, of Service Weaver component method invocations sent to an endpoint by a load balancer",
+	)
+	OutlierEjections = metrics.NewCounterMap[EjectionLabels](
+		"serviceweaver_outlier_ejection_count",
+		"Count of Service Weaver component endpoints ejected by outlier detection",
+	)
 )
 
 type MethodLabels struct {
@@ -52,6 +76,33 @@ type MethodLabels struct {
 	Method    string // callee component method's name
 }
 
+// MethodBytesLabels are the labels of MethodBytesRequest and MethodBytesReply.
+// Every request and reply is measured before it is compressed. Requests and
+// replies that are compressed are also measured after they are compressed,
+// with Compressed set to true.
+type MethodBytesLabels struct {
+	Caller     string // full calling component name
+	Component  string // full callee component name
+	Method     string // callee component method's name
+	Compressed bool   // is this the size of a compressed payload?
+}
+
+// BalancerLabels are the labels of BalancerOutstandingCalls and
+// BalancerLatencies, which hold a load balancer's view of the load of an
+// endpoint.
+type BalancerLabels struct {
+	Component string // full callee component name
+	Balancer  string // load balancer name (e.g., "least_loaded")
+	Endpoint  string // endpoint address
+}
+
+// EjectionLabels are the labels of OutlierEjections.
+type EjectionLabels struct {
+	Component string // full callee component name
+	Endpoint  string // ejected endpoint address
+	Reason    string // why the endpoint was ejected (e.g., "latency")
+}
+
 // MethodMetrics contains metrics for a single Service Weaver component method.
 type MethodMetrics struct {
 	Count        *metrics.Counter   // See MethodCounts.
@@ -67,7 +118,48 @@ func MethodMetricsFor(labels MethodLabels) *MethodMetrics {
 		Count:        MethodCounts.Get(labels),
 		ErrorCount:   MethodErrors.Get(labels),
 		Latency:      MethodLatencies.Get(labels),
-		BytesRequest: MethodBytesRequest.Get(labels),
-		BytesReply:   MethodBytesReply.Get(labels),
+		BytesRequest: MethodBytesRequest.Get(bytesLabels(labels, false)),
+		BytesReply:   MethodBytesReply.Get(bytesLabels(labels, false)),
+	}
+}
+
+// CompressedMethodMetrics contains the metrics that measure the compressed
+// requests and replies of a single Service Weaver component method.
+type CompressedMethodMetrics struct {
+	BytesRequest *metrics.Histogram // See MethodBytesRequest.
+	BytesReply   *metrics.Histogram // See MethodBytesReply.
+}
+
+// CompressedMethodMetricsFor returns compressed metrics for the specified
+// method.
+func CompressedMethodMetricsFor(labels MethodLabels) *CompressedMethodMetrics {
+	return &CompressedMethodMetrics{
+		BytesRequest: MethodBytesRequest.Get(bytesLabels(labels, true)),
+		BytesReply:   MethodBytesReply.Get(bytesLabels(labels, true)),
+	}
+}
+
+// HedgeMethodMetrics contains the metrics that measure the hedged calls to a
+// single Service Weaver component method.
+type HedgeMethodMetrics struct {
+	Hedges *metrics.Counter // See MethodHedges.
+	Wins   *metrics.Counter // See MethodHedgeWins.
+}
+
+// HedgeMethodMetricsFor returns hedge metrics for the specified method.
+func HedgeMethodMetricsFor(labels MethodLabels) *HedgeMethodMetrics {
+	return &HedgeMethodMetrics{
+		Hedges: MethodHedges.Get(labels),
+		Wins:   MethodHedgeWins.Get(labels),
+	}
+}
+
+// bytesLabels returns the MethodBytesLabels for the provided method.
+func bytesLabels(labels MethodLabels, compressed bool) MethodBytesLabels {
+	return MethodBytesLabels{
+		Caller:     labels.Caller,
+		Component:  labels.Component,
+		Method:     labels.Method,
+		Compressed: compressed,
 	}
 }
@@ -0,0 +1,399 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime"
+	"golang.org/x/exp/slices"
+)
+
+// MethodOptions configures how remote calls to a component method are made.
+// The options of a method are declared with //weaver: directives in the
+// method's doc comment (e.g., //weaver:timeout 1s), and can be overridden in
+// the [calls] section of a config file.
+type MethodOptions struct {
+	// Timeout, if positive, bounds the duration of every attempt of a call.
+	// A retry gets a fresh Timeout, so the total duration of a call with
+	// retries may exceed Timeout; the caller's context bounds the total.
+	Timeout time.Duration
+
+	// Retries is the number of times a failed call is retried. A call that
+	// failed before it was sent to the component, or that the component shed
+	// because it was overloaded, is always safe to retry, and is retried at
+	// least once, even if Retries is zero. A call that failed with a
+	// communication error is only retried if the method is idempotent.
+	Retries int
+
+	// Backoff, if positive, is the minimum delay between retries. The delay
+	// grows exponentially with every retry.
+	Backoff time.Duration
+
+	// Idempotent is true if a call to the method can safely be executed more
+	// than once.
+	Idempotent bool
+
+	// Hedge, if positive, hedges calls to the method: if a call hasn't
+	// finished after Hedge, a second copy of the call is sent to a different
+	// replica of the component. The result of the first copy to finish is
+	// used, and the other copy is canceled. Only idempotent methods can be
+	// hedged.
+	Hedge time.Duration
+
+	// HedgeP95, if true, hedges calls to the method like Hedge, but with a
+	// delay equal to the observed 95th percentile latency of the method.
+	HedgeP95 bool
+}
+
+// hedgeP95 is the value of a hedge option that hedges calls after the
+// observed 95th percentile latency of a method (e.g., //weaver:hedge p95).
+const hedgeP95 = "p95"
+
+// parseHedge parses the value of a hedge option, which is either a positive
+// duration (e.g., "50ms") or "p95", and sets the corresponding fields of opts.
+func parseHedge(value string, opts *MethodOptions) error {
+	if value == hedgeP95 {
+		opts.Hedge, opts.HedgeP95 = 0, true
+		return nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("invalid hedge %q: must be a duration (e.g., 50ms) or %q", value, hedgeP95)
+	}
+	if d <= 0 {
+		return fmt.Errorf("invalid hedge %q: duration must be positive", value)
+	}
+	opts.Hedge, opts.HedgeP95 = d, false
+	return nil
+}
+
+// hedged returns true if calls to the method are hedged.
+func (o MethodOptions) hedged() bool {
+	return o.Hedge > 0 || o.HedgeP95
+}
+
+const (
+	// Keys of the config section that overrides method options.
+	callsKey      = "github.com/ServiceWeaver/weaver/calls"
+	shortCallsKey = "calls"
+)
+
+// CompressionOptions configures the compression of the payloads of remote
+// calls to a component.
+type CompressionOptions struct {
+	// Algorithm is the name of the compression algorithm (e.g., "deflate"),
+	// or "" or "none" if payloads are not compressed.
+	Algorithm string
+
+	// Threshold, if positive, is the minimum size, in bytes, of a compressed
+	// payload.
+	Threshold int
+}
+
+// compressionAlgorithms are the names of the supported compression algorithms.
+// See call.ParseCompression.
+var compressionAlgorithms = []string{"", "none", "deflate"}
+
+// OutlierOptions configures the detection and ejection of the outlier
+// replicas of a component. See call.OutlierOptions for details.
+type OutlierOptions struct {
+	ConsecutiveErrors   int
+	LatencyFactor       float64
+	EjectionTime        time.Duration
+	MaxEjectionTime     time.Duration
+	MaxEjectionFraction float64
+	CircuitBreaker      bool
+}
+
+// LimitOptions configures a limit on the number of concurrent calls to one or
+// more methods of a component. See call.LimiterOptions for details.
+type LimitOptions struct {
+	MaxConcurrent int
+	MaxQueued     int
+	Adaptive      bool
+}
+
+// balancers are the names of the supported load balancers. See
+// call.NewBalancer.
+var balancers = []string{"", "round_robin", "least_loaded", "power_of_two_choices", "least_latency"}
+
+// callsConfig holds the method options overrides found in the [calls]
+// section of a config file, keyed by component name. The overrides of a
+// component apply to all of its methods, and can in turn be overridden for
+// individual methods. For example:
+//
+//	[calls."github.com/example/app/Cache"]
+//	timeout = "1s"
+//	compression = "deflate"
+//	balancer = "least_loaded"
+//	connections_per_replica = 4
+//
+//	[calls."github.com/example/app/Cache".methods.Get]
+//	retries = 3
+//	idempotent = true
+//	hedge = "p95"
+//
+//	[calls."github.com/example/app/Cache".outlier_detection]
+//	consecutive_errors = 5
+//	circuit_breaker = true
+//
+//	[calls."github.com/example/app/Cache".concurrency_limit]
+//	max_concurrent_calls = 100
+//	max_queued_calls = 50
+//
+// The compression options, the load balancer, the number of connections per
+// replica, and the outlier detection options of a component apply to all of
+// its methods. The concurrency limit of a component is shared by all of its
+// methods, while the concurrency limit of a method applies to that method
+// only.
+type callsConfig map[string]*componentCallsConfig
+
+// componentCallsConfig holds the method options overrides, the compression
+// options, the load balancer, and the connection options for a component.
+type componentCallsConfig struct {
+	methodCallsConfig
+	Compression           string
+	CompressionThreshold  int `toml:"compression_threshold"`
+	Balancer              string
+	ConnectionsPerReplica int            `toml:"connections_per_replica"`
+	OutlierDetection      *outlierConfig `toml:"outlier_detection"`
+	Methods               map[string]*methodCallsConfig
+}
+
+// outlierConfig holds the outlier detection options for a component.
+type outlierConfig struct {
+	ConsecutiveErrors   int           `toml:"consecutive_errors"`
+	LatencyFactor       float64       `toml:"latency_factor"`
+	EjectionTime        time.Duration `toml:"ejection_time"`
+	MaxEjectionTime     time.Duration `toml:"max_ejection_time"`
+	MaxEjectionFraction float64       `toml:"max_ejection_fraction"`
+	CircuitBreaker      bool          `toml:"circuit_breaker"`
+}
+
+func (c *outlierConfig) validate() error {
+	if c.ConsecutiveErrors < 0 {
+		return fmt.Errorf("negative consecutive errors %d", c.ConsecutiveErrors)
+	}
+	if c.LatencyFactor != 0 && c.LatencyFactor <= 1 {
+		return fmt.Errorf("latency factor %v is not greater than 1", c.LatencyFactor)
+	}
+	if c.ConsecutiveErrors == 0 && c.LatencyFactor == 0 {
+		return fmt.Errorf("neither consecutive errors nor latency factor set")
+	}
+	if c.EjectionTime < 0 {
+		return fmt.Errorf("negative ejection time %v", c.EjectionTime)
+	}
+	if c.MaxEjectionTime < 0 {
+		return fmt.Errorf("negative max ejection time %v", c.MaxEjectionTime)
+	}
+	if c.MaxEjectionFraction < 0 || c.MaxEjectionFraction > 1 {
+		return fmt.Errorf("max ejection fraction %v not in [0, 1]", c.MaxEjectionFraction)
+	}
+	return nil
+}
+
+// limitConfig holds a concurrency limit.
+type limitConfig struct {
+	MaxConcurrent int  `toml:"max_concurrent_calls"`
+	MaxQueued     int  `toml:"max_queued_calls"`
+	Adaptive      bool `toml:"adaptive"`
+}
+
+func (c *limitConfig) validate() error {
+	if c.MaxConcurrent <= 0 {
+		return fmt.Errorf("max concurrent calls %d is not positive", c.MaxConcurrent)
+	}
+	if c.MaxQueued < 0 {
+		return fmt.Errorf("negative max queued calls %d", c.MaxQueued)
+	}
+	return nil
+}
+
+// methodCallsConfig holds the method options overrides for a method. Only
+// non-nil fields override the options declared in code. ConcurrencyLimit is
+// not a method option; see callsConfig.
+type methodCallsConfig struct {
+	Timeout          *time.Duration
+	Retries          *int
+	Backoff          *time.Duration
+	Idempotent       *bool
+	Hedge            *string      // a duration (e.g., "50ms") or "p95"
+	ConcurrencyLimit *limitConfig `toml:"concurrency_limit"`
+}
+
+// Validate validates the overrides.
+func (c callsConfig) Validate() error {
+	for component, cc := range c {
+		if err := cc.validate(); err != nil {
+			return fmt.Errorf("component %q: %w", component, err)
+		}
+		if !slices.Contains(compressionAlgorithms, cc.Compression) {
+			return fmt.Errorf("component %q: unknown compression algorithm %q", component, cc.Compression)
+		}
+		if cc.CompressionThreshold < 0 {
+			return fmt.Errorf("component %q: negative compression threshold %d", component, cc.CompressionThreshold)
+		}
+		if !slices.Contains(balancers, cc.Balancer) {
+			return fmt.Errorf("component %q: unknown balancer %q", component, cc.Balancer)
+		}
+		if cc.ConnectionsPerReplica < 0 {
+			return fmt.Errorf("component %q: negative connections per replica %d", component, cc.ConnectionsPerReplica)
+		}
+		if cc.OutlierDetection != nil {
+			if err := cc.OutlierDetection.validate(); err != nil {
+				return fmt.Errorf("component %q outlier detection: %w", component, err)
+			}
+		}
+		for method, mc := range cc.Methods {
+			if err := mc.validate(); err != nil {
+				return fmt.Errorf("component %q method %q: %w", component, method, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *methodCallsConfig) validate() error {
+	if c.Timeout != nil && *c.Timeout < 0 {
+		return fmt.Errorf("negative timeout %v", *c.Timeout)
+	}
+	if c.Retries != nil && *c.Retries < 0 {
+		return fmt.Errorf("negative retries %d", *c.Retries)
+	}
+	if c.Backoff != nil && *c.Backoff < 0 {
+		return fmt.Errorf("negative backoff %v", *c.Backoff)
+	}
+	if c.Hedge != nil {
+		if err := parseHedge(*c.Hedge, &MethodOptions{}); err != nil {
+			return err
+		}
+	}
+	if c.ConcurrencyLimit != nil {
+		if err := c.ConcurrencyLimit.validate(); err != nil {
+			return fmt.Errorf("concurrency limit: %w", err)
+		}
+	}
+	return nil
+}
+
+// apply overrides the fields of opts with the non-nil fields of c.
+func (c *methodCallsConfig) apply(opts *MethodOptions) {
+	if c.Timeout != nil {
+		opts.Timeout = *c.Timeout
+	}
+	if c.Retries != nil {
+		opts.Retries = *c.Retries
+	}
+	if c.Backoff != nil {
+		opts.Backoff = *c.Backoff
+	}
+	if c.Idempotent != nil {
+		opts.Idempotent = *c.Idempotent
+	}
+	if c.Hedge != nil {
+		parseHedge(*c.Hedge, opts) //nolint:errcheck // checked by validate
+	}
+}
+
+// isCallsSection returns true if key is the key of the [calls] section.
+func isCallsSection(key string) bool {
+	return key == callsKey || key == shortCallsKey
+}
+
+// CallOptions configures the remote calls to a component. See callsConfig.
+type CallOptions struct {
+	// Methods holds the options of every method of the component, keyed by
+	// method name.
+	Methods map[string]MethodOptions
+
+	// Compression configures the compression of call payloads.
+	Compression CompressionOptions
+
+	// Balancer is the name of the load balancer used to balance calls to the
+	// component (e.g., "least_loaded"). The empty string names the default
+	// balancer.
+	Balancer string
+
+	// ConnectionsPerReplica is the maximum number of connections a client
+	// opens to every replica of the component. Zero means the default of one
+	// connection per replica.
+	ConnectionsPerReplica int
+
+	// Outliers configures outlier detection. Outlier detection is disabled if
+	// both ConsecutiveErrors and LatencyFactor are zero.
+	Outliers OutlierOptions
+
+	// ComponentLimit, if not nil, is the concurrency limit shared by all
+	// methods of the component.
+	ComponentLimit *LimitOptions
+
+	// MethodLimits holds the concurrency limits of individual methods, keyed
+	// by method name.
+	MethodLimits map[string]LimitOptions
+}
+
+// CallOptionsFor returns the options of remote calls to the provided
+// component. The options declared in code are overridden by the [calls]
+// section found in sections, if any.
+func CallOptionsFor(reg *Registration, sections map[string]string) (CallOptions, error) {
+	var config callsConfig
+	if err := runtime.ParseConfigSection(callsKey, shortCallsKey, sections, &config); err != nil {
+		return CallOptions{}, err
+	}
+	cc := config[reg.Name]
+	if cc == nil {
+		cc = &componentCallsConfig{}
+	}
+	for name := range cc.Methods {
+		if _, ok := reg.Iface.MethodByName(name); !ok {
+			return CallOptions{}, fmt.Errorf("section %q: component %q has no method %q", shortCallsKey, reg.Name, name)
+		}
+	}
+
+	n := reg.Iface.NumMethod()
+	options := CallOptions{
+		Methods:               make(map[string]MethodOptions, n),
+		Compression:           CompressionOptions{Algorithm: cc.Compression, Threshold: cc.CompressionThreshold},
+		Balancer:              cc.Balancer,
+		ConnectionsPerReplica: cc.ConnectionsPerReplica,
+		MethodLimits:          map[string]LimitOptions{},
+	}
+	if cc.OutlierDetection != nil {
+		options.Outliers = OutlierOptions(*cc.OutlierDetection)
+	}
+	if cc.ConcurrencyLimit != nil {
+		limit := LimitOptions(*cc.ConcurrencyLimit)
+		options.ComponentLimit = &limit
+	}
+	for i := 0; i < n; i++ {
+		name := reg.Iface.Method(i).Name
+		opts := reg.MethodOptions[name]
+		cc.methodCallsConfig.apply(&opts)
+		mc := cc.Methods[name]
+		if mc != nil {
+			mc.apply(&opts)
+		}
+		if opts.hedged() && !opts.Idempotent {
+			return CallOptions{}, fmt.Errorf("section %q: component %q method %q is hedged but not idempotent", shortCallsKey, reg.Name, name)
+		}
+		options.Methods[name] = opts
+		if mc != nil && mc.ConcurrencyLimit != nil {
+			options.MethodLimits[name] = LimitOptions(*mc.ConcurrencyLimit)
+		}
+	}
+	return options, nil
+}
@@ -0,0 +1,345 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime"
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+	"github.com/google/go-cmp/cmp"
+)
+
+type cache interface {
+	Get(context.Context, string) (string, error)
+	Put(context.Context, string, string) error
+}
+
+var cacheRegistration = &codegen.Registration{
+	Name:  "codegen_test/cache",
+	Iface: reflect.TypeOf((*cache)(nil)).Elem(),
+	MethodOptions: map[string]codegen.MethodOptions{
+		"Get": {Timeout: time.Second, Retries: 1, Idempotent: true},
+	},
+}
+
+func TestCallOptionsForMethods(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		config string
+		want   map[string]codegen.MethodOptions
+	}{
+		{
+			name: "NoConfig",
+			want: map[string]codegen.MethodOptions{
+				"Get": {Timeout: time.Second, Retries: 1, Idempotent: true},
+				"Put": {},
+			},
+		},
+		{
+			name: "Component",
+			config: `
+[calls."codegen_test/cache"]
+timeout = "2s"
+backoff = "5ms"
+`,
+			want: map[string]codegen.MethodOptions{
+				"Get": {Timeout: 2 * time.Second, Retries: 1, Backoff: 5 * time.Millisecond, Idempotent: true},
+				"Put": {Timeout: 2 * time.Second, Backoff: 5 * time.Millisecond},
+			},
+		},
+		{
+			name: "Method",
+			config: `
+[calls."codegen_test/cache"]
+retries = 2
+
+[calls."codegen_test/cache".methods.Get]
+retries = 5
+idempotent = false
+`,
+			want: map[string]codegen.MethodOptions{
+				"Get": {Timeout: time.Second, Retries: 5},
+				"Put": {Retries: 2},
+			},
+		},
+		{
+			name: "Hedge",
+			config: `
+[calls."codegen_test/cache".methods.Get]
+hedge = "p95"
+
+[calls."codegen_test/cache".methods.Put]
+idempotent = true
+hedge = "50ms"
+`,
+			want: map[string]codegen.MethodOptions{
+				"Get": {Timeout: time.Second, Retries: 1, Idempotent: true, HedgeP95: true},
+				"Put": {Idempotent: true, Hedge: 50 * time.Millisecond},
+			},
+		},
+		{
+			name: "OtherComponent",
+			config: `
+[calls."codegen_test/other"]
+retries = 2
+`,
+			want: map[string]codegen.MethodOptions{
+				"Get": {Timeout: time.Second, Retries: 1, Idempotent: true},
+				"Put": {},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			sections := parseSections(t, test.config)
+			got, err := codegen.CallOptionsFor(cacheRegistration, sections)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got.Methods); diff != "" {
+				t.Fatalf("CallOptionsFor (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCallOptionsForErrors(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		config string
+		want   string
+		syntax bool // is the error also caught by ComponentConfigValidator?
+	}{
+		{
+			name: "UnknownMethod",
+			config: `
+[calls."codegen_test/cache".methods.Delete]
+retries = 2
+`,
+			want: "no method",
+		},
+		{
+			name: "UnknownKey",
+			config: `
+[calls."codegen_test/cache"]
+retry = 2
+`,
+			want:   "unknown keys",
+			syntax: true,
+		},
+		{
+			name: "NegativeRetries",
+			config: `
+[calls."codegen_test/cache".methods.Get]
+retries = -1
+`,
+			want:   "negative retries",
+			syntax: true,
+		},
+		{
+			name: "UnknownCompression",
+			config: `
+[calls."codegen_test/cache"]
+compression = "lz77"
+`,
+			want:   "unknown compression algorithm",
+			syntax: true,
+		},
+		{
+			name: "NegativeCompressionThreshold",
+			config: `
+[calls."codegen_test/cache"]
+compression = "deflate"
+compression_threshold = -1
+`,
+			want:   "negative compression threshold",
+			syntax: true,
+		},
+		{
+			name: "UnknownBalancer",
+			config: `
+[calls."codegen_test/cache"]
+balancer = "random"
+`,
+			want:   "unknown balancer",
+			syntax: true,
+		},
+		{
+			config: `
+[calls."codegen_test/cache"]
+connections_per_replica = -1
+`,
+			want:   "negative connections per replica",
+			syntax: true,
+		},
+		{
+			name: "NoOutlierCriteria",
+			config: `
+[calls."codegen_test/cache".outlier_detection]
+circuit_breaker = true
+`,
+			want:   "neither consecutive errors nor latency factor",
+			syntax: true,
+		},
+		{
+			name: "SmallLatencyFactor",
+			config: `
+[calls."codegen_test/cache".outlier_detection]
+latency_factor = 0.5
+`,
+			want:   "latency factor",
+			syntax: true,
+		},
+		{
+			name: "MaxEjectionFraction",
+			config: `
+[calls."codegen_test/cache".outlier_detection]
+consecutive_errors = 5
+max_ejection_fraction = 2
+`,
+			want:   "max ejection fraction",
+			syntax: true,
+		},
+		{
+			name: "InvalidHedge",
+			config: `
+[calls."codegen_test/cache".methods.Get]
+hedge = "p99"
+`,
+			want:   "invalid hedge",
+			syntax: true,
+		},
+		{
+			name: "HedgeNotIdempotent",
+			config: `
+[calls."codegen_test/cache".methods.Put]
+hedge = "10ms"
+`,
+			want: "hedged but not idempotent",
+		},
+		{
+			name: "ZeroConcurrencyLimit",
+			config: `
+[calls."codegen_test/cache".methods.Get.concurrency_limit]
+max_queued_calls = 10
+`,
+			want:   "max concurrent calls 0 is not positive",
+			syntax: true,
+		},
+		{
+			name: "MethodCompression",
+			config: `
+[calls."codegen_test/cache".methods.Get]
+compression = "deflate"
+`,
+			want:   "unknown keys",
+			syntax: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			sections := parseSections(t, test.config)
+			_, err := codegen.CallOptionsFor(cacheRegistration, sections)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("CallOptionsFor: got error %v, want %q", err, test.want)
+			}
+			if !test.syntax {
+				return
+			}
+			err = codegen.ComponentConfigValidator("calls", sections["calls"])
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("ComponentConfigValidator: got error %v, want %q", err, test.want)
+			}
+		})
+	}
+}
+
+func TestCallOptionsFor(t *testing.T) {
+	const config = `
+[calls."codegen_test/cache"]
+compression = "deflate"
+compression_threshold = 4096
+balancer = "power_of_two_choices"
+connections_per_replica = 4
+
+[calls."codegen_test/cache".outlier_detection]
+consecutive_errors = 5
+latency_factor = 3
+ejection_time = "10s"
+max_ejection_time = "1m"
+max_ejection_fraction = 0.25
+circuit_breaker = true
+
+[calls."codegen_test/cache".concurrency_limit]
+max_concurrent_calls = 100
+max_queued_calls = 50
+adaptive = true
+
+[calls."codegen_test/cache".methods.Put.concurrency_limit]
+max_concurrent_calls = 10
+`
+	got, err := codegen.CallOptionsFor(cacheRegistration, parseSections(t, config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := codegen.CallOptions{
+		Methods: map[string]codegen.MethodOptions{
+			"Get": {Timeout: time.Second, Retries: 1, Idempotent: true},
+			"Put": {},
+		},
+		Compression:           codegen.CompressionOptions{Algorithm: "deflate", Threshold: 4096},
+		Balancer:              "power_of_two_choices",
+		ConnectionsPerReplica: 4,
+		Outliers: codegen.OutlierOptions{
+			ConsecutiveErrors:   5,
+			LatencyFactor:       3,
+			EjectionTime:        10 * time.Second,
+			MaxEjectionTime:     time.Minute,
+			MaxEjectionFraction: 0.25,
+			CircuitBreaker:      true,
+		},
+		ComponentLimit: &codegen.LimitOptions{MaxConcurrent: 100, MaxQueued: 50, Adaptive: true},
+		MethodLimits:   map[string]codegen.LimitOptions{"Put": {MaxConcurrent: 10}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CallOptionsFor (-want +got):\n%s", diff)
+	}
+
+	// Only the method options are set by default.
+	got, err = codegen.CallOptionsFor(cacheRegistration, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = codegen.CallOptions{
+		Methods:      want.Methods,
+		MethodLimits: map[string]codegen.LimitOptions{},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("default CallOptionsFor (-want +got):\n%s", diff)
+	}
+}
+
+// parseSections returns the sections of the provided TOML config.
+func parseSections(t *testing.T, config string) map[string]string {
+	t.Helper()
+	app, err := runtime.ParseConfig("", config, func(string, string) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	return app.Sections
+}
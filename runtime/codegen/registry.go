@@ -57,6 +57,10 @@ type Registration struct {
 	ConfigFn func(impl any) any // returns pointer to config field in local impl if non-nil
 	Routed   bool               // True if calls to this component should be routed
 
+	// Options for remote calls to the component's methods, keyed by method
+	// name. Methods without an entry use the zero MethodOptions.
+	MethodOptions map[string]MethodOptions
+
 	// Functions that return different types of stubs.
 	LocalStubFn  func(impl any, tracer trace.Tracer) any
 	ClientStubFn func(stub Stub, caller string) any
@@ -125,6 +129,23 @@ func (r *registry) find(path string) (*Registration, bool) {
 // ComponentConfigValidator checks that cfg is a valid configuration
 // for the component type whose fully qualified name is given by path.
 func ComponentConfigValidator(path, cfg string) error {
+	if isCallsSection(path) {
+		var config callsConfig
+		sections := map[string]string{path: cfg}
+		if err := runtime.ParseConfigSection(path, "", sections, &config); err != nil {
+			return fmt.Errorf("bad %q config: %w", shortCallsKey, err)
+		}
+		return nil
+	}
+	if isAuthorizationSection(path) {
+		var config authorizationConfig
+		sections := map[string]string{path: cfg}
+		if err := runtime.ParseConfigSection(path, "", sections, &config); err != nil {
+			return fmt.Errorf("bad %q config: %w", shortAuthorizationKey, err)
+		}
+		return nil
+	}
+
 	info, ok := globalRegistry.find(path)
 	if !ok {
 		// Not for a known component.
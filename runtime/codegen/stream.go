@@ -0,0 +1,169 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// A ClientStream is the client side of a streaming method call. See
+// Stub.Stream.
+type ClientStream interface {
+	// Send sends a serialized stream value to the server. If the call has
+	// ended, Send returns an error, and the outcome of the call is returned
+	// by Result.
+	Send([]byte) error
+
+	// CloseSend tells the server that no more values will be sent.
+	CloseSend() error
+
+	// Recv returns the next serialized stream value sent by the server. Recv
+	// returns io.EOF once the call has ended and all values have been
+	// received.
+	Recv() ([]byte, error)
+
+	// Result waits for the call to end and returns the serialized results.
+	Result() ([]byte, error)
+}
+
+// A ServerStream is the server side of a streaming method call. See
+// StreamServer.
+type ServerStream interface {
+	// Send sends a serialized stream value to the client.
+	Send([]byte) error
+
+	// Recv returns the next serialized stream value sent by the client. Recv
+	// returns io.EOF once the client has sent all values.
+	Recv() ([]byte, error)
+}
+
+// A StreamServer is a Server that also handles streaming methods, i.e.
+// methods that take a weaver.StreamReader or weaver.StreamWriter argument.
+type StreamServer interface {
+	Server
+
+	// GetStreamStubFn returns a handler function for the given streaming
+	// method, or nil if the method is not a streaming method.
+	GetStreamStubFn(method string) func(ctx context.Context, args []byte, stream ServerStream) ([]byte, error)
+}
+
+// StreamReader is a weaver.StreamReader[T] that decodes the values sent by
+// the client of a client-streaming method.
+type StreamReader[T any] struct {
+	recv   func() ([]byte, error)
+	decode func(*Decoder) T
+}
+
+// NewStreamReader returns a StreamReader that receives serialized values
+// using recv and decodes them using decode.
+func NewStreamReader[T any](recv func() ([]byte, error), decode func(*Decoder) T) *StreamReader[T] {
+	return &StreamReader[T]{recv: recv, decode: decode}
+}
+
+// Next returns the next value in the stream, or io.EOF if there are no more
+// values.
+func (r *StreamReader[T]) Next() (value T, err error) {
+	data, err := r.recv()
+	if err != nil {
+		return value, err
+	}
+
+	// Next is called by user code, possibly from a goroutine other than the
+	// one running the server stub, so decoding errors have to be caught here.
+	defer func() {
+		if err == nil {
+			err = CatchPanics(recover())
+		}
+	}()
+	dec := NewDecoder(data)
+	return r.decode(dec), nil
+}
+
+// StreamWriter is a weaver.StreamWriter[T] that encodes the values sent to
+// the client of a server-streaming method.
+type StreamWriter[T any] struct {
+	send   func([]byte) error
+	encode func(*Encoder, T)
+}
+
+// NewStreamWriter returns a StreamWriter that encodes values using encode and
+// sends them using send.
+func NewStreamWriter[T any](send func([]byte) error, encode func(*Encoder, T)) *StreamWriter[T] {
+	return &StreamWriter[T]{send: send, encode: encode}
+}
+
+// Send sends the provided value.
+func (w *StreamWriter[T]) Send(value T) error {
+	enc, err := w.encodeValue(value)
+	if err != nil {
+		return err
+	}
+	return w.send(enc.Data())
+}
+
+// encodeValue encodes value, catching any encoding errors.
+func (w *StreamWriter[T]) encodeValue(value T) (enc *Encoder, err error) {
+	defer func() {
+		if err == nil {
+			err = CatchPanics(recover())
+		}
+	}()
+	enc = NewEncoder()
+	w.encode(enc, value)
+	return enc, nil
+}
+
+// SendStream sends all of the values returned by next, encoded with encode,
+// over stream, and then closes the sending side of the stream. next returns
+// io.EOF once there are no more values. SendStream returns the error returned
+// by next, if any. Errors sending values are not returned: they end the call,
+// and are reported by stream.Result.
+func SendStream[T any](stream ClientStream, next func() (T, error), encode func(*Encoder, T)) error {
+	for {
+		value, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		enc := NewEncoder()
+		encode(enc, value)
+		if err := stream.Send(enc.Data()); err != nil {
+			return nil
+		}
+	}
+	stream.CloseSend()
+	return nil
+}
+
+// RecvStream receives all of the values sent over stream, decodes them with
+// decode, and passes them to send. RecvStream returns the error returned by
+// send, if any. Errors receiving values are not returned: they end the call,
+// and are reported by stream.Result.
+func RecvStream[T any](stream ClientStream, send func(T) error, decode func(*Decoder) T) error {
+	for {
+		data, err := stream.Recv()
+		if err != nil {
+			return nil
+		}
+		dec := NewDecoder(data)
+		if err := send(decode(dec)); err != nil {
+			return err
+		}
+	}
+}
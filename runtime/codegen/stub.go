@@ -35,6 +35,11 @@ type Stub interface {
 	// serialized arguments and results, respectively. shardKey is the shard
 	// key for routed components, and 0 otherwise.
 	Run(ctx context.Context, method int, args []byte, shardKey uint64) (results []byte, err error)
+
+	// Stream starts a streaming call of the provided method with the provided
+	// serialized arguments. The arguments are the same as for Run. The call
+	// is canceled when ctx is canceled.
+	Stream(ctx context.Context, method int, args []byte, shardKey uint64) (ClientStream, error)
 }
 
 // A Server allows a Service Weaver component in one process to receive and execute
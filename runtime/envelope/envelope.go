@@ -24,6 +24,7 @@ import (
 	"os"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/ServiceWeaver/weaver/internal/envelope/conn"
 	"github.com/ServiceWeaver/weaver/internal/pipe"
@@ -81,11 +82,13 @@ type Envelope struct {
 
 	mu        sync.Mutex // guards the following fields
 	profiling bool       // are we currently collecting a profile?
+	stopping  bool       // has Shutdown been called?
 }
 
 // NewEnvelope creates a new envelope, starting a weavelet subprocess and
 // establishing a bidirectional connection with it. The weavelet process can be
-// stopped at any time by canceling the passed-in context.
+// stopped gracefully by calling [Shutdown], or it can be killed at any time by
+// canceling the passed-in context.
 //
 // You can issue RPCs *to* the weavelet using the returned Envelope. To start
 // receiving messages *from* the weavelet, call [Serve].
@@ -150,8 +153,8 @@ func NewEnvelope(ctx context.Context, wlet *protos.EnvelopeInfo, config *protos.
 // Serve accepts incoming messages from the weavelet. RPC requests are handled
 // serially in the order they are received. Serve blocks until the connection
 // terminates, returning the error that caused it to terminate. You can cancel
-// the connection by cancelling the context passed to [NewEnvelope]. This
-// method never returns a non-nil error.
+// the connection by cancelling the context passed to [NewEnvelope]. If the
+// weavelet was stopped by a call to [Shutdown], Serve returns nil.
 func (e *Envelope) Serve(h EnvelopeHandler) error {
 	var running errgroup.Group
 
@@ -200,6 +203,11 @@ func (e *Envelope) Serve(h EnvelopeHandler) error {
 	stop(err)
 	e.cmd.Cleanup()
 
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.stopping {
+		return nil
+	}
 	return stopErr
 }
 
@@ -224,11 +232,18 @@ func (e *Envelope) WeaveletInfo() *protos.WeaveletInfo {
 
 // GetHealth returns the health status of the weavelet.
 func (e *Envelope) GetHealth() protos.HealthStatus {
-	status, err := e.conn.GetHealthRPC()
+	reply, err := e.conn.GetHealthRPC()
 	if err != nil {
 		return protos.HealthStatus_UNHEALTHY
 	}
-	return status
+	return reply.Status
+}
+
+// GetComponentHealth returns the health status of the weavelet, along with
+// the health of every component hosted by the weavelet that checks its own
+// health.
+func (e *Envelope) GetComponentHealth() (*protos.GetHealthReply, error) {
+	return e.conn.GetHealthRPC()
 }
 
 // GetProfile gets a profile from the weavelet.
@@ -250,6 +265,51 @@ func (e *Envelope) GetLoad() (*protos.LoadReport, error) {
 	return e.conn.GetLoadRPC()
 }
 
+// Shutdown gracefully shuts down the weavelet. The weavelet stops accepting
+// new method calls, waits for in-progress method calls to finish, and shuts
+// down its components, after which the weavelet process exits. If ctx is
+// done before the weavelet process exits, the process is killed.
+//
+// Note that a weavelet that runs main does not exit on its own; it is killed
+// as soon as it has shut down its components.
+func (e *Envelope) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	e.stopping = true
+	e.mu.Unlock()
+	defer e.ctxCancel()
+
+	req := &protos.ShutdownRequest{}
+	if deadline, ok := ctx.Deadline(); ok {
+		req.GracePeriodNs = int64(time.Until(deadline))
+	}
+	errs := make(chan error, 1)
+	go func() { errs <- e.conn.ShutdownRPC(req) }()
+	select {
+	case err := <-errs:
+		if err != nil && e.weavelet.RunMain {
+			return err
+		}
+		// Note that the weavelet process may exit before its reply to the
+		// shutdown request is processed, in which case the RPC fails with a
+		// broken connection. We ignore the error and wait for the process
+		// to exit below.
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if e.weavelet.RunMain {
+		return nil
+	}
+
+	// Wait for the weavelet process to exit. Note that e.ctx is canceled by
+	// Serve when the weavelet process exits.
+	select {
+	case <-e.ctx.Done():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // UpdateComponents updates the weavelet with the latest set of components it
 // should be running.
 func (e *Envelope) UpdateComponents(components []string) error {
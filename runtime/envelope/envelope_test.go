@@ -19,6 +19,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -434,6 +435,39 @@ func TestRPCBeforeServe(t *testing.T) {
 	}
 }
 
+func TestUnixSocket(t *testing.T) {
+	// Test plan: Start a weavelet that is asked to listen on a Unix socket.
+	// Make sure that the weavelet reports the socket's address and that the
+	// socket can be dialed.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	socket := filepath.Join(t.TempDir(), "weavelet.sock")
+	wlet, config := wlet(executable, "serve_conn")
+	wlet.UnixSocket = socket
+	e, err := NewEnvelope(ctx, wlet, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() {
+		done <- e.Serve(&handlerForTest{logSaver: testSaver(t)})
+	}()
+
+	if got, want := e.WeaveletInfo().UnixDialAddr, "unix://"+socket; got != want {
+		t.Fatalf("UnixDialAddr: got %q, want %q", got, want)
+	}
+	c, err := net.Dial("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Close()
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("weavelet failed: %v", err)
+	}
+}
+
 func startEnvelope(ctx context.Context, t *testing.T) (*Envelope, chan error) {
 	wlet, config := wlet(executable, "serve_conn")
 	e, err := NewEnvelope(ctx, wlet, config)
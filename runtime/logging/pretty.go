@@ -17,6 +17,7 @@ package logging
 import (
 	"fmt"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
@@ -210,10 +211,21 @@ func Shorten(s string) string {
 	return string(runes[:n])
 }
 
+// packagePaths matches the package paths that qualify the type arguments in
+// the names of generic components, e.g., "github.com/foo/" in
+// "github.com/foo/Cache[github.com/foo.User]".
+var packagePaths = regexp.MustCompile(`([^\[\]*,\s/]+/)+`)
+
 // ShortenComponent shortens the given component name to be of the format
 // <pkg>.<IfaceType>. (Recall that the full component name is of the format
-// <path1>/<path2>/.../<pathN>/<IfaceType>.)
+// <path1>/<path2>/.../<pathN>/<IfaceType>.) The type arguments of a generic
+// component are shortened similarly, e.g., the component name
+// "github.com/foo/Cache[github.com/foo.User]" is shortened to
+// "foo.Cache[foo.User]".
 func ShortenComponent(component string) string {
+	if i := strings.IndexByte(component, '['); i >= 0 {
+		return ShortenComponent(component[:i]) + packagePaths.ReplaceAllString(component[i:], "")
+	}
 	parts := strings.Split(component, "/")
 	switch len(parts) {
 	case 0: // should never happen
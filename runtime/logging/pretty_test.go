@@ -0,0 +1,32 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import "testing"
+
+func TestShortenComponent(t *testing.T) {
+	for _, test := range []struct{ component, want string }{
+		{"main", "main"},
+		{"main/Main", "main.Main"},
+		{"github.com/foo/bar/Cache", "bar.Cache"},
+		{"github.com/foo/bar/Cache[github.com/foo/bar.User]", "bar.Cache[bar.User]"},
+		{"github.com/foo/bar/Cache[string,map[int][]*github.com/foo/baz.User]", "bar.Cache[string,map[int][]*baz.User]"},
+		{"github.com/foo/bar/Cache[github.com/foo/bar.User].Get", "bar.Cache[bar.User].Get"},
+	} {
+		if got := ShortenComponent(test.component); got != test.want {
+			t.Errorf("ShortenComponent(%q): got %q, want %q", test.component, got, test.want)
+		}
+	}
+}
@@ -27,8 +27,9 @@ type Exporter struct {
 	versions map[uint64]uint64
 }
 
-// Export produces a MetricUpdate that summarizes the changes to all metrics
-// since the last call to MetricUpdate.
+// Export produces a MetricUpdate that summarizes the changes to all metrics,
+// including the metrics that have been deleted, since the last call to
+// MetricUpdate.
 func (e *Exporter) Export() *protos.MetricUpdate {
 	if e.versions == nil {
 		e.versions = map[uint64]uint64{}
@@ -55,6 +56,21 @@ func (e *Exporter) Export() *protos.MetricUpdate {
 		e.versions[metric.id] = version
 		update.Values = append(update.Values, metric.MetricValue())
 	}
+
+	// Every exported metric has an entry in e.versions, so there are more
+	// entries than metrics only if some exported metrics have been deleted.
+	if len(e.versions) > len(metrics) {
+		live := make(map[uint64]bool, len(metrics))
+		for _, metric := range metrics {
+			live[metric.id] = true
+		}
+		for id := range e.versions {
+			if !live[id] {
+				delete(e.versions, id)
+				update.Deleted = append(update.Deleted, id)
+			}
+		}
+	}
 	return update
 }
 
@@ -93,5 +109,9 @@ func (i *Importer) Import(update *protos.MetricUpdate) ([]*MetricSnapshot, error
 		metric.Counts = val.Counts
 	}
 
+	for _, id := range update.Deleted {
+		delete(i.metrics, id)
+	}
+
 	return maps.Values(i.metrics), nil
 }
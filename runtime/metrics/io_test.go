@@ -215,3 +215,56 @@ func TestConcurrentExportImport(t *testing.T) {
 		t.Fatalf("bad snapshot (-want +got):\n%s", diff)
 	}
 }
+
+func TestExportImportDelete(t *testing.T) {
+	clear()
+	var exporter Exporter
+	var importer Importer
+	io := func() []*MetricSnapshot {
+		snapshots, err := importer.Import(exporter.Export())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return snapshots
+	}
+
+	type a = struct{ A string }
+	gaugeFamily := RegisterMap[a](gaugeType, "TestExportImportDelete/gauge_family", "", nil)
+	gaugeFamily.Get(a{"1"}).Set(100)
+	gaugeFamily.Get(a{"2"}).Set(200)
+	io()
+
+	// Deleted metrics are removed from the importer's snapshot.
+	gaugeFamily.Delete(a{"1"})
+	gaugeFamily.Get(a{"2"}).Set(201)
+	want := []*MetricSnapshot{
+		{
+			Type:   protos.MetricType_GAUGE,
+			Name:   "TestExportImportDelete/gauge_family",
+			Value:  201.0,
+			Labels: map[string]string{"a": "2"},
+		},
+	}
+	opts := []cmp.Option{cmpopts.IgnoreFields(MetricSnapshot{}, "Id", "Help")}
+	if diff := cmp.Diff(want, io(), opts...); diff != "" {
+		t.Fatalf("bad snapshot (-want +got):\n%s", diff)
+	}
+	if got := len(Snapshot()); got != 1 {
+		t.Fatalf("got %d registered metrics, want 1", got)
+	}
+
+	// A deleted metric can be recreated.
+	gaugeFamily.Get(a{"1"}).Set(101)
+	want = append(want, &MetricSnapshot{
+		Type:   protos.MetricType_GAUGE,
+		Name:   "TestExportImportDelete/gauge_family",
+		Value:  101.0,
+		Labels: map[string]string{"a": "1"},
+	})
+	opts = append(opts, cmpopts.SortSlices(func(x, y *MetricSnapshot) bool {
+		return x.Value > y.Value
+	}))
+	if diff := cmp.Diff(want, io(), opts...); diff != "" {
+		t.Fatalf("bad snapshot (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mtls issues the certificates that weavelets use for mutual TLS, and
+// builds the TLS configs that weavelets use to communicate with each other.
+//
+// A deployer creates a certificate authority (CA) for every deployment with
+// NewCA, and issues a certificate for every weavelet with CA.Issue. The
+// issued credentials are passed to the weavelet in its EnvelopeInfo. Every
+// certificate is tagged with the id of the deployment it belongs to, and a
+// weavelet only communicates with weavelets in the same deployment. A
+// certificate also lists the components that the weavelet may host, which
+// lets a weavelet check the identity of the component making a call; see
+// Components.
+package mtls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+)
+
+const (
+	// uriScheme is the scheme of the URI that identifies the deployment and
+	// the weavelet a certificate is issued for, along with the components the
+	// weavelet may host, e.g.,
+	// serviceweaver://<deployment id>/<weavelet id>?component=<component>.
+	uriScheme = "serviceweaver"
+
+	// componentParam is the URI query parameter that lists components.
+	componentParam = "component"
+
+	// validity is how long certificates are valid for.
+	validity = 365 * 24 * time.Hour
+)
+
+// A CA is a certificate authority for a single deployment.
+type CA struct {
+	deploymentID string
+	cert         *x509.Certificate
+	certPEM      []byte
+	key          *ecdsa.PrivateKey
+}
+
+// NewCA returns a new self-signed CA for the provided deployment.
+func NewCA(deploymentID string) (*CA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate CA key: %w", err)
+	}
+	serial, err := serialNumber()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "Service Weaver CA " + deploymentID},
+		NotBefore:             now.Add(-time.Hour), // tolerate clock skew
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+		URIs:                  []*url.URL{deploymentURI(deploymentID, "", nil)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("create CA certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("parse CA certificate: %w", err)
+	}
+	return &CA{
+		deploymentID: deploymentID,
+		cert:         cert,
+		certPEM:      pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		key:          key,
+	}, nil
+}
+
+// Issue issues a certificate for the provided weavelet, which may host the
+// provided components, and returns the credentials to pass to the weavelet in
+// its EnvelopeInfo.
+func (ca *CA) Issue(weaveletID string, components []string) (*protos.MTLSInfo, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate key: %w", err)
+	}
+	serial, err := serialNumber()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: weaveletID},
+		NotBefore:    now.Add(-time.Hour), // tolerate clock skew
+		NotAfter:     now.Add(validity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		URIs:         []*url.URL{deploymentURI(ca.deploymentID, weaveletID, components)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, fmt.Errorf("create certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("marshal key: %w", err)
+	}
+	return &protos.MTLSInfo{
+		CaCert: ca.certPEM,
+		Cert:   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		Key:    pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
+
+// ClientConfig returns the TLS config a weavelet in the provided deployment
+// uses to connect to other weavelets.
+func ClientConfig(deploymentID string, info *protos.MTLSInfo) (*tls.Config, error) {
+	cert, roots, err := parse(info)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS13,
+		// Weavelets are dialed by IP address, which is not included in the
+		// certificates, so the standard verification, which checks the host
+		// name, is replaced by verifyPeer.
+		InsecureSkipVerify:    true,
+		VerifyPeerCertificate: verifyPeer(deploymentID, roots, x509.ExtKeyUsageServerAuth),
+	}, nil
+}
+
+// ServerConfig returns the TLS config a weavelet in the provided deployment
+// uses to accept connections from other weavelets.
+func ServerConfig(deploymentID string, info *protos.MTLSInfo) (*tls.Config, error) {
+	cert, roots, err := parse(info)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS13,
+		// The client certificate is verified by verifyPeer, which also checks
+		// the deployment.
+		ClientAuth:            tls.RequireAnyClientCert,
+		VerifyPeerCertificate: verifyPeer(deploymentID, roots, x509.ExtKeyUsageClientAuth),
+	}, nil
+}
+
+// parse parses the weavelet's certificate and the CA certificate in info.
+func parse(info *protos.MTLSInfo) (tls.Certificate, *x509.CertPool, error) {
+	cert, err := tls.X509KeyPair(info.Cert, info.Key)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("parse weavelet certificate: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(info.CaCert) {
+		return tls.Certificate{}, nil, fmt.Errorf("parse CA certificate: no certificates found")
+	}
+	return cert, roots, nil
+}
+
+// verifyPeer returns a function that verifies that the certificate presented
+// by a peer is issued by one of the provided roots, for the provided usage,
+// and for a weavelet in the provided deployment.
+func verifyPeer(deploymentID string, roots *x509.CertPool, usage x509.ExtKeyUsage) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("peer has no certificate")
+		}
+		certs := make([]*x509.Certificate, len(rawCerts))
+		for i, raw := range rawCerts {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return fmt.Errorf("parse peer certificate: %w", err)
+			}
+			certs[i] = cert
+		}
+		intermediates := x509.NewCertPool()
+		for _, cert := range certs[1:] {
+			intermediates.AddCert(cert)
+		}
+		opts := x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{usage},
+		}
+		if _, err := certs[0].Verify(opts); err != nil {
+			return fmt.Errorf("verify peer certificate: %w", err)
+		}
+		for _, uri := range certs[0].URIs {
+			if uri.Scheme == uriScheme && uri.Host == deploymentID {
+				return nil
+			}
+		}
+		return fmt.Errorf("peer %q does not belong to deployment %q", certs[0].Subject.CommonName, deploymentID)
+	}
+}
+
+// Components returns the components that the weavelet holding the provided
+// certificate may host. The certificate must have been verified.
+func Components(cert *x509.Certificate) []string {
+	for _, uri := range cert.URIs {
+		if uri.Scheme == uriScheme {
+			return uri.Query()[componentParam]
+		}
+	}
+	return nil
+}
+
+// deploymentURI returns the URI that identifies the provided weavelet in the
+// provided deployment, along with the components it may host.
+func deploymentURI(deploymentID, weaveletID string, components []string) *url.URL {
+	u := &url.URL{Scheme: uriScheme, Host: deploymentID}
+	if weaveletID != "" {
+		u.Path = "/" + weaveletID
+	}
+	if len(components) > 0 {
+		u.RawQuery = url.Values{componentParam: components}.Encode()
+	}
+	return u
+}
+
+// serialNumber returns a random certificate serial number.
+func serialNumber() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("generate serial number: %w", err)
+	}
+	return serial, nil
+}
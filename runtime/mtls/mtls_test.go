@@ -0,0 +1,169 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtls_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/runtime/mtls"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"github.com/google/go-cmp/cmp"
+)
+
+// components are the components of the weavelets issued credentials by issue.
+var components = []string{"main", "github.com/example/app/Cache"}
+
+// issue returns credentials for a weavelet in the provided deployment, issued
+// by ca.
+func issue(t *testing.T, ca *mtls.CA, weavelet string) *protos.MTLSInfo {
+	t.Helper()
+	creds, err := ca.Issue(weavelet, components)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return creds
+}
+
+// handshake performs a TLS handshake between a client in clientDep with
+// clientCreds and a server in serverDep with serverCreds. It returns the
+// errors returned by the client and the server.
+func handshake(t *testing.T, clientDep string, clientCreds *protos.MTLSInfo, serverDep string, serverCreds *protos.MTLSInfo) (clientErr, serverErr error) {
+	t.Helper()
+	clientConfig, err := mtls.ClientConfig(clientDep, clientCreds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverConfig, err := mtls.ServerConfig(serverDep, serverCreds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Note that we use a TCP connection, rather than a net.Pipe, because a
+	// failing handshake may leave both sides writing.
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+	errs := make(chan error, 1)
+	go func() {
+		s, err := lis.Accept()
+		if err != nil {
+			errs <- err
+			return
+		}
+		defer s.Close()
+		errs <- tls.Server(s, serverConfig).Handshake()
+	}()
+
+	c, err := net.Dial("tcp", lis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	client := tls.Client(c, clientConfig)
+	clientErr = client.Handshake()
+	if clientErr == nil {
+		// With TLS 1.3, the client finishes its handshake before the server
+		// has verified the client's certificate. A failed verification is
+		// reported by the server when the client next reads.
+		_, clientErr = client.Read(make([]byte, 1))
+		if errors.Is(clientErr, io.EOF) {
+			clientErr = nil
+		}
+	}
+	return clientErr, <-errs
+}
+
+func TestSameDeployment(t *testing.T) {
+	ca, err := mtls.NewCA("dep")
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientErr, serverErr := handshake(t, "dep", issue(t, ca, "a"), "dep", issue(t, ca, "b"))
+	if clientErr != nil {
+		t.Errorf("client: %v", clientErr)
+	}
+	if serverErr != nil {
+		t.Errorf("server: %v", serverErr)
+	}
+}
+
+func TestOtherCA(t *testing.T) {
+	// Two CAs for the same deployment id don't trust each other.
+	ca1, err := mtls.NewCA("dep")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca2, err := mtls.NewCA("dep")
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientErr, _ := handshake(t, "dep", issue(t, ca1, "a"), "dep", issue(t, ca2, "b"))
+	if clientErr == nil || !strings.Contains(clientErr.Error(), "verify peer certificate") {
+		t.Fatalf("client: got error %v, want verification error", clientErr)
+	}
+}
+
+func TestOtherDeployment(t *testing.T) {
+	// A server that expects peers in deployment "other" rejects a client in
+	// deployment "dep", even if it trusts the client's CA.
+	ca, err := mtls.NewCA("dep")
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds := issue(t, ca, "a")
+	_, serverErr := handshake(t, "dep", creds, "other", creds)
+	if serverErr == nil || !strings.Contains(serverErr.Error(), `does not belong to deployment "other"`) {
+		t.Fatalf("server: got error %v, want deployment error", serverErr)
+	}
+}
+
+func TestBadCredentials(t *testing.T) {
+	ca, err := mtls.NewCA("dep")
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds := issue(t, ca, "a")
+	creds.Key = []byte("not a key")
+	if _, err := mtls.ClientConfig("dep", creds); err == nil {
+		t.Fatal("unexpected success")
+	}
+}
+
+func TestComponents(t *testing.T) {
+	ca, err := mtls.NewCA("dep")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(issue(t, ca, "a").Cert)
+	if block == nil {
+		t.Fatal("no certificate found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(components, mtls.Components(cert)); diff != "" {
+		t.Fatalf("Components (-want +got):\n%s", diff)
+	}
+}
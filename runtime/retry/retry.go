@@ -39,10 +39,12 @@ type Retry struct {
 
 // Options are the options that configure a retry loop. Before the ith
 // iteration of a retry loop, retry.Continue() sleeps for a duration of
-// BackoffMinDuration * BackoffMultiplier^i, with added jitter.
+// BackoffMinDuration * BackoffMultiplier^i, capped at BackoffMaxDuration, with
+// added jitter.
 type Options struct {
 	BackoffMultiplier  float64 // If specified, must be at least 1.
 	BackoffMinDuration time.Duration
+	BackoffMaxDuration time.Duration // If zero, the backoff is unbounded.
 }
 
 // DefaultOptions is the default set of Options.
@@ -107,7 +109,11 @@ func (r *Retry) Reset() {
 
 func backoffDelay(i int, opts Options) time.Duration {
 	mult := math.Pow(opts.BackoffMultiplier, float64(i))
-	return time.Duration(float64(opts.BackoffMinDuration) * mult)
+	delay := float64(opts.BackoffMinDuration) * mult
+	if opts.BackoffMaxDuration > 0 && delay > float64(opts.BackoffMaxDuration) {
+		return opts.BackoffMaxDuration
+	}
+	return time.Duration(delay)
 }
 
 // randomized sleeps for a random duration close to d, or until context is done,
@@ -44,6 +44,28 @@ func TestRetry(t *testing.T) {
 	}
 }
 
+func TestBackoffDelay(t *testing.T) {
+	opts := Options{
+		BackoffMultiplier:  2,
+		BackoffMinDuration: time.Second,
+		BackoffMaxDuration: 30 * time.Second,
+	}
+	for _, c := range []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{4, 16 * time.Second},
+		{5, 30 * time.Second},
+		{1000, 30 * time.Second},
+	} {
+		if got := backoffDelay(c.attempt, opts); got != c.want {
+			t.Errorf("backoffDelay(%d): got %v, want %v", c.attempt, got, c.want)
+		}
+	}
+}
+
 func TestSleepFor(t *testing.T) {
 	const N = 20
 	const delay = time.Millisecond * 10
@@ -0,0 +1,79 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaver
+
+import "io"
+
+// StreamReader is a stream of values of type T. A component method that takes
+// a StreamReader as its last argument is a client-streaming method: the
+// caller provides the values, and the method reads them. For example:
+//
+//	type Uploader interface {
+//	    Upload(ctx context.Context, name string, chunks weaver.StreamReader[[]byte]) (int, error)
+//	}
+//
+// When the method is called remotely, the values are sent to the component
+// while the method runs, rather than all at once, and the sender blocks if the
+// method doesn't read the values fast enough. T must be serializable.
+type StreamReader[T any] interface {
+	// Next returns the next value in the stream, or io.EOF if there are no
+	// more values. Next must not be called concurrently.
+	Next() (T, error)
+}
+
+// StreamWriter is a sink for values of type T. A component method that takes
+// a StreamWriter as its last argument is a server-streaming method: the
+// method provides the values, and the caller receives them. For example:
+//
+//	type Lister interface {
+//	    List(ctx context.Context, prefix string, files weaver.StreamWriter[string]) error
+//	}
+//
+// When the method is called remotely, the values are sent to the caller while
+// the method runs, and the method blocks in Send if the caller doesn't
+// receive the values fast enough. If Send returns an error, the method should
+// stop sending values and return. T must be serializable.
+type StreamWriter[T any] interface {
+	// Send sends a value. Send must not be called concurrently.
+	Send(T) error
+}
+
+// SliceReader returns a StreamReader that returns the provided values.
+func SliceReader[T any](values []T) StreamReader[T] {
+	return &sliceReader[T]{values: values}
+}
+
+type sliceReader[T any] struct {
+	values []T
+}
+
+func (r *sliceReader[T]) Next() (T, error) {
+	if len(r.values) == 0 {
+		var zero T
+		return zero, io.EOF
+	}
+	v := r.values[0]
+	r.values = r.values[1:]
+	return v, nil
+}
+
+// StreamWriterFunc is an adapter that allows the use of an ordinary function
+// as a StreamWriter.
+type StreamWriterFunc[T any] func(T) error
+
+// Send calls f(value).
+func (f StreamWriterFunc[T]) Send(value T) error {
+	return f(value)
+}
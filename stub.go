@@ -16,18 +16,44 @@ package weaver
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"time"
 
 	"github.com/ServiceWeaver/weaver/internal/net/call"
 	"github.com/ServiceWeaver/weaver/runtime/codegen"
+	"github.com/ServiceWeaver/weaver/runtime/retry"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slices"
 )
 
 // stub holds information about a client stub to the remote component.
 type stub struct {
-	client   call.Connection  // client to talk to the remote component, created lazily.
-	methods  []call.MethodKey // Keys for the remote component methods.
-	balancer call.Balancer    // if not nil, component load balancer
-	tracer   trace.Tracer     // component tracer
+	client   call.Connection         // client to talk to the remote component, created lazily.
+	methods  []call.MethodKey        // Keys for the remote component methods.
+	options  []codegen.MethodOptions // if not nil, options for the remote component methods.
+	balancer call.Balancer           // if not nil, component load balancer
+	tracer   trace.Tracer            // component tracer
+	caller   string                  // name of the calling component
+	compress bool                    // are calls to the remote component compressed?
+	hedged   bool                    // are calls to some remote component methods hedged?
+
+	// If not nil, metrics for the compressed calls to the remote component
+	// methods.
+	compressed []*codegen.CompressedMethodMetrics
+
+	// If not nil, metrics for the hedged calls to the remote component
+	// methods, or nil for methods that are not hedged.
+	hedges []*codegen.HedgeMethodMetrics
+
+	// If not nil, the recent latencies of the remote component methods that
+	// are hedged at their 95th percentile latency, or nil for other methods.
+	latencies []*latencyTracker
+
+	// If not nil, intercepts calls to the remote component methods, which
+	// are described by calls.
+	interceptor RemoteClientInterceptor
+	calls       []RemoteCallInfo
 }
 
 var _ codegen.Stub = &stub{}
@@ -39,9 +65,211 @@ func (s *stub) Tracer() trace.Tracer {
 
 // Run implements the codegen.Stub interface.
 func (s *stub) Run(ctx context.Context, method int, args []byte, shardKey uint64) ([]byte, error) {
+	if s.interceptor == nil {
+		return s.run(ctx, method, args, shardKey)
+	}
+	var result []byte
+	err := s.interceptor(ctx, s.calls[method], func(ctx context.Context) error {
+		var err error
+		result, err = s.run(ctx, method, args, shardKey)
+		return err
+	})
+	return result, err
+}
+
+// run makes a call to the provided method, applying the method's options.
+func (s *stub) run(ctx context.Context, method int, args []byte, shardKey uint64) ([]byte, error) {
 	opts := call.CallOptions{
 		ShardKey: shardKey,
 		Balancer: s.balancer,
+		Caller:   s.caller,
+	}
+	var mopts codegen.MethodOptions
+	if s.options != nil {
+		mopts = s.options[method]
+	}
+	if mopts.Idempotent {
+		if mopts.Hedge > 0 {
+			opts.HedgeDelay = mopts.Hedge
+		} else if mopts.HedgeP95 && s.latencies != nil {
+			opts.HedgeDelay = s.latencies[method].percentile95()
+		}
+	}
+
+	backoff := retry.DefaultOptions
+	if mopts.Backoff > 0 {
+		backoff.BackoffMinDuration = mopts.Backoff
+	}
+	attempt := 0
+	for r := retry.BeginWithOptions(backoff); r.Continue(ctx); attempt++ {
+		result, err := s.attempt(ctx, method, args, opts, mopts.Timeout)
+		if err == nil {
+			return result, nil
+		}
+		// An attempt that timed out may have executed, so, like a call that
+		// failed to communicate with the component, it is only retried if
+		// the method is idempotent.
+		timedOut := ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded)
+		if !retriable(err, mopts.Idempotent) && !(timedOut && mopts.Idempotent) {
+			return result, err
+		}
+		// A call that was never executed by the component is retried at
+		// least once, even if the method isn't configured with retries.
+		retries := mopts.Retries
+		if retries == 0 && rejected(err) {
+			retries = 1
+		}
+		if attempt >= retries {
+			return result, err
+		}
 	}
-	return s.client.Call(ctx, s.methods[method], args, opts)
+	return nil, ctx.Err()
+}
+
+// attempt makes a single call to the provided method, bounded by timeout if
+// timeout is positive.
+func (s *stub) attempt(ctx context.Context, method int, args []byte, opts call.CallOptions, timeout time.Duration) ([]byte, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return s.call(ctx, method, args, opts)
+}
+
+// call makes a single call to the provided method, and records the sizes of
+// its compressed payloads, whether it was hedged, and its latency, if needed.
+func (s *stub) call(ctx context.Context, method int, args []byte, opts call.CallOptions) ([]byte, error) {
+	var latencies *latencyTracker
+	if s.latencies != nil {
+		latencies = s.latencies[method]
+	}
+	if s.compressed == nil && s.hedges == nil && latencies == nil {
+		result, err := s.client.Call(ctx, s.methods[method], args, opts)
+		return result, callError(err)
+	}
+	var stats call.CallStats
+	opts.Stats = &stats
+	start := time.Now()
+	result, err := s.client.Call(ctx, s.methods[method], args, opts)
+	if latencies != nil && err == nil {
+		latencies.record(time.Since(start))
+	}
+	if s.compressed != nil {
+		if stats.CompressedRequestBytes > 0 {
+			s.compressed[method].BytesRequest.Put(float64(stats.CompressedRequestBytes))
+		}
+		if stats.CompressedReplyBytes > 0 {
+			s.compressed[method].BytesReply.Put(float64(stats.CompressedReplyBytes))
+		}
+	}
+	if s.hedges != nil && stats.Hedged {
+		s.hedges[method].Hedges.Add(1)
+		if stats.HedgeWon {
+			s.hedges[method].Wins.Add(1)
+		}
+	}
+	return result, callError(err)
+}
+
+// callError returns err, with an embedded CircuitOpenError or OverloadedError
+// if err is a call.CircuitOpen or call.Overloaded error, respectively.
+func callError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, call.CircuitOpen):
+		return errors.Join(CircuitOpenError, err)
+	case errors.Is(err, call.Overloaded):
+		return errors.Join(OverloadedError, err)
+	default:
+		return err
+	}
+}
+
+// retriable returns true if a call that failed with err can be retried. A
+// call to an idempotent method can be retried if there was any problem
+// communicating with the component. Other calls can only be retried if they
+// were rejected (see rejected). A retry backs off, and is likely sent to
+// another replica.
+func retriable(err error, idempotent bool) bool {
+	return rejected(err) || (idempotent && errors.Is(err, call.CommunicationError))
+}
+
+// rejected returns true if a call that failed with err was never executed by
+// the component, either because it was never sent to the component, or
+// because the component shed it because it was overloaded.
+func rejected(err error) bool {
+	return errors.Is(err, call.Unreachable) || errors.Is(err, call.Overloaded)
+}
+
+// Stream implements the codegen.Stub interface.
+func (s *stub) Stream(ctx context.Context, method int, args []byte, shardKey uint64) (codegen.ClientStream, error) {
+	opts := call.CallOptions{
+		ShardKey: shardKey,
+		Balancer: s.balancer,
+		Caller:   s.caller,
+	}
+	if s.interceptor == nil {
+		stream, err := s.client.Stream(ctx, s.methods[method], args, opts)
+		if err != nil {
+			return nil, callError(err)
+		}
+		return stream, nil
+	}
+	var stream codegen.ClientStream
+	err := s.interceptor(ctx, s.calls[method], func(ctx context.Context) error {
+		var err error
+		stream, err = s.client.Stream(ctx, s.methods[method], args, opts)
+		return callError(err)
+	})
+	return stream, err
+}
+
+const (
+	// latencyWindow is the number of recent calls whose latency is tracked
+	// by a latencyTracker.
+	latencyWindow = 1000
+
+	// latencyInterval is the number of calls between two estimations of the
+	// 95th percentile latency. The first estimation is made after
+	// latencyInterval calls.
+	latencyInterval = 100
+)
+
+// latencyTracker tracks the latencies of the recent successful calls to a
+// method, and estimates their 95th percentile. A latencyTracker is safe for
+// concurrent use.
+type latencyTracker struct {
+	mu      sync.Mutex
+	window  [latencyWindow]time.Duration // ring buffer of recent latencies
+	n       int                          // number of recorded latencies
+	p95     time.Duration                // latest estimate, or zero
+	scratch []time.Duration              // used to sort the window
+}
+
+// record records the latency of a call.
+func (l *latencyTracker) record(latency time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.window[l.n%latencyWindow] = latency
+	l.n++
+	if l.n%latencyInterval != 0 {
+		return
+	}
+	n := l.n
+	if n > latencyWindow {
+		n = latencyWindow
+	}
+	l.scratch = append(l.scratch[:0], l.window[:n]...)
+	slices.Sort(l.scratch)
+	l.p95 = l.scratch[n*95/100]
+}
+
+// percentile95 returns the estimated 95th percentile latency, or zero if too
+// few calls have been recorded.
+func (l *latencyTracker) percentile95() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.p95
 }
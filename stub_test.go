@@ -18,12 +18,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/ServiceWeaver/weaver/internal/net/call"
 	"github.com/ServiceWeaver/weaver/runtime/codegen"
+	"github.com/ServiceWeaver/weaver/runtime/logging"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -37,6 +41,10 @@ func (c *localClient) Call(ctx context.Context, _ call.MethodKey, args []byte, _
 	return handleCall(ctx, reflect.ValueOf(c.fn), args)
 }
 
+func (c *localClient) Stream(context.Context, call.MethodKey, []byte, call.CallOptions) (call.ClientStream, error) {
+	return nil, fmt.Errorf("streaming calls not supported")
+}
+
 func (c *localClient) Close() {}
 
 func TestCall(t *testing.T) {
@@ -306,3 +314,330 @@ func weaverDecode(d *codegen.Decoder, output interface{}) interface{} {
 		panic(fmt.Errorf("Unable to decode type %v with Service Weaver decoder\n", x))
 	}
 }
+
+// failingClient is a call.Connection whose calls fail with the provided
+// errors, in order, and then succeed.
+type failingClient struct {
+	errs  []error
+	calls int
+}
+
+var _ call.Connection = &failingClient{}
+
+func (c *failingClient) Call(context.Context, call.MethodKey, []byte, call.CallOptions) ([]byte, error) {
+	c.calls++
+	if len(c.errs) == 0 {
+		return nil, nil
+	}
+	err := c.errs[0]
+	c.errs = c.errs[1:]
+	return nil, err
+}
+
+func (c *failingClient) Stream(context.Context, call.MethodKey, []byte, call.CallOptions) (call.ClientStream, error) {
+	return nil, fmt.Errorf("streaming calls not supported")
+}
+
+func (c *failingClient) Close() {}
+
+func TestRetries(t *testing.T) {
+	unreachable := fmt.Errorf("%w: no endpoints", call.Unreachable)
+	broken := fmt.Errorf("%w: connection closed", call.CommunicationError)
+	appErr := errors.New("application error")
+	open := fmt.Errorf("%w: every endpoint is unhealthy", call.CircuitOpen)
+	overloaded := fmt.Errorf("%w: too many concurrent calls", call.Overloaded)
+
+	for _, test := range []struct {
+		name      string
+		opts      codegen.MethodOptions
+		errs      []error
+		wantErr   error // nil if the call should succeed
+		wantCalls int
+	}{
+		{"NoRetries", codegen.MethodOptions{}, []error{broken}, call.CommunicationError, 1},
+		{"NoRetriesUnreachable", codegen.MethodOptions{}, []error{unreachable}, nil, 2},
+		{"NoRetriesOverloaded", codegen.MethodOptions{}, []error{overloaded}, nil, 2},
+		{"NoRetriesTooOverloaded", codegen.MethodOptions{}, []error{overloaded, overloaded}, OverloadedError, 2},
+		{"Unreachable", codegen.MethodOptions{Retries: 2}, []error{unreachable, unreachable}, nil, 3},
+		{"TooManyFailures", codegen.MethodOptions{Retries: 2}, []error{unreachable, unreachable, unreachable}, call.Unreachable, 3},
+		{"NotIdempotent", codegen.MethodOptions{Retries: 2}, []error{broken}, call.CommunicationError, 1},
+		{"Idempotent", codegen.MethodOptions{Retries: 2, Idempotent: true}, []error{broken, unreachable}, nil, 3},
+		{"ApplicationError", codegen.MethodOptions{Retries: 2, Idempotent: true}, []error{appErr}, appErr, 1},
+		{"CircuitOpen", codegen.MethodOptions{Retries: 2, Idempotent: true}, []error{open}, CircuitOpenError, 1},
+		{"Overloaded", codegen.MethodOptions{Retries: 2}, []error{overloaded}, nil, 2},
+		{"TooOverloaded", codegen.MethodOptions{Retries: 1}, []error{overloaded, overloaded}, OverloadedError, 2},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			client := &failingClient{errs: test.errs}
+			test.opts.Backoff = time.Millisecond
+			stub := stub{
+				client:  client,
+				methods: []call.MethodKey{call.MakeMethodKey("", "test")},
+				options: []codegen.MethodOptions{test.opts},
+			}
+			_, err := stub.Run(context.Background(), 0, nil, 0)
+			if test.wantErr == nil && err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("Run: got error %v, want %v", err, test.wantErr)
+			}
+			if client.calls != test.wantCalls {
+				t.Fatalf("Run: got %d calls, want %d", client.calls, test.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryOverloadedOnAnotherReplica(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// Serve two replicas of a method, one of which sheds every call.
+	serve := func(handler call.Handler) string {
+		t.Helper()
+		handlers := &call.HandlerMap{}
+		handlers.Set("test", "Get", handler)
+		lis, err := net.Listen("tcp", "localhost:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		go call.Serve(ctx, lis, handlers, call.ServerOptions{Logger: logging.NewTestLogger(t)})
+		return lis.Addr().String()
+	}
+	var shed atomic.Int32
+	overloaded := serve(func(context.Context, []byte) ([]byte, error) {
+		shed.Add(1)
+		return nil, fmt.Errorf("%w: too many concurrent calls", call.Overloaded)
+	})
+	healthy := serve(func(context.Context, []byte) ([]byte, error) {
+		return []byte("ok"), nil
+	})
+
+	// Send the first attempt to the overloaded replica, and the other
+	// attempts to the healthy replica.
+	var picks atomic.Int32
+	balancer := call.BalancerFunc(func(endpoints []call.Endpoint, _ call.CallOptions) (call.Endpoint, error) {
+		want := healthy
+		if picks.Add(1) == 1 {
+			want = overloaded
+		}
+		for _, e := range endpoints {
+			if e.Address() == "tcp://"+want {
+				return e, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: no endpoint %s", call.Unreachable, want)
+	})
+	resolver := call.NewConstantResolver(call.TCP(overloaded), call.TCP(healthy))
+	client, err := call.Connect(ctx, resolver, call.ClientOptions{Logger: logging.NewTestLogger(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+
+	// The method has no retries, but the shed call is retried anyway.
+	stub := stub{
+		client:   client,
+		methods:  []call.MethodKey{call.MakeMethodKey("test", "Get")},
+		balancer: balancer,
+	}
+	result, err := stub.Run(ctx, 0, nil, 0)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got, want := string(result), "ok"; got != want {
+		t.Fatalf("Run: got %q, want %q", got, want)
+	}
+	if got := shed.Load(); got != 1 {
+		t.Fatalf("shed calls: got %d, want 1", got)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	fn := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	stub := stub{
+		client:  &localClient{fn: fn},
+		methods: []call.MethodKey{call.MakeMethodKey("", "test")},
+		options: []codegen.MethodOptions{{Timeout: 10 * time.Millisecond}},
+	}
+	_, err := stub.Run(context.Background(), 0, nil, 0)
+	if !strings.Contains(fmt.Sprint(err), context.DeadlineExceeded.Error()) {
+		t.Fatalf("Run: got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+// slowClient is a call.Connection whose first slow calls block until they
+// are canceled, and whose other calls succeed.
+type slowClient struct {
+	slow     int
+	calls    int
+	timeouts []time.Duration // timeout of every call
+}
+
+var _ call.Connection = &slowClient{}
+
+func (c *slowClient) Call(ctx context.Context, _ call.MethodKey, _ []byte, _ call.CallOptions) ([]byte, error) {
+	c.calls++
+	deadline, _ := ctx.Deadline()
+	c.timeouts = append(c.timeouts, time.Until(deadline))
+	if c.calls > c.slow {
+		return nil, nil
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (c *slowClient) Stream(context.Context, call.MethodKey, []byte, call.CallOptions) (call.ClientStream, error) {
+	return nil, fmt.Errorf("streaming calls not supported")
+}
+
+func (c *slowClient) Close() {}
+
+func TestTimeoutPerAttempt(t *testing.T) {
+	const timeout = 50 * time.Millisecond
+	for _, test := range []struct {
+		name      string
+		opts      codegen.MethodOptions
+		wantErr   error // nil if the call should succeed
+		wantCalls int
+	}{
+		// Every attempt of an idempotent method gets a fresh timeout, so the
+		// call succeeds after two attempts time out.
+		{"Idempotent", codegen.MethodOptions{Retries: 2, Idempotent: true}, nil, 3},
+		// An attempt that timed out may have executed, so it isn't retried
+		// if the method isn't idempotent.
+		{"NotIdempotent", codegen.MethodOptions{Retries: 2}, context.DeadlineExceeded, 1},
+		{"TooManyTimeouts", codegen.MethodOptions{Retries: 1, Idempotent: true}, context.DeadlineExceeded, 2},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			client := &slowClient{slow: 2}
+			test.opts.Timeout = timeout
+			test.opts.Backoff = time.Millisecond
+			stub := stub{
+				client:  client,
+				methods: []call.MethodKey{call.MakeMethodKey("", "test")},
+				options: []codegen.MethodOptions{test.opts},
+			}
+			_, err := stub.Run(context.Background(), 0, nil, 0)
+			if test.wantErr == nil && err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("Run: got error %v, want %v", err, test.wantErr)
+			}
+			if client.calls != test.wantCalls {
+				t.Fatalf("Run: got %d calls, want %d", client.calls, test.wantCalls)
+			}
+			for i, got := range client.timeouts {
+				// Every attempt gets the whole timeout, minus a little time
+				// spent before the attempt starts.
+				if got <= timeout/2 || got > timeout {
+					t.Errorf("attempt %d: got timeout %v, want about %v", i, got, timeout)
+				}
+			}
+		})
+	}
+}
+
+// hedgingClient is a call.Connection that records the hedge delay of every
+// call, and reports every call with a positive hedge delay as a winning hedge.
+type hedgingClient struct {
+	delays []time.Duration
+}
+
+var _ call.Connection = &hedgingClient{}
+
+func (c *hedgingClient) Call(_ context.Context, _ call.MethodKey, _ []byte, opts call.CallOptions) ([]byte, error) {
+	c.delays = append(c.delays, opts.HedgeDelay)
+	if opts.HedgeDelay > 0 && opts.Stats != nil {
+		opts.Stats.Hedged = true
+		opts.Stats.HedgeWon = true
+	}
+	return nil, nil
+}
+
+func (c *hedgingClient) Stream(context.Context, call.MethodKey, []byte, call.CallOptions) (call.ClientStream, error) {
+	return nil, fmt.Errorf("streaming calls not supported")
+}
+
+func (c *hedgingClient) Close() {}
+
+func TestHedgeDelay(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		opts codegen.MethodOptions
+		want time.Duration
+	}{
+		{"NotHedged", codegen.MethodOptions{Idempotent: true}, 0},
+		{"Hedged", codegen.MethodOptions{Idempotent: true, Hedge: 5 * time.Millisecond}, 5 * time.Millisecond},
+		{"NotIdempotent", codegen.MethodOptions{Hedge: 5 * time.Millisecond}, 0},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			client := &hedgingClient{}
+			stub := stub{
+				client:  client,
+				methods: []call.MethodKey{call.MakeMethodKey("", "test")},
+				options: []codegen.MethodOptions{test.opts},
+				hedges: []*codegen.HedgeMethodMetrics{
+					codegen.HedgeMethodMetricsFor(codegen.MethodLabels{Method: "test"}),
+				},
+			}
+			if _, err := stub.Run(context.Background(), 0, nil, 0); err != nil {
+				t.Fatal(err)
+			}
+			if got := client.delays[0]; got != test.want {
+				t.Fatalf("hedge delay: got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestHedgeP95(t *testing.T) {
+	client := &hedgingClient{}
+	stub := stub{
+		client:    client,
+		methods:   []call.MethodKey{call.MakeMethodKey("", "test")},
+		options:   []codegen.MethodOptions{{Idempotent: true, HedgeP95: true}},
+		latencies: []*latencyTracker{{}},
+	}
+
+	// Calls are not hedged until enough latencies have been recorded.
+	for i := 0; i <= latencyInterval; i++ {
+		if _, err := stub.Run(context.Background(), 0, nil, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, delay := range client.delays[:latencyInterval] {
+		if delay != 0 {
+			t.Fatalf("call %d: got hedge delay %v, want 0", i, delay)
+		}
+	}
+	if delay := client.delays[latencyInterval]; delay <= 0 {
+		t.Fatalf("call %d: got hedge delay %v, want positive", latencyInterval, delay)
+	}
+}
+
+func TestLatencyTracker(t *testing.T) {
+	var l latencyTracker
+	for i := 1; i <= latencyInterval; i++ {
+		if got := l.percentile95(); got != 0 {
+			t.Fatalf("percentile95 after %d latencies: got %v, want 0", i-1, got)
+		}
+		l.record(time.Duration(i) * time.Millisecond)
+	}
+	if got, want := l.percentile95(), 96*time.Millisecond; got != want {
+		t.Fatalf("percentile95: got %v, want %v", got, want)
+	}
+
+	// Only the most recent latencies are retained.
+	for i := 0; i < latencyWindow; i++ {
+		l.record(time.Millisecond)
+	}
+	if got, want := l.percentile95(), time.Millisecond; got != want {
+		t.Fatalf("percentile95: got %v, want %v", got, want)
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/ServiceWeaver/weaver/runtime"
 	"github.com/ServiceWeaver/weaver/runtime/codegen"
 	"github.com/ServiceWeaver/weaver/runtime/logging"
+	"github.com/ServiceWeaver/weaver/runtime/mtls"
 	"github.com/ServiceWeaver/weaver/runtime/protos"
 	"github.com/ServiceWeaver/weaver/runtime/retry"
 	"go.opentelemetry.io/otel"
@@ -46,6 +47,10 @@ import (
 // readyMethodKey holds the key for a method used to check if a backend is ready.
 var readyMethodKey = call.MakeMethodKey("", "ready")
 
+// defaultShutdownGracePeriod is the amount of time a weavelet has to shut down
+// if the envelope doesn't specify a grace period.
+const defaultShutdownGracePeriod = 10 * time.Second
+
 // A weavelet runs and manages components. As the name suggests, a weavelet is
 // analogous to a kubelet.
 type weavelet struct {
@@ -69,6 +74,15 @@ type weavelet struct {
 	// avoid the redundancy.
 	clientsLock sync.Mutex
 	tcpClients  map[string]*client // indexed by component
+
+	serveCancel context.CancelFunc // stops serving method calls
+	serveDone   chan struct{}      // closed when we stop serving method calls
+
+	mu      sync.Mutex   // guards started
+	started []*component // components started by this weavelet, in order
+
+	shutdownOnce sync.Once
+	shutdownErr  error
 }
 
 type transport struct {
@@ -94,6 +108,8 @@ func newWeavelet(ctx context.Context, componentInfos []*codegen.Registration) (*
 		componentsByName: byName,
 		componentsByType: byType,
 		tcpClients:       map[string]*client{},
+		serveCancel:      func() {},
+		serveDone:        make(chan struct{}),
 	}
 
 	// TODO(mwhittaker): getEnv starts the WeaveletConn handler which calls
@@ -111,9 +127,14 @@ func newWeavelet(ctx context.Context, componentInfos []*codegen.Registration) (*
 	w.info = info
 
 	for _, info := range componentInfos {
+		methods := make([]string, info.Iface.NumMethod())
+		for i := range methods {
+			methods[i] = info.Iface.Method(i).Name
+		}
 		c := &component{
-			wlet: w,
-			info: info,
+			wlet:  w,
+			info:  info,
+			calls: newCallCounter(methods),
 			// May be remote, so start with no-op logger. May set real logger later.
 			// Discard all log entries.
 			logger: slog.New(slog.HandlerOptions{Level: slog.LevelError + 1}.NewTextHandler(os.Stdout)),
@@ -158,11 +179,25 @@ func newWeavelet(ctx context.Context, componentInfos []*codegen.Registration) (*
 			Tracer:                tracer,
 			InlineHandlerDuration: 20 * time.Microsecond,
 			WriteFlattenLimit:     4 << 10,
+			DrainTimeout:          defaultShutdownGracePeriod,
 		},
 	}
+	if info.Mtls != nil {
+		clientTLS, err := mtls.ClientConfig(info.DeploymentId, info.Mtls)
+		if err != nil {
+			return nil, fmt.Errorf("mTLS: %w", err)
+		}
+		serverTLS, err := mtls.ServerConfig(info.DeploymentId, info.Mtls)
+		if err != nil {
+			return nil, fmt.Errorf("mTLS: %w", err)
+		}
+		w.transport.clientOpts.TLSConfig = clientTLS
+		w.transport.serverOpts.TLSConfig = serverTLS
+	}
 	w.tracer = tracer
 	main.tracer = tracer
 	w.root = main
+	registerHealth(w)
 
 	return w, nil
 }
@@ -170,7 +205,8 @@ func newWeavelet(ctx context.Context, componentInfos []*codegen.Registration) (*
 // start starts a weavelet, executing the logic to start and manage components.
 // If Start fails, it returns a non-nil error.
 // Otherwise, if this process hosts "main", start returns the main component.
-// Otherwise, Start never returns.
+// Otherwise, start returns a nil Instance once the envelope has shut down the
+// weavelet (see Shutdown).
 func (w *weavelet) start() (Instance, error) {
 	// Launch status server for single process deployments.
 	if single, ok := w.env.(*singleprocessEnv); ok {
@@ -191,7 +227,9 @@ func (w *weavelet) start() (Instance, error) {
 	// start serving them, which might require some additional locking.
 	handlers := &call.HandlerMap{}
 	for _, c := range w.componentsByName {
-		w.addHandlers(handlers, c)
+		if err := w.addHandlers(handlers, c); err != nil {
+			return nil, err
+		}
 	}
 	// Add a dummy "ready" handler. Clients will repeatedly call this RPC until
 	// it responds successfully, ensuring the server is ready.
@@ -222,7 +260,9 @@ func (w *weavelet) start() (Instance, error) {
 	// For a singleprocess deployment, no server is launched because all
 	// method invocations are process-local and executed as regular go function
 	// calls.
-	if remote, ok := w.env.(*remoteEnv); ok {
+	var remote *remoteEnv
+	if r, ok := w.env.(*remoteEnv); ok {
+		remote = r
 		startWork(w.ctx, "serve weavelet conn", remote.conn.Serve)
 
 		lis := remote.conn.Listener()
@@ -235,9 +275,28 @@ func (w *weavelet) start() (Instance, error) {
 			}
 		}
 
-		startWork(w.ctx, "handle calls", func() error {
-			return call.Serve(w.ctx, lis, handlers, w.transport.serverOpts)
-		})
+		// Method calls are served until the weavelet is shut down, at which
+		// point in-progress calls are drained. Weavelets on the same machine
+		// may also make method calls over a Unix socket.
+		var serveCtx context.Context
+		serveCtx, w.serveCancel = context.WithCancel(w.ctx)
+		listeners := []net.Listener{lis}
+		if unixLis := remote.conn.UnixListener(); unixLis != nil {
+			listeners = append(listeners, unixLis)
+		}
+		var serving sync.WaitGroup
+		for _, l := range listeners {
+			l := l
+			serving.Add(1)
+			startWork(serveCtx, "handle calls", func() error {
+				defer serving.Done()
+				return call.Serve(serveCtx, l, handlers, w.transport.serverOpts)
+			})
+		}
+		go func() {
+			serving.Wait()
+			close(w.serveDone)
+		}()
 	}
 
 	w.logRolodexCard()
@@ -263,9 +322,16 @@ func (w *weavelet) start() (Instance, error) {
 		return w.root.impl, nil
 	}
 
-	// Not the main-process. Run forever.
-	// TODO(mwhittaker): Catch and return errors.
-	select {}
+	// Not the main-process. Serve until the envelope shuts us down.
+	if remote == nil {
+		return nil, fmt.Errorf("weavelet without main must be run by an envelope")
+	}
+	select {
+	case <-remote.conn.Stopped():
+		return nil, nil
+	case <-w.ctx.Done():
+		return nil, w.ctx.Err()
+	}
 }
 
 // logRolodexCard pretty prints a card that includes basic information about
@@ -333,6 +399,9 @@ func (w *weavelet) getInstance(c *component, requester string) (interface{}, err
 		if err != nil {
 			return nil, err
 		}
+		if stub := w.localStub(c, impl, requester); stub != nil {
+			return c.info.ClientStubFn(stub, requester), nil
+		}
 		return c.info.LocalStubFn(impl.impl, impl.component.tracer), nil
 	}
 
@@ -340,7 +409,67 @@ func (w *weavelet) getInstance(c *component, requester string) (interface{}, err
 	if err != nil {
 		return nil, err
 	}
-	return c.info.ClientStubFn(stub.stub, requester), nil
+	// Every requester gets its own copy of the stub, which identifies the
+	// requester to the remote component.
+	caller := *stub.stub
+	caller.caller = requester
+	if caller.compress {
+		caller.compressed = make([]*codegen.CompressedMethodMetrics, len(caller.calls))
+		for i, info := range caller.calls {
+			caller.compressed[i] = codegen.CompressedMethodMetricsFor(codegen.MethodLabels{
+				Caller:    requester,
+				Component: info.Component,
+				Method:    info.Method,
+			})
+		}
+	}
+	if caller.hedged {
+		caller.hedges = make([]*codegen.HedgeMethodMetrics, len(caller.calls))
+		for i, info := range caller.calls {
+			if opts := caller.options[i]; opts.Hedge == 0 && !opts.HedgeP95 {
+				continue
+			}
+			caller.hedges[i] = codegen.HedgeMethodMetricsFor(codegen.MethodLabels{
+				Caller:    requester,
+				Component: info.Component,
+				Method:    info.Method,
+			})
+		}
+	}
+	return c.info.ClientStubFn(&caller, requester), nil
+}
+
+// localStub returns a stub that makes calls to the provided local component
+// through its interceptors, or nil if the component has no interceptors.
+// requester is the name of the requesting component.
+func (w *weavelet) localStub(c *component, impl *componentImpl, requester string) *stub {
+	client, server := interceptorsFor(c.info.Iface)
+	if client == nil && server == nil {
+		return nil
+	}
+	n := c.info.Iface.NumMethod()
+	methods := make([]call.MethodKey, n)
+	calls := make([]RemoteCallInfo, n)
+	byKey := make(map[call.MethodKey]RemoteCallInfo, n)
+	for i := 0; i < n; i++ {
+		mname := c.info.Iface.Method(i).Name
+		methods[i] = call.MakeMethodKey(c.info.Name, mname)
+		calls[i] = RemoteCallInfo{Component: c.info.Name, Method: mname}
+		byKey[methods[i]] = calls[i]
+	}
+	return &stub{
+		client: &localConnection{
+			// Local calls don't count towards the load of the component.
+			server:      c.info.ServerStubFn(impl.impl, func(uint64, float64) {}),
+			interceptor: server,
+			calls:       byKey,
+		},
+		methods:     methods,
+		tracer:      w.tracer,
+		caller:      requester,
+		interceptor: client,
+		calls:       calls,
+	}
 }
 
 // getListener returns a network listener with the given name.
@@ -379,12 +508,44 @@ func (w *weavelet) getListener(name string, opts ListenerOptions) (*Listener, er
 
 // addHandlers registers a component's methods as handlers in stub.HandlerMap.
 // Specifically, for every method m in the component, we register a function f
-// that (1) creates the local component if it hasn't been created yet and (2)
-// calls m.
-func (w *weavelet) addHandlers(handlers *call.HandlerMap, c *component) {
+// (and a streaming function, for streaming methods) that (1) creates the local
+// component if it hasn't been created yet and (2) calls m.
+func (w *weavelet) addHandlers(handlers *call.HandlerMap, c *component) error {
+	_, interceptor := interceptorsFor(c.info.Iface)
+	allowed, err := codegen.AllowedCallers(c.info, w.info.Sections)
+	if err != nil {
+		return fmt.Errorf("component %q: %w", c.info.Name, err)
+	}
+	// Callers need to be checked if there is a policy, or if callers can be
+	// authenticated with mTLS.
+	authorize := allowed != nil || w.info.Mtls != nil
+
+	// Limit the number of concurrent calls if configured to do so.
+	callOpts, err := codegen.CallOptionsFor(c.info, w.info.Sections)
+	if err != nil {
+		return fmt.Errorf("component %q: %w", c.info.Name, err)
+	}
+	var componentLimiter *call.Limiter
+	if callOpts.ComponentLimit != nil {
+		componentLimiter = call.NewLimiter(call.LimiterOptions(*callOpts.ComponentLimit))
+	}
+
 	for i, n := 0, c.info.Iface.NumMethod(); i < n; i++ {
 		mname := c.info.Iface.Method(i).Name
+		var limiters []*call.Limiter
+		if limit, ok := callOpts.MethodLimits[mname]; ok {
+			limiters = append(limiters, call.NewLimiter(call.LimiterOptions(limit)))
+		}
+		if componentLimiter != nil {
+			limiters = append(limiters, componentLimiter)
+		}
+		if len(limiters) > 0 {
+			handlers.SetLimiters(c.info.Name, mname, limiters...)
+		}
+
 		handler := func(ctx context.Context, args []byte) (res []byte, err error) {
+			c.calls.add(mname)
+
 			// This handler is supposed to invoke the method named mname on the
 			// local component. However, it is possible that the component has not
 			// yet been started (e.g., the start command was issued but hasn't
@@ -396,9 +557,71 @@ func (w *weavelet) addHandlers(handlers *call.HandlerMap, c *component) {
 				return nil, err
 			}
 			fn := impl.serverStub.GetStubFn(mname)
+			if fn == nil {
+				return nil, fmt.Errorf("method %s.%s can only be called as a streaming method", c.info.Name, mname)
+			}
 			return fn(ctx, args)
 		}
+		if interceptor != nil {
+			handler = interceptHandler(interceptor, RemoteCallInfo{Component: c.info.Name, Method: mname}, handler)
+		}
+		if authorize {
+			handler = authorizeHandler(RemoteCallInfo{Component: c.info.Name, Method: mname}, allowed[mname], handler)
+		}
 		handlers.Set(c.info.Name, mname, handler)
+
+		// Every method is also registered as a streaming handler. The server
+		// stub reports whether the method is actually a streaming method.
+		streamHandler := func(ctx context.Context, args []byte, stream call.ServerStream) ([]byte, error) {
+			c.calls.add(mname)
+			impl, err := w.getImpl(c)
+			if err != nil {
+				return nil, err
+			}
+			var fn func(context.Context, []byte, codegen.ServerStream) ([]byte, error)
+			if server, ok := impl.serverStub.(codegen.StreamServer); ok {
+				fn = server.GetStreamStubFn(mname)
+			}
+			if fn == nil {
+				return nil, fmt.Errorf("method %s.%s is not a streaming method", c.info.Name, mname)
+			}
+			return fn(ctx, args, stream)
+		}
+		if interceptor != nil {
+			streamHandler = interceptStreamHandler(interceptor, RemoteCallInfo{Component: c.info.Name, Method: mname}, streamHandler)
+		}
+		if authorize {
+			streamHandler = authorizeStreamHandler(RemoteCallInfo{Component: c.info.Name, Method: mname}, allowed[mname], streamHandler)
+		}
+		handlers.SetStream(c.info.Name, mname, streamHandler)
+	}
+	return nil
+}
+
+// interceptHandler returns a handler that runs handler through interceptor.
+func interceptHandler(interceptor RemoteServerInterceptor, info RemoteCallInfo, handler call.Handler) call.Handler {
+	return func(ctx context.Context, args []byte) ([]byte, error) {
+		var result []byte
+		err := interceptor(ctx, info, func(ctx context.Context) error {
+			var err error
+			result, err = handler(ctx, args)
+			return err
+		})
+		return result, err
+	}
+}
+
+// interceptStreamHandler returns a stream handler that runs handler through
+// interceptor.
+func interceptStreamHandler(interceptor RemoteServerInterceptor, info RemoteCallInfo, handler call.StreamHandler) call.StreamHandler {
+	return func(ctx context.Context, args []byte, stream call.ServerStream) ([]byte, error) {
+		var result []byte
+		err := interceptor(ctx, info, func(ctx context.Context) error {
+			var err error
+			result, err = handler(ctx, args, stream)
+			return err
+		})
+		return result, err
 	}
 }
 
@@ -406,9 +629,13 @@ func (w *weavelet) addHandlers(handlers *call.HandlerMap, c *component) {
 func (w *weavelet) GetLoad(*protos.GetLoadRequest) (*protos.GetLoadReply, error) {
 	report := &protos.LoadReport{
 		Loads: map[string]*protos.LoadReport_ComponentLoad{},
+		Calls: map[string]*protos.LoadReport_MethodCalls{},
 	}
 
 	for _, c := range w.componentsByName {
+		if x := c.calls.report(); x != nil {
+			report.Calls[c.info.Name] = x
+		}
 		if c.load == nil {
 			continue
 		}
@@ -472,15 +699,13 @@ func (w *weavelet) UpdateRoutingInfo(req *protos.UpdateRoutingInfoRequest) (repl
 	}()
 
 	// Update load collector.
-	for _, c := range w.componentsByName {
-		if c.load != nil && req.RoutingInfo.Assignment != nil {
-			c.load.updateAssignment(req.RoutingInfo.Assignment)
-		}
+	if c, ok := w.componentsByName[req.RoutingInfo.Component]; ok && c.load != nil && req.RoutingInfo.Assignment != nil {
+		c.load.updateAssignment(req.RoutingInfo.Assignment)
 	}
 
 	// Update resolver and balancer.
 	client := w.getTCPClient(req.RoutingInfo.Component)
-	endpoints, err := parseEndpoints(req.RoutingInfo.Replicas)
+	endpoints, err := parseEndpoints(req.RoutingInfo.Replicas, req.RoutingInfo.UnixReplicas)
 	if err != nil {
 		return nil, err
 	}
@@ -496,6 +721,64 @@ func (w *weavelet) UpdateRoutingInfo(req *protos.UpdateRoutingInfoRequest) (repl
 	return &protos.UpdateRoutingInfoReply{}, nil
 }
 
+// Shutdown implements the conn.WeaveletHandler interface.
+func (w *weavelet) Shutdown(req *protos.ShutdownRequest) (*protos.ShutdownReply, error) {
+	gracePeriod := time.Duration(req.GracePeriodNs)
+	if gracePeriod <= 0 {
+		gracePeriod = defaultShutdownGracePeriod
+	}
+	ctx, cancel := context.WithTimeout(w.ctx, gracePeriod)
+	defer cancel()
+	return &protos.ShutdownReply{}, w.shutdown(ctx)
+}
+
+// shutdown gracefully shuts down the weavelet. It stops accepting new method
+// calls, waits for in-progress method calls to finish, and then calls the
+// Shutdown method, if any, of every started component in the reverse order
+// the components were started. Because a component's dependencies are
+// constructed when the component calls weaver.Get, typically in Init, the
+// reverse start order shuts down a component before the components it depends
+// on.
+//
+// shutdown is idempotent; only the first call has an effect.
+func (w *weavelet) shutdown(ctx context.Context) error {
+	w.shutdownOnce.Do(func() {
+		logger := w.env.SystemLogger()
+		logger.Debug("Shutting down weavelet...")
+
+		// Stop serving method calls, draining in-progress calls.
+		w.serveCancel()
+		if _, ok := w.env.(*remoteEnv); ok {
+			select {
+			case <-w.serveDone:
+			case <-ctx.Done():
+				logger.Error("Draining method calls failed", "err", ctx.Err())
+			}
+		}
+
+		// Shut down components in reverse start order.
+		w.mu.Lock()
+		started := slices.Clone(w.started)
+		w.mu.Unlock()
+		var errs []error
+		for i := len(started) - 1; i >= 0; i-- {
+			c := started[i]
+			s, ok := c.impl.impl.(interface{ Shutdown(context.Context) error })
+			if !ok {
+				continue
+			}
+			logger.Debug("Shutting down component", "component", c.info.Name)
+			if err := s.Shutdown(ctx); err != nil {
+				logger.Error("Shutting down component failed", "err", err, "component", c.info.Name)
+				errs = append(errs, fmt.Errorf("component %q shutdown failed: %w", c.info.Name, err))
+			}
+		}
+		w.shutdownErr = errors.Join(errs...)
+		logger.Debug("Shutting down weavelet succeeded")
+	})
+	return w.shutdownErr
+}
+
 // getComponent returns the component with the given name.
 func (w *weavelet) getComponent(name string) (*component, error) {
 	// Note that we don't need to lock d.components because, while the components
@@ -546,6 +829,13 @@ func (w *weavelet) getImpl(c *component) (*componentImpl, error) {
 		}
 		w.env.SystemLogger().Debug("Constructing component succeeded", "component", c.info.Name)
 
+		// Record the component as started. Note that a component's
+		// dependencies are usually started (and recorded) while the component
+		// itself is being constructed, so they appear earlier in w.started.
+		w.mu.Lock()
+		w.started = append(w.started, c)
+		w.mu.Unlock()
+
 		c.impl.serverStub = c.info.ServerStubFn(c.impl.impl, func(key uint64, v float64) {
 			if c.info.Routed {
 				if err := c.load.add(key, v); err != nil {
@@ -601,33 +891,99 @@ func (w *weavelet) repeatedly(errMsg string, f func() error) error {
 // getStub returns a component's componentStub, initializing it if necessary.
 func (w *weavelet) getStub(c *component) (*componentStub, error) {
 	init := func(c *component) error {
+		callOpts, err := codegen.CallOptionsFor(c.info, w.info.Sections)
+		if err != nil {
+			return fmt.Errorf("component %q: %w", c.info.Name, err)
+		}
+
+		// Compress calls to the component if configured to do so.
+		opts := w.transport.clientOpts
+		opts.Compression, err = call.ParseCompression(callOpts.Compression.Algorithm)
+		if err != nil {
+			return fmt.Errorf("component %q: %w", c.info.Name, err)
+		}
+		opts.CompressionThreshold = callOpts.Compression.Threshold
+
+		// Balance calls to the component with the configured balancer.
+		name := callOpts.Balancer
+		lb, err := call.NewBalancer(name)
+		if err != nil {
+			return fmt.Errorf("component %q: %w", c.info.Name, err)
+		}
+		if name == "" {
+			name = "round_robin"
+		}
+
+		// Spread calls to every replica across the configured number of
+		// connections.
+		opts.ConnectionsPerEndpoint = callOpts.ConnectionsPerReplica
+
+		// Eject unhealthy replicas of the component if configured to do so.
+		opts.Outliers = call.OutlierOptions(callOpts.Outliers)
+		opts.Logger = opts.Logger.With("component", c.info.Name)
+		opts.OnEject = func(e call.Ejection) {
+			codegen.OutlierEjections.Get(codegen.EjectionLabels{
+				Component: c.info.Name,
+				Endpoint:  e.Endpoint.Address(),
+				Reason:    e.Reason,
+			}).Add(1)
+		}
+
 		// Initialize the client.
 		w.env.SystemLogger().Debug("Getting TCP client to component...", "component", c.info.Name)
 		client := w.getTCPClient(c.info.Name)
-		if err := client.init(w.ctx, w.transport.clientOpts); err != nil {
+		client.balancer.setBalancer(c.info.Name, name, lb)
+		opts.Balancer = client.balancer
+		if err := client.init(w.ctx, opts); err != nil {
 			w.env.SystemLogger().Error("Getting TCP client to component failed", "err", err, "component", c.info.Name)
 			return err
 		}
 		w.env.SystemLogger().Debug("Getting TCP client to component succeeded", "component", c.info.Name)
 
-		// Construct the keys for the methods.
+		// Construct the keys and call info for the methods.
 		n := c.info.Iface.NumMethod()
 		methods := make([]call.MethodKey, n)
+		calls := make([]RemoteCallInfo, n)
 		for i := 0; i < n; i++ {
 			mname := c.info.Iface.Method(i).Name
 			methods[i] = call.MakeMethodKey(c.info.Name, mname)
+			calls[i] = RemoteCallInfo{Component: c.info.Name, Method: mname}
+		}
+
+		// Construct the options for the methods.
+		options := make([]codegen.MethodOptions, n)
+		var hedged bool
+		var latencies []*latencyTracker
+		for i := 0; i < n; i++ {
+			options[i] = callOpts.Methods[c.info.Iface.Method(i).Name]
+			if options[i].Hedge > 0 || options[i].HedgeP95 {
+				hedged = true
+			}
+			if options[i].HedgeP95 {
+				if latencies == nil {
+					latencies = make([]*latencyTracker, n)
+				}
+				latencies[i] = &latencyTracker{}
+			}
 		}
 
 		var balancer call.Balancer
 		if c.info.Routed {
 			balancer = client.balancer
 		}
+		interceptor, _ := interceptorsFor(c.info.Iface)
 		c.stub = &componentStub{
 			stub: &stub{
-				client:   client.client,
-				methods:  methods,
-				balancer: balancer,
-				tracer:   w.tracer,
+				client:      client.client,
+				methods:     methods,
+				options:     options,
+				balancer:    balancer,
+				tracer:      w.tracer,
+				interceptor: interceptor,
+				calls:       calls,
+				compress:    opts.Compression != call.NoCompression,
+				hedged:      hedged,
+				latencies:   latencies,
 			},
 		}
 		return nil
@@ -64,6 +64,47 @@ import (
 // example.
 var RemoteCallError = errors.New("Service Weaver remote call error")
 
+// PermissionDeniedError indicates that a remote component method call was
+// rejected because the [authorization] section of the config file doesn't
+// allow the calling component to call the method. The error returned by such
+// a call embeds both a RemoteCallError and a PermissionDeniedError:
+//
+//	err := foo.Foo(ctx)
+//	if errors.Is(err, weaver.PermissionDeniedError) {
+//	    // The caller is not allowed to call foo.Foo.
+//	}
+//
+// A call that is rejected is never executed.
+var PermissionDeniedError = errors.New("Service Weaver permission denied")
+
+// CircuitOpenError indicates that a remote component method call failed
+// immediately because the circuit breaker of the component is open, i.e.
+// because every replica of the component recently failed. Circuit breakers are
+// configured in the [calls] section of the config file. The error returned by
+// such a call embeds both a RemoteCallError and a CircuitOpenError:
+//
+//	err := foo.Foo(ctx)
+//	if errors.Is(err, weaver.CircuitOpenError) {
+//	    // Every replica of foo.Foo's component is unhealthy.
+//	}
+//
+// A call that fails with a CircuitOpenError is never executed.
+var CircuitOpenError = errors.New("Service Weaver circuit breaker open")
+
+// OverloadedError indicates that a remote component method call was rejected
+// because the replica of the component it was sent to had too many concurrent
+// calls. Concurrency limits are configured in the [calls] section of the
+// config file. The error returned by such a call embeds both a RemoteCallError
+// and an OverloadedError:
+//
+//	err := foo.Foo(ctx)
+//	if errors.Is(err, weaver.OverloadedError) {
+//	    // Back off before calling foo.Foo again.
+//	}
+//
+// A call that is rejected is never executed, so it can safely be retried.
+var OverloadedError = errors.New("Service Weaver component overloaded")
+
 // mainIface is an empty interface "implemented" by the user main function,
 // allowing us to treat the user main as a regular Service Weaver component in the
 // implementation.
@@ -85,10 +126,9 @@ func init() {
 		ServerStubFn: func(any, func(uint64, float64)) codegen.Server { return nil },
 	})
 
-	// Add a trivial /healthz handler to the default mux.
-	http.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
-		w.Write([]byte("ok")) //nolint:errcheck // response write error
-	})
+	// Add a /healthz handler that reports the health of the components
+	// hosted in this process to the default mux.
+	http.HandleFunc("/healthz", serveHealthz)
 }
 
 // Init initializes the execution of a process involved in a Service Weaver application.
@@ -100,12 +140,29 @@ func init() {
 //
 // If this process is not hosting the "main" component, Init will never return and will
 // just serve requests directed at the components being hosted inside the process.
+// When the deployer asks the process to shut down, Init stops serving requests,
+// waits for in-progress requests to finish, calls the Shutdown method of every
+// component that has one, and exits the process.
+//
+// A component implementation can optionally provide a Shutdown method with the
+// following signature:
+//
+//	func (f *foo) Shutdown(context.Context) error
+//
+// Shutdown methods are called in the reverse order the components were
+// started, so a component is shut down before the components it got using
+// [weaver.Get] in its Init method. The provided context is canceled when the
+// deployer's shutdown grace period expires.
 func Init(ctx context.Context) Instance {
 	root, err := initInternal(ctx)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, fmt.Errorf("error initializing Service Weaver: %w", err))
 		os.Exit(1)
 	}
+	if root == nil {
+		// This process isn't hosting "main" and was shut down gracefully.
+		os.Exit(0)
+	}
 	return root
 }
 
@@ -21,6 +21,7 @@ import (
 	"os"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/ServiceWeaver/weaver"
 	"github.com/ServiceWeaver/weaver/internal/envelope/conn"
@@ -41,6 +42,10 @@ import (
 // TODO(mwhittaker): Include this in the Options struct?
 const DefaultReplication = 2
 
+// shutdownGracePeriod is the amount of time weavelets are given to shut down
+// gracefully when a test ends.
+const shutdownGracePeriod = 5 * time.Second
+
 // deployer is the weavertest multiprocess deployer. Every multiprocess
 // weavertest runs its own deployer. The main component is run in the same
 // process as the deployer, which is the same process as the unit test. All
@@ -236,6 +241,7 @@ func (d *deployer) stop(err error) {
 
 // cleanup cleans up all of the running envelopes' state.
 func (d *deployer) cleanup() error {
+	d.shutdown()
 	d.ctxCancel()
 	d.running.Wait() //nolint:errcheck // supplanted by b.err
 	d.mu.Lock()
@@ -243,6 +249,40 @@ func (d *deployer) cleanup() error {
 	return d.err
 }
 
+// shutdown gracefully shuts down all weavelets. The main weavelet is shut
+// down first, since it is the root of the component graph, followed by the
+// weavelets in all other co-location groups.
+func (d *deployer) shutdown() {
+	d.mu.Lock()
+	var main []connection
+	var others []connection
+	for name, g := range d.groups {
+		if name == "main" {
+			main = append(main, g.conns...)
+		} else {
+			others = append(others, g.conns...)
+		}
+	}
+	d.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(d.ctx, shutdownGracePeriod)
+	defer cancel()
+	for _, conns := range [][]connection{main, others} {
+		var wait sync.WaitGroup
+		for _, c := range conns {
+			c := c
+			wait.Add(1)
+			go func() {
+				defer wait.Done()
+				if err := c.Shutdown(ctx); err != nil {
+					d.logger.Error("shutdown", "err", err)
+				}
+			}()
+		}
+		wait.Wait()
+	}
+}
+
 // HandleLogEntry implements the envelope.EnvelopeHandler interface.
 func (d *deployer) HandleLogEntry(_ context.Context, entry *protos.LogEntry) error {
 	d.logMu.Lock()
@@ -377,7 +417,11 @@ func (d *deployer) startGroup(g *group) error {
 		}
 		d.running.Go(func() error {
 			err := e.Serve(handler)
-			d.stop(err)
+			if err != nil {
+				// Note that Serve returns nil if the weavelet was shut down
+				// gracefully, in which case we don't stop the test.
+				d.stop(err)
+			}
 			return err
 		})
 		if err := d.registerReplica(g, e.WeaveletInfo()); err != nil {
@@ -446,3 +490,18 @@ func (c connection) UpdateComponents(components []string) error {
 	}
 	panic(fmt.Errorf("nil connection"))
 }
+
+// Shutdown is equivalent to Envelope.Shutdown.
+func (c connection) Shutdown(ctx context.Context) error {
+	if c.envelope != nil {
+		return c.envelope.Shutdown(ctx)
+	}
+	if c.conn != nil {
+		req := &protos.ShutdownRequest{}
+		if deadline, ok := ctx.Deadline(); ok {
+			req.GracePeriodNs = int64(time.Until(deadline))
+		}
+		return c.conn.ShutdownRPC(req)
+	}
+	panic(fmt.Errorf("nil connection"))
+}
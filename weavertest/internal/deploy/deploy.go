@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/ServiceWeaver/weaver"
 	"github.com/google/uuid"
@@ -30,10 +31,14 @@ type Started interface {
 	MarkStarted(_ context.Context, dir string) error
 }
 
-// started is a Service Weaver component that can mark itself as started.
+// started is a Service Weaver component that can mark itself as started and,
+// when shut down, as stopped.
 type started struct {
 	weaver.Implements[Started]
 	id uuid.UUID
+
+	mu  sync.Mutex
+	dir string // the directory passed to MarkStarted, if any
 }
 
 func (d *started) Init(context.Context) error {
@@ -45,10 +50,25 @@ func (d *started) Init(context.Context) error {
 // "started". You can count the number of started components by counting the
 // number of "*.started" files.
 func (d *started) MarkStarted(_ context.Context, dir string) error {
+	d.mu.Lock()
+	d.dir = dir
+	d.mu.Unlock()
 	filename := filepath.Join(dir, fmt.Sprintf("%s.started", d.id))
 	return os.WriteFile(filename, []byte{}, 0600)
 }
 
+// Shutdown writes a unique file with suffix "stopped" to the directory
+// previously passed to MarkStarted, if any.
+func (d *started) Shutdown(context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.dir == "" {
+		return nil
+	}
+	filename := filepath.Join(d.dir, fmt.Sprintf("%s.stopped", d.id))
+	return os.WriteFile(filename, []byte{}, 0600)
+}
+
 type Widget interface {
 	Use(ctx context.Context, dir string) error
 }
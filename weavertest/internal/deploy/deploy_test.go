@@ -46,21 +46,53 @@ func TestReplicated(t *testing.T) {
 	}
 }
 
+// TestShutdown tests that every replica of a component is shut down
+// gracefully when a test ends.
+func TestShutdown(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	// Run the application in a subtest, so that it is shut down when the
+	// subtest ends.
+	t.Run("Run", func(t *testing.T) {
+		root := weavertest.Init(ctx, t, weavertest.Options{})
+		w, err := weaver.Get[deploy.Widget](root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Use(ctx, dir); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Verify that the deployed processes were shut down.
+	want := weavertest.DefaultReplication
+	if got := countFiles(t, dir, "stopped"); got != want {
+		t.Fatalf("wrong number of stopped processes: want %d, got %d", want, got)
+	}
+}
+
 // numDeployed returns the number of Started or ReplicatedStarted components that
 // have been successfully deployed.
 func numDeployed(t *testing.T, dir string) int {
 	t.Helper()
 	// To get the count of successfully deployed processes, we count the number
 	// of files with suffix "started" in dir.
+	return countFiles(t, dir, "started")
+}
+
+// countFiles returns the number of files in dir with the provided suffix.
+func countFiles(t *testing.T, dir, suffix string) int {
+	t.Helper()
 	files, err := os.ReadDir(dir)
 	if err != nil {
 		t.Fatalf("os.ReadDir: %v", err)
 	}
-	numDeployed := 0
+	n := 0
 	for _, f := range files {
-		if strings.HasSuffix(f.Name(), "started") {
-			numDeployed++
+		if strings.HasSuffix(f.Name(), suffix) {
+			n++
 		}
 	}
-	return numDeployed
+	return n
 }
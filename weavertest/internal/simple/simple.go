@@ -20,9 +20,13 @@ package simple
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/ServiceWeaver/weaver"
 )
@@ -31,6 +35,7 @@ import (
 
 type Source interface {
 	Emit(ctx context.Context, file, msg string) error
+	Criticality(ctx context.Context) (string, error)
 }
 
 type source struct {
@@ -49,11 +54,67 @@ func (s *source) Emit(ctx context.Context, file, msg string) error {
 	return s.dst.Record(ctx, file, msg)
 }
 
+// Criticality returns the criticality of a call made by Source to Destination.
+func (s *source) Criticality(ctx context.Context) (string, error) {
+	return s.dst.Criticality(ctx)
+}
+
 type Destination interface {
 	Getpid(_ context.Context) (int, error)
 	Record(_ context.Context, file, msg string) error
 	GetAll(_ context.Context, file string) ([]string, error)
 	RoutedRecord(_ context.Context, file, msg string) error
+	Fail(_ context.Context, code int) error
+	Count(_ context.Context, n int, out weaver.StreamWriter[int]) error
+	Sum(_ context.Context, in weaver.StreamReader[int]) (int, error)
+	Metadata(_ context.Context) (map[string]string, error)
+	Criticality(_ context.Context) (string, error)
+	Scale(_ context.Context, s Shape, factor int) (Shape, error)
+
+	// SetUnhealthy makes the component report itself as unhealthy for the
+	// provided reason, or as healthy if the reason is empty.
+	SetUnhealthy(_ context.Context, reason string) error
+
+	// Sleep sleeps for the provided duration, or until ctx is done.
+	//
+	//weaver:timeout 100ms
+	Sleep(_ context.Context, d time.Duration) error
+}
+
+// AppError is an application error returned by Destination.Fail.
+type AppError struct {
+	weaver.AutoMarshal
+	Code int
+}
+
+func (e AppError) Error() string {
+	return fmt.Sprintf("app error %d", e.Code)
+}
+
+// Shape is a polymorphic value passed to and returned by Destination.Scale.
+type Shape interface {
+	Area() int
+}
+
+// Square is a Shape implemented by value.
+type Square struct {
+	weaver.AutoMarshal
+	Side int
+}
+
+func (s Square) Area() int { return s.Side * s.Side }
+
+// Rect is a Shape implemented by pointer.
+type Rect struct {
+	weaver.AutoMarshal
+	Width, Height int
+}
+
+func (r *Rect) Area() int { return r.Width * r.Height }
+
+func init() {
+	weaver.RegisterImpl[Shape, Square]()
+	weaver.RegisterImpl[Shape, Rect]()
 }
 
 type destRouter struct{}
@@ -65,7 +126,8 @@ func (r destRouter) RoutedRecord(_ context.Context, file, msg string) string {
 type destination struct {
 	weaver.Implements[Destination]
 	weaver.WithRouter[destRouter]
-	mu sync.Mutex
+	mu        sync.Mutex
+	unhealthy string // reason the component is unhealthy, if any
 }
 
 func (d *destination) Getpid(_ context.Context) (int, error) {
@@ -90,6 +152,84 @@ func (d *destination) RoutedRecord(ctx context.Context, file, msg string) error
 	return d.Record(ctx, file, "routed: "+msg)
 }
 
+// Fail returns a wrapped AppError with the provided code.
+func (d *destination) Fail(_ context.Context, code int) error {
+	return fmt.Errorf("failed: %w", AppError{Code: code})
+}
+
+// Count streams the integers in the range [0, n).
+func (d *destination) Count(_ context.Context, n int, out weaver.StreamWriter[int]) error {
+	for i := 0; i < n; i++ {
+		if err := out.Send(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sum returns the sum of the streamed integers.
+func (d *destination) Sum(_ context.Context, in weaver.StreamReader[int]) (int, error) {
+	sum := 0
+	for {
+		x, err := in.Next()
+		if errors.Is(err, io.EOF) {
+			return sum, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		sum += x
+	}
+}
+
+// Metadata returns the metadata attached to the context.
+func (d *destination) Metadata(ctx context.Context) (map[string]string, error) {
+	return weaver.Metadata(ctx), nil
+}
+
+// Criticality returns the criticality attached to the context.
+func (d *destination) Criticality(ctx context.Context) (string, error) {
+	return weaver.CriticalityOf(ctx).String(), nil
+}
+
+// Scale returns the provided shape, scaled by the provided factor.
+func (d *destination) Scale(_ context.Context, s Shape, factor int) (Shape, error) {
+	switch x := s.(type) {
+	case Square:
+		return Square{Side: x.Side * factor}, nil
+	case *Rect:
+		return &Rect{Width: x.Width * factor, Height: x.Height * factor}, nil
+	default:
+		return nil, fmt.Errorf("unexpected shape %T", s)
+	}
+}
+
+func (d *destination) SetUnhealthy(_ context.Context, reason string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.unhealthy = reason
+	return nil
+}
+
+// Health returns an error if the component was made unhealthy.
+func (d *destination) Health(context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.unhealthy != "" {
+		return errors.New(d.unhealthy)
+	}
+	return nil
+}
+
+func (d *destination) Sleep(ctx context.Context, duration time.Duration) error {
+	select {
+	case <-time.After(duration):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // GetAll returns all added messages.
 func (d *destination) GetAll(_ context.Context, file string) ([]string, error) {
 	d.mu.Lock()
@@ -102,3 +242,45 @@ func (d *destination) GetAll(_ context.Context, file string) ([]string, error) {
 	str := strings.TrimSpace(string(data))
 	return strings.Split(str, "\n"), nil
 }
+
+// Lists is a generic component. Every instantiation of Lists, e.g.,
+// Lists[int], is a separate component.
+type Lists[T any] interface {
+	Reverse(_ context.Context, list []T) ([]T, error)
+	First(_ context.Context, list []T) (Option[T], error)
+}
+
+// Option is an optional value returned by Lists.First.
+type Option[T any] struct {
+	weaver.AutoMarshal
+	Value T
+	Valid bool
+}
+
+// lists implements Lists[T].
+type lists[T any] struct{}
+
+func (lists[T]) Reverse(_ context.Context, list []T) ([]T, error) {
+	reversed := make([]T, len(list))
+	for i, x := range list {
+		reversed[len(list)-1-i] = x
+	}
+	return reversed, nil
+}
+
+func (lists[T]) First(_ context.Context, list []T) (Option[T], error) {
+	if len(list) == 0 {
+		return Option[T]{}, nil
+	}
+	return Option[T]{Value: list[0], Valid: true}, nil
+}
+
+type intLists struct {
+	weaver.Implements[Lists[int]]
+	lists[int]
+}
+
+type shapeLists struct {
+	weaver.Implements[Lists[Shape]]
+	lists[Shape]
+}
@@ -16,17 +16,21 @@ package simple_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/ServiceWeaver/weaver"
+	"github.com/ServiceWeaver/weaver/runtime/metrics"
 	"github.com/ServiceWeaver/weaver/weavertest"
 	"github.com/ServiceWeaver/weaver/weavertest/internal/simple"
 	"github.com/google/uuid"
@@ -113,6 +117,473 @@ func TestTwoComponents(t *testing.T) {
 	}
 }
 
+func TestTypedError(t *testing.T) {
+	// Check that an error returned by a (possibly remote) component method can
+	// be converted back to its original type using errors.As.
+	for _, single := range []bool{true, false} {
+		t.Run(fmt.Sprintf("Single=%t", single), func(t *testing.T) {
+			ctx := context.Background()
+			root := weavertest.Init(ctx, t, weavertest.Options{SingleProcess: single})
+			dst, err := weaver.Get[simple.Destination](root)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = dst.Fail(ctx, 42)
+			var appErr simple.AppError
+			if !errors.As(err, &appErr) {
+				t.Fatalf("errors.As(%v, AppError): got false, want true", err)
+			}
+			if appErr.Code != 42 {
+				t.Fatalf("AppError.Code: got %d, want 42", appErr.Code)
+			}
+			if errors.Is(err, weaver.RemoteCallError) {
+				t.Fatalf("errors.Is(%v, RemoteCallError): got true, want false", err)
+			}
+		})
+	}
+}
+
+func TestInterfaceValues(t *testing.T) {
+	// Pass values of registered types in interface-typed arguments and
+	// results of a (possibly remote) component method.
+	for _, single := range []bool{true, false} {
+		t.Run(fmt.Sprintf("Single=%t", single), func(t *testing.T) {
+			ctx := context.Background()
+			root := weavertest.Init(ctx, t, weavertest.Options{SingleProcess: single})
+			dst, err := weaver.Get[simple.Destination](root)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, c := range []struct {
+				in, want simple.Shape
+			}{
+				{simple.Square{Side: 2}, simple.Square{Side: 6}},
+				{&simple.Rect{Width: 1, Height: 2}, &simple.Rect{Width: 3, Height: 6}},
+			} {
+				got, err := dst.Scale(ctx, c.in, 3)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(got, c.want) {
+					t.Fatalf("Scale(%v, 3): got %#v, want %#v", c.in, got, c.want)
+				}
+			}
+
+			// A nil interface value is preserved.
+			if _, err := dst.Scale(ctx, nil, 3); err == nil || !strings.Contains(err.Error(), "unexpected shape <nil>") {
+				t.Fatalf("Scale(nil, 3): got %v, want unexpected shape error", err)
+			}
+		})
+	}
+}
+
+func TestGenericComponents(t *testing.T) {
+	// Call the methods of different instantiations of a generic component.
+	for _, single := range []bool{true, false} {
+		t.Run(fmt.Sprintf("Single=%t", single), func(t *testing.T) {
+			ctx := context.Background()
+			root := weavertest.Init(ctx, t, weavertest.Options{SingleProcess: single})
+			ints, err := weaver.Get[simple.Lists[int]](root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			shapes, err := weaver.Get[simple.Lists[simple.Shape]](root)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			reversed, err := ints.Reverse(ctx, []int{1, 2, 3})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := []int{3, 2, 1}; !reflect.DeepEqual(reversed, want) {
+				t.Fatalf("Lists[int].Reverse: got %v, want %v", reversed, want)
+			}
+			first, err := ints.First(ctx, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if first.Valid {
+				t.Fatalf("Lists[int].First(nil): got %+v, want invalid", first)
+			}
+
+			square, rect := simple.Square{Side: 2}, &simple.Rect{Width: 1, Height: 2}
+			got, err := shapes.First(ctx, []simple.Shape{square, rect})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Valid || !reflect.DeepEqual(got.Value, square) {
+				t.Fatalf("Lists[Shape].First: got %+v, want %v", got, square)
+			}
+		})
+	}
+}
+
+func TestStreaming(t *testing.T) {
+	// Stream values to and from a (possibly remote) component.
+	for _, single := range []bool{true, false} {
+		t.Run(fmt.Sprintf("Single=%t", single), func(t *testing.T) {
+			ctx := context.Background()
+			root := weavertest.Init(ctx, t, weavertest.Options{SingleProcess: single})
+			dst, err := weaver.Get[simple.Destination](root)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			const n = 1000
+			var got []int
+			collect := weaver.StreamWriterFunc[int](func(x int) error {
+				got = append(got, x)
+				return nil
+			})
+			if err := dst.Count(ctx, n, collect); err != nil {
+				t.Fatal(err)
+			}
+			values := make([]int, n)
+			for i := range values {
+				values[i] = i
+			}
+			if !reflect.DeepEqual(got, values) {
+				t.Fatalf("Count(%d) = %v; expecting %v", n, got, values)
+			}
+
+			sum, err := dst.Sum(ctx, weaver.SliceReader(values))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := n * (n - 1) / 2; sum != want {
+				t.Fatalf("Sum() = %d; expecting %d", sum, want)
+			}
+
+			// An error returned by the caller's writer stops the method.
+			errStop := errors.New("stop")
+			stop := weaver.StreamWriterFunc[int](func(x int) error {
+				if x == 10 {
+					return errStop
+				}
+				return nil
+			})
+			if err := dst.Count(ctx, n, stop); !errors.Is(err, errStop) {
+				t.Fatalf("Count: got %v, want %v", err, errStop)
+			}
+		})
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	// Check that metadata attached to the context is propagated to a
+	// (possibly remote) component.
+	for _, single := range []bool{true, false} {
+		t.Run(fmt.Sprintf("Single=%t", single), func(t *testing.T) {
+			ctx := context.Background()
+			root := weavertest.Init(ctx, t, weavertest.Options{SingleProcess: single})
+			dst, err := weaver.Get[simple.Destination](root)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := map[string]string{"tenant": "acme", "request": "42"}
+			got, err := dst.Metadata(weaver.WithMetadata(ctx, want))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("Metadata() = %v; expecting %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCriticality(t *testing.T) {
+	// Check that the criticality attached to a context is propagated across
+	// nested component method calls.
+	for _, single := range []bool{true, false} {
+		t.Run(fmt.Sprintf("Single=%t", single), func(t *testing.T) {
+			ctx := context.Background()
+			root := weavertest.Init(ctx, t, weavertest.Options{SingleProcess: single})
+			src, err := weaver.Get[simple.Source](root)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, c := range []weaver.Criticality{weaver.Sheddable, weaver.DefaultCriticality, weaver.Critical} {
+				got, err := src.Criticality(weaver.WithCriticality(ctx, c))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want := c.String(); got != want {
+					t.Fatalf("Criticality() = %q; expecting %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHealthz(t *testing.T) {
+	// Check that /healthz reflects the health of the components hosted in
+	// the process. Note that we run the components in a single process, so
+	// that they are hosted in the test process.
+	ctx := context.Background()
+	root := weavertest.Init(ctx, t, weavertest.Options{SingleProcess: true})
+	dst, err := weaver.Get[simple.Destination](root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	healthz := func() (int, string) {
+		w := httptest.NewRecorder()
+		http.DefaultServeMux.ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+		return w.Code, w.Body.String()
+	}
+	if err := dst.SetUnhealthy(ctx, "disk full"); err != nil {
+		t.Fatal(err)
+	}
+	if code, body := healthz(); code != http.StatusServiceUnavailable || !strings.Contains(body, "disk full") {
+		t.Fatalf("unhealthy /healthz: got %d %q, want %d and the reason", code, body, http.StatusServiceUnavailable)
+	}
+	if err := dst.SetUnhealthy(ctx, ""); err != nil {
+		t.Fatal(err)
+	}
+	if code, body := healthz(); code != http.StatusOK || body != "ok" {
+		t.Fatalf("healthy /healthz: got %d %q, want %d %q", code, body, http.StatusOK, "ok")
+	}
+}
+
+func TestCallOptions(t *testing.T) {
+	// Check that the timeout declared for Destination.Sleep, or the timeout
+	// configured in the config, is applied to remote calls. Call options
+	// don't apply to local calls, so only multiprocess mode is tested.
+	for _, test := range []struct {
+		name    string
+		config  string
+		timeout time.Duration
+	}{
+		{"Declared", "", 100 * time.Millisecond},
+		{"Configured", `
+[calls."github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination".methods.Sleep]
+timeout = "1ms"
+`, time.Millisecond},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			root := weavertest.Init(ctx, t, weavertest.Options{Config: test.config})
+			dst, err := weaver.Get[simple.Destination](root)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Make sure the remote component is up before measuring.
+			if _, err := dst.Getpid(ctx); err != nil {
+				t.Fatal(err)
+			}
+			start := time.Now()
+			err = dst.Sleep(ctx, time.Minute)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("Sleep: got error %v, want %v", err, context.DeadlineExceeded)
+			}
+			if elapsed := time.Since(start); elapsed < test.timeout || elapsed > 10*time.Second {
+				t.Fatalf("Sleep: took %v, want about %v", elapsed, test.timeout)
+			}
+		})
+	}
+}
+
+func TestAuthorization(t *testing.T) {
+	// The authorization policy only applies to remote calls, so only
+	// multiprocess mode is tested.
+	const config = `
+[authorization."github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination"]
+callers = ["main"]
+
+[authorization."github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination".methods.Record]
+callers = ["github.com/ServiceWeaver/weaver/weavertest/internal/simple/Source"]
+`
+	ctx := context.Background()
+	root := weavertest.Init(ctx, t, weavertest.Options{Config: config})
+	src, err := weaver.Get[simple.Source](root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst, err := weaver.Get[simple.Destination](root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// main may call Destination.Getpid.
+	if _, err := dst.Getpid(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Source, but not main, may call Destination.Record.
+	file := filepath.Join(t.TempDir(), "authorization")
+	if err := src.Emit(ctx, file, "allowed"); err != nil {
+		t.Fatal(err)
+	}
+	err = dst.Record(ctx, file, "denied")
+	if !errors.Is(err, weaver.PermissionDeniedError) {
+		t.Fatalf("Record: got error %v, want %v", err, weaver.PermissionDeniedError)
+	}
+	if !errors.Is(err, weaver.RemoteCallError) {
+		t.Fatalf("Record: got error %v, expecting a weaver.RemoteCallError", err)
+	}
+	got, err := dst.GetAll(ctx, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"allowed"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("GetAll: got %v, want %v", got, want)
+	}
+}
+
+func TestCompression(t *testing.T) {
+	// Compression only applies to remote calls, so only multiprocess mode is
+	// tested.
+	const config = `
+[calls."github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination"]
+compression = "deflate"
+compression_threshold = 100
+`
+	ctx := context.Background()
+	root := weavertest.Init(ctx, t, weavertest.Options{Config: config})
+	dst, err := weaver.Get[simple.Destination](root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(t.TempDir(), "compression")
+	msg := strings.Repeat("compress-me,", 1000)
+	if err := dst.Record(ctx, file, msg); err != nil {
+		t.Fatal(err)
+	}
+	got, err := dst.GetAll(ctx, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{msg}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("GetAll: got %d messages, want %d", len(got), len(want))
+	}
+
+	// Check that the compressed sizes were measured.
+	for _, test := range []struct{ metric, method string }{
+		{"serviceweaver_remote_method_bytes_request", "Record"},
+		{"serviceweaver_remote_method_bytes_reply", "GetAll"},
+	} {
+		found := false
+		for _, m := range metrics.Snapshot() {
+			if m.Name == test.metric && m.Labels["method"] == test.method && m.Labels["compressed"] == "true" {
+				found = found || m.Value > 0 && m.Value < float64(len(msg))
+			}
+		}
+		if !found {
+			t.Errorf("no compressed %s for %s", test.metric, test.method)
+		}
+	}
+}
+
+func TestBalancer(t *testing.T) {
+	// Balancers only apply to remote calls, so only multiprocess mode is
+	// tested.
+	const config = `
+[calls."github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination"]
+balancer = "least_latency"
+`
+	ctx := context.Background()
+	root := weavertest.Init(ctx, t, weavertest.Options{Config: config})
+	dst, err := weaver.Get[simple.Destination](root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(t.TempDir(), "balancer")
+	for i := 0; i < 10; i++ {
+		if err := dst.Record(ctx, file, fmt.Sprint(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Check that the balancer's view of the replicas was exported.
+	found := false
+	for _, m := range metrics.Snapshot() {
+		if m.Name == "serviceweaver_balancer_latency_micros" && m.Labels["balancer"] == "least_latency" {
+			found = found || m.Value > 0
+		}
+	}
+	if !found {
+		t.Error("no latency exported by the least_latency balancer")
+	}
+}
+
+// intercepted records the client calls to Destination seen by the test
+// interceptor. Calls are only recorded if they carry "record" metadata.
+var intercepted struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func init() {
+	// Interceptors are registered in an init function, so that they are also
+	// registered in the processes started by multiprocess tests.
+	weaver.InterceptRemoteCallsTo[simple.Destination](weaver.RemoteInterceptors{
+		Client: func(ctx context.Context, call weaver.RemoteCallInfo, invoke func(context.Context) error) error {
+			if weaver.Metadata(ctx)["record"] != "" {
+				intercepted.mu.Lock()
+				intercepted.calls = append(intercepted.calls, call.Component+"."+call.Method)
+				intercepted.mu.Unlock()
+			}
+			return invoke(ctx)
+		},
+		Server: func(ctx context.Context, call weaver.RemoteCallInfo, handle func(context.Context) error) error {
+			if fault := weaver.Metadata(ctx)["fault"]; fault != "" {
+				return fmt.Errorf("injected fault %q in %s", fault, call.Method)
+			}
+			return handle(ctx)
+		},
+	})
+}
+
+func TestInterceptors(t *testing.T) {
+	for _, single := range []bool{true, false} {
+		// Local calls, which are made when the application runs in a single
+		// process, are intercepted like remote calls.
+		t.Run(fmt.Sprintf("Single=%t", single), func(t *testing.T) {
+			ctx := context.Background()
+			root := weavertest.Init(ctx, t, weavertest.Options{SingleProcess: single})
+			dst, err := weaver.Get[simple.Destination](root)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Check that the client interceptor sees the call.
+			intercepted.mu.Lock()
+			intercepted.calls = nil
+			intercepted.mu.Unlock()
+			ctx = weaver.WithMetadata(ctx, map[string]string{"record": "true"})
+			if _, err := dst.Getpid(ctx); err != nil {
+				t.Fatal(err)
+			}
+			intercepted.mu.Lock()
+			got := intercepted.calls
+			intercepted.mu.Unlock()
+			want := []string{"github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination.Getpid"}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("intercepted calls = %v; expecting %v", got, want)
+			}
+
+			// Check that the server interceptor can fail the call.
+			ctx = weaver.WithMetadata(ctx, map[string]string{"fault": "boom"})
+			_, err = dst.Getpid(ctx)
+			if err == nil || !strings.Contains(err.Error(), `injected fault "boom" in Getpid`) {
+				t.Fatalf("Getpid: got error %v, expecting injected fault", err)
+			}
+			if !errors.Is(err, weaver.RemoteCallError) {
+				t.Fatalf("Getpid: got error %v, expecting a weaver.RemoteCallError", err)
+			}
+		})
+	}
+}
+
 func TestListener(t *testing.T) {
 	for _, single := range []bool{true, false} {
 		// Get a listener, serve on it, and make an HTTP request to the server.
@@ -4,6 +4,7 @@ package simple
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/ServiceWeaver/weaver"
 	"github.com/ServiceWeaver/weaver/runtime/codegen"
 	"go.opentelemetry.io/otel/codes"
@@ -18,23 +19,54 @@ func init() {
 		Iface:  reflect.TypeOf((*Destination)(nil)).Elem(),
 		New:    func() any { return &destination{} },
 		Routed: true,
+		MethodOptions: map[string]codegen.MethodOptions{
+			"Sleep": {Timeout: 100000000 /* 100ms */},
+		},
 		LocalStubFn: func(impl any, tracer trace.Tracer) any {
 			return destination_local_stub{impl: impl.(Destination), tracer: tracer}
 		},
 		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return destination_client_stub{stub: stub, getpidMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "Getpid"}), recordMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "Record"}), getAllMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "GetAll"}), routedRecordMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "RoutedRecord"})}
+			return destination_client_stub{stub: stub, getpidMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "Getpid"}), recordMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "Record"}), getAllMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "GetAll"}), routedRecordMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "RoutedRecord"}), failMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "Fail"}), countMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "Count"}), sumMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "Sum"}), metadataMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "Metadata"}), criticalityMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "Criticality"}), scaleMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "Scale"}), setUnhealthyMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "SetUnhealthy"}), sleepMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "Sleep"})}
 		},
 		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
 			return destination_server_stub{impl: impl.(Destination), addLoad: addLoad}
 		},
 	})
+	codegen.Register(codegen.Registration{
+		Name:  "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Lists[github.com/ServiceWeaver/weaver/weavertest/internal/simple.Shape]",
+		Iface: reflect.TypeOf((*Lists[Shape])(nil)).Elem(),
+		New:   func() any { return &shapeLists{} },
+		LocalStubFn: func(impl any, tracer trace.Tracer) any {
+			return lists_Shape_4cb892b2_local_stub{impl: impl.(Lists[Shape]), tracer: tracer}
+		},
+		ClientStubFn: func(stub codegen.Stub, caller string) any {
+			return lists_Shape_4cb892b2_client_stub{stub: stub, reverseMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Lists[github.com/ServiceWeaver/weaver/weavertest/internal/simple.Shape]", Method: "Reverse"}), firstMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Lists[github.com/ServiceWeaver/weaver/weavertest/internal/simple.Shape]", Method: "First"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
+			return lists_Shape_4cb892b2_server_stub{impl: impl.(Lists[Shape]), addLoad: addLoad}
+		},
+	})
+	codegen.Register(codegen.Registration{
+		Name:  "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Lists[int]",
+		Iface: reflect.TypeOf((*Lists[int])(nil)).Elem(),
+		New:   func() any { return &intLists{} },
+		LocalStubFn: func(impl any, tracer trace.Tracer) any {
+			return lists_int_7a437bea_local_stub{impl: impl.(Lists[int]), tracer: tracer}
+		},
+		ClientStubFn: func(stub codegen.Stub, caller string) any {
+			return lists_int_7a437bea_client_stub{stub: stub, reverseMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Lists[int]", Method: "Reverse"}), firstMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Lists[int]", Method: "First"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
+			return lists_int_7a437bea_server_stub{impl: impl.(Lists[int]), addLoad: addLoad}
+		},
+	})
 	codegen.Register(codegen.Registration{
 		Name:        "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Source",
 		Iface:       reflect.TypeOf((*Source)(nil)).Elem(),
 		New:         func() any { return &source{} },
 		LocalStubFn: func(impl any, tracer trace.Tracer) any { return source_local_stub{impl: impl.(Source), tracer: tracer} },
 		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return source_client_stub{stub: stub, emitMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Source", Method: "Emit"})}
+			return source_client_stub{stub: stub, emitMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Source", Method: "Emit"}), criticalityMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Source", Method: "Criticality"})}
 		},
 		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
 			return source_server_stub{impl: impl.(Source), addLoad: addLoad}
@@ -117,6 +149,220 @@ func (s destination_local_stub) RoutedRecord(ctx context.Context, a0 string, a1
 	return s.impl.RoutedRecord(ctx, a0, a1)
 }
 
+func (s destination_local_stub) Fail(ctx context.Context, a0 int) (err error) {
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Destination.Fail", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Fail(ctx, a0)
+}
+
+func (s destination_local_stub) Count(ctx context.Context, a0 int, a1 weaver.StreamWriter[int]) (err error) {
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Destination.Count", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Count(ctx, a0, a1)
+}
+
+func (s destination_local_stub) Sum(ctx context.Context, a0 weaver.StreamReader[int]) (r0 int, err error) {
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Destination.Sum", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Sum(ctx, a0)
+}
+
+func (s destination_local_stub) Metadata(ctx context.Context) (r0 map[string]string, err error) {
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Destination.Metadata", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Metadata(ctx)
+}
+
+func (s destination_local_stub) Criticality(ctx context.Context) (r0 string, err error) {
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Destination.Criticality", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Criticality(ctx)
+}
+
+func (s destination_local_stub) Scale(ctx context.Context, a0 Shape, a1 int) (r0 Shape, err error) {
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Destination.Scale", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Scale(ctx, a0, a1)
+}
+
+func (s destination_local_stub) SetUnhealthy(ctx context.Context, a0 string) (err error) {
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Destination.SetUnhealthy", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.SetUnhealthy(ctx, a0)
+}
+
+func (s destination_local_stub) Sleep(ctx context.Context, a0 time.Duration) (err error) {
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Destination.Sleep", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Sleep(ctx, a0)
+}
+
+type lists_Shape_4cb892b2_local_stub struct {
+	impl   Lists[Shape]
+	tracer trace.Tracer
+}
+
+func (s lists_Shape_4cb892b2_local_stub) Reverse(ctx context.Context, a0 []Shape) (r0 []Shape, err error) {
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Lists[Shape].Reverse", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Reverse(ctx, a0)
+}
+
+func (s lists_Shape_4cb892b2_local_stub) First(ctx context.Context, a0 []Shape) (r0 Option[Shape], err error) {
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Lists[Shape].First", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.First(ctx, a0)
+}
+
+type lists_int_7a437bea_local_stub struct {
+	impl   Lists[int]
+	tracer trace.Tracer
+}
+
+func (s lists_int_7a437bea_local_stub) Reverse(ctx context.Context, a0 []int) (r0 []int, err error) {
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Lists[int].Reverse", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Reverse(ctx, a0)
+}
+
+func (s lists_int_7a437bea_local_stub) First(ctx context.Context, a0 []int) (r0 Option[int], err error) {
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Lists[int].First", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.First(ctx, a0)
+}
+
 type source_local_stub struct {
 	impl   Source
 	tracer trace.Tracer
@@ -139,6 +385,23 @@ func (s source_local_stub) Emit(ctx context.Context, a0 string, a1 string) (err
 	return s.impl.Emit(ctx, a0, a1)
 }
 
+func (s source_local_stub) Criticality(ctx context.Context) (r0 string, err error) {
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Source.Criticality", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Criticality(ctx)
+}
+
 // Client stub implementations.
 
 type destination_client_stub struct {
@@ -147,6 +410,14 @@ type destination_client_stub struct {
 	recordMetrics       *codegen.MethodMetrics
 	getAllMetrics       *codegen.MethodMetrics
 	routedRecordMetrics *codegen.MethodMetrics
+	failMetrics         *codegen.MethodMetrics
+	countMetrics        *codegen.MethodMetrics
+	sumMetrics          *codegen.MethodMetrics
+	metadataMetrics     *codegen.MethodMetrics
+	criticalityMetrics  *codegen.MethodMetrics
+	scaleMetrics        *codegen.MethodMetrics
+	setUnhealthyMetrics *codegen.MethodMetrics
+	sleepMetrics        *codegen.MethodMetrics
 }
 
 func (s destination_client_stub) Getpid(ctx context.Context) (r0 int, err error) {
@@ -184,7 +455,7 @@ func (s destination_client_stub) Getpid(ctx context.Context) (r0 int, err error)
 	// Call the remote method.
 	s.getpidMetrics.BytesRequest.Put(0)
 	var results []byte
-	results, err = s.stub.Run(ctx, 1, nil, shardKey)
+	results, err = s.stub.Run(ctx, 4, nil, shardKey)
 	if err != nil {
 		err = errors.Join(weaver.RemoteCallError, err)
 		return
@@ -243,7 +514,7 @@ func (s destination_client_stub) Record(ctx context.Context, a0 string, a1 strin
 	// Call the remote method.
 	s.recordMetrics.BytesRequest.Put(float64(len(enc.Data())))
 	var results []byte
-	results, err = s.stub.Run(ctx, 2, enc.Data(), shardKey)
+	results, err = s.stub.Run(ctx, 6, enc.Data(), shardKey)
 	if err != nil {
 		err = errors.Join(weaver.RemoteCallError, err)
 		return
@@ -299,7 +570,7 @@ func (s destination_client_stub) GetAll(ctx context.Context, a0 string) (r0 []st
 	// Call the remote method.
 	s.getAllMetrics.BytesRequest.Put(float64(len(enc.Data())))
 	var results []byte
-	results, err = s.stub.Run(ctx, 0, enc.Data(), shardKey)
+	results, err = s.stub.Run(ctx, 3, enc.Data(), shardKey)
 	if err != nil {
 		err = errors.Join(weaver.RemoteCallError, err)
 		return
@@ -361,7 +632,7 @@ func (s destination_client_stub) RoutedRecord(ctx context.Context, a0 string, a1
 	// Call the remote method.
 	s.routedRecordMetrics.BytesRequest.Put(float64(len(enc.Data())))
 	var results []byte
-	results, err = s.stub.Run(ctx, 3, enc.Data(), shardKey)
+	results, err = s.stub.Run(ctx, 7, enc.Data(), shardKey)
 	if err != nil {
 		err = errors.Join(weaver.RemoteCallError, err)
 		return
@@ -374,20 +645,15 @@ func (s destination_client_stub) RoutedRecord(ctx context.Context, a0 string, a1
 	return
 }
 
-type source_client_stub struct {
-	stub        codegen.Stub
-	emitMetrics *codegen.MethodMetrics
-}
-
-func (s source_client_stub) Emit(ctx context.Context, a0 string, a1 string) (err error) {
+func (s destination_client_stub) Fail(ctx context.Context, a0 int) (err error) {
 	// Update metrics.
 	start := time.Now()
-	s.emitMetrics.Count.Add(1)
+	s.failMetrics.Count.Add(1)
 
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.stub.Tracer().Start(ctx, "simple.Source.Emit", trace.WithSpanKind(trace.SpanKindClient))
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Destination.Fail", trace.WithSpanKind(trace.SpanKindClient))
 	}
 
 	defer func() {
@@ -402,34 +668,32 @@ func (s source_client_stub) Emit(ctx context.Context, a0 string, a1 string) (err
 		if err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
-			s.emitMetrics.ErrorCount.Add(1)
+			s.failMetrics.ErrorCount.Add(1)
 		}
 		span.End()
 
-		s.emitMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+		s.failMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
 	}()
 
 	// Preallocate a buffer of the right size.
 	size := 0
-	size += (4 + len(a0))
-	size += (4 + len(a1))
+	size += 8
 	enc := codegen.NewEncoder()
 	enc.Reset(size)
 
 	// Encode arguments.
-	enc.String(a0)
-	enc.String(a1)
+	enc.Int(a0)
 	var shardKey uint64
 
 	// Call the remote method.
-	s.emitMetrics.BytesRequest.Put(float64(len(enc.Data())))
+	s.failMetrics.BytesRequest.Put(float64(len(enc.Data())))
 	var results []byte
-	results, err = s.stub.Run(ctx, 0, enc.Data(), shardKey)
+	results, err = s.stub.Run(ctx, 2, enc.Data(), shardKey)
 	if err != nil {
 		err = errors.Join(weaver.RemoteCallError, err)
 		return
 	}
-	s.emitMetrics.BytesReply.Put(float64(len(results)))
+	s.failMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
 	dec := codegen.NewDecoder(results)
@@ -437,41 +701,950 @@ func (s source_client_stub) Emit(ctx context.Context, a0 string, a1 string) (err
 	return
 }
 
-// Server stub implementations.
-
-type destination_server_stub struct {
-	impl    Destination
-	addLoad func(key uint64, load float64)
-}
+func (s destination_client_stub) Count(ctx context.Context, a0 int, a1 weaver.StreamWriter[int]) (err error) {
+	// Update metrics.
+	start := time.Now()
+	s.countMetrics.Count.Add(1)
 
-// GetStubFn implements the stub.Server interface.
-func (s destination_server_stub) GetStubFn(method string) func(ctx context.Context, args []byte) ([]byte, error) {
-	switch method {
-	case "Getpid":
-		return s.getpid
-	case "Record":
-		return s.record
-	case "GetAll":
-		return s.getAll
-	case "RoutedRecord":
-		return s.routedRecord
-	default:
-		return nil
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Destination.Count", trace.WithSpanKind(trace.SpanKindClient))
 	}
-}
 
-func (s destination_server_stub) getpid(ctx context.Context, args []byte) (res []byte, err error) {
-	// Catch and return any panics detected during encoding/decoding/rpc.
 	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
 		if err == nil {
 			err = codegen.CatchPanics(recover())
-		}
-	}()
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			s.countMetrics.ErrorCount.Add(1)
+		}
+		span.End()
+
+		s.countMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+	}()
+
+	// Preallocate a buffer of the right size.
+	size := 0
+	size += 8
+	enc := codegen.NewEncoder()
+	enc.Reset(size)
+
+	// Encode arguments.
+	enc.Int(a0)
+	var shardKey uint64
+
+	// Call the remote method.
+	s.countMetrics.BytesRequest.Put(float64(len(enc.Data())))
+	var results []byte
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var stream codegen.ClientStream
+	stream, err = s.stub.Stream(ctx, 0, enc.Data(), shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+
+	// Stream the values.
+	if err = codegen.RecvStream(stream, a1.Send, func(dec *codegen.Decoder) (v int) { v = dec.Int(); return }); err != nil {
+		return
+	}
+	results, err = stream.Result()
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+	s.countMetrics.BytesReply.Put(float64(len(results)))
+
+	// Decode the results.
+	dec := codegen.NewDecoder(results)
+	err = dec.Error()
+	return
+}
+
+func (s destination_client_stub) Sum(ctx context.Context, a0 weaver.StreamReader[int]) (r0 int, err error) {
+	// Update metrics.
+	start := time.Now()
+	s.sumMetrics.Count.Add(1)
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Destination.Sum", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			s.sumMetrics.ErrorCount.Add(1)
+		}
+		span.End()
+
+		s.sumMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+	}()
+
+	var shardKey uint64
+
+	// Call the remote method.
+	s.sumMetrics.BytesRequest.Put(0)
+	var results []byte
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var stream codegen.ClientStream
+	stream, err = s.stub.Stream(ctx, 11, nil, shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+
+	// Stream the values.
+	if err = codegen.SendStream(stream, a0.Next, func(enc *codegen.Encoder, v int) { enc.Int(v) }); err != nil {
+		return
+	}
+	results, err = stream.Result()
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+	s.sumMetrics.BytesReply.Put(float64(len(results)))
+
+	// Decode the results.
+	dec := codegen.NewDecoder(results)
+	r0 = dec.Int()
+	err = dec.Error()
+	return
+}
+
+func (s destination_client_stub) Metadata(ctx context.Context) (r0 map[string]string, err error) {
+	// Update metrics.
+	start := time.Now()
+	s.metadataMetrics.Count.Add(1)
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Destination.Metadata", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			s.metadataMetrics.ErrorCount.Add(1)
+		}
+		span.End()
+
+		s.metadataMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+	}()
+
+	var shardKey uint64
+
+	// Call the remote method.
+	s.metadataMetrics.BytesRequest.Put(0)
+	var results []byte
+	results, err = s.stub.Run(ctx, 5, nil, shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+	s.metadataMetrics.BytesReply.Put(float64(len(results)))
+
+	// Decode the results.
+	dec := codegen.NewDecoder(results)
+	r0 = serviceweaver_dec_map_string_string_219dd46d(dec)
+	err = dec.Error()
+	return
+}
+
+func (s destination_client_stub) Criticality(ctx context.Context) (r0 string, err error) {
+	// Update metrics.
+	start := time.Now()
+	s.criticalityMetrics.Count.Add(1)
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Destination.Criticality", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			s.criticalityMetrics.ErrorCount.Add(1)
+		}
+		span.End()
+
+		s.criticalityMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+	}()
+
+	var shardKey uint64
+
+	// Call the remote method.
+	s.criticalityMetrics.BytesRequest.Put(0)
+	var results []byte
+	results, err = s.stub.Run(ctx, 1, nil, shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+	s.criticalityMetrics.BytesReply.Put(float64(len(results)))
+
+	// Decode the results.
+	dec := codegen.NewDecoder(results)
+	r0 = dec.String()
+	err = dec.Error()
+	return
+}
+
+func (s destination_client_stub) Scale(ctx context.Context, a0 Shape, a1 int) (r0 Shape, err error) {
+	// Update metrics.
+	start := time.Now()
+	s.scaleMetrics.Count.Add(1)
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Destination.Scale", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			s.scaleMetrics.ErrorCount.Add(1)
+		}
+		span.End()
+
+		s.scaleMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+	}()
+
+	// Encode arguments.
+	enc := codegen.NewEncoder()
+	enc.Interface(a0)
+	enc.Int(a1)
+	var shardKey uint64
+
+	// Call the remote method.
+	s.scaleMetrics.BytesRequest.Put(float64(len(enc.Data())))
+	var results []byte
+	results, err = s.stub.Run(ctx, 8, enc.Data(), shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+	s.scaleMetrics.BytesReply.Put(float64(len(results)))
+
+	// Decode the results.
+	dec := codegen.NewDecoder(results)
+	dec.Interface(&r0)
+	err = dec.Error()
+	return
+}
+
+func (s destination_client_stub) SetUnhealthy(ctx context.Context, a0 string) (err error) {
+	// Update metrics.
+	start := time.Now()
+	s.setUnhealthyMetrics.Count.Add(1)
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Destination.SetUnhealthy", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			s.setUnhealthyMetrics.ErrorCount.Add(1)
+		}
+		span.End()
+
+		s.setUnhealthyMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+	}()
+
+	// Preallocate a buffer of the right size.
+	size := 0
+	size += (4 + len(a0))
+	enc := codegen.NewEncoder()
+	enc.Reset(size)
+
+	// Encode arguments.
+	enc.String(a0)
+	var shardKey uint64
+
+	// Call the remote method.
+	s.setUnhealthyMetrics.BytesRequest.Put(float64(len(enc.Data())))
+	var results []byte
+	results, err = s.stub.Run(ctx, 9, enc.Data(), shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+	s.setUnhealthyMetrics.BytesReply.Put(float64(len(results)))
+
+	// Decode the results.
+	dec := codegen.NewDecoder(results)
+	err = dec.Error()
+	return
+}
+
+func (s destination_client_stub) Sleep(ctx context.Context, a0 time.Duration) (err error) {
+	// Update metrics.
+	start := time.Now()
+	s.sleepMetrics.Count.Add(1)
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Destination.Sleep", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			s.sleepMetrics.ErrorCount.Add(1)
+		}
+		span.End()
+
+		s.sleepMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+	}()
+
+	// Encode arguments.
+	enc := codegen.NewEncoder()
+	enc.Int64((int64)(a0))
+	var shardKey uint64
+
+	// Call the remote method.
+	s.sleepMetrics.BytesRequest.Put(float64(len(enc.Data())))
+	var results []byte
+	results, err = s.stub.Run(ctx, 10, enc.Data(), shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+	s.sleepMetrics.BytesReply.Put(float64(len(results)))
+
+	// Decode the results.
+	dec := codegen.NewDecoder(results)
+	err = dec.Error()
+	return
+}
+
+type lists_Shape_4cb892b2_client_stub struct {
+	stub           codegen.Stub
+	reverseMetrics *codegen.MethodMetrics
+	firstMetrics   *codegen.MethodMetrics
+}
+
+func (s lists_Shape_4cb892b2_client_stub) Reverse(ctx context.Context, a0 []Shape) (r0 []Shape, err error) {
+	// Update metrics.
+	start := time.Now()
+	s.reverseMetrics.Count.Add(1)
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Lists[Shape].Reverse", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			s.reverseMetrics.ErrorCount.Add(1)
+		}
+		span.End()
+
+		s.reverseMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+	}()
+
+	// Encode arguments.
+	enc := codegen.NewEncoder()
+	serviceweaver_enc_slice_Shape_a450fcb0(enc, a0)
+	var shardKey uint64
+
+	// Call the remote method.
+	s.reverseMetrics.BytesRequest.Put(float64(len(enc.Data())))
+	var results []byte
+	results, err = s.stub.Run(ctx, 1, enc.Data(), shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+	s.reverseMetrics.BytesReply.Put(float64(len(results)))
+
+	// Decode the results.
+	dec := codegen.NewDecoder(results)
+	r0 = serviceweaver_dec_slice_Shape_a450fcb0(dec)
+	err = dec.Error()
+	return
+}
+
+func (s lists_Shape_4cb892b2_client_stub) First(ctx context.Context, a0 []Shape) (r0 Option[Shape], err error) {
+	// Update metrics.
+	start := time.Now()
+	s.firstMetrics.Count.Add(1)
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Lists[Shape].First", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			s.firstMetrics.ErrorCount.Add(1)
+		}
+		span.End()
+
+		s.firstMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+	}()
+
+	// Encode arguments.
+	enc := codegen.NewEncoder()
+	serviceweaver_enc_slice_Shape_a450fcb0(enc, a0)
+	var shardKey uint64
+
+	// Call the remote method.
+	s.firstMetrics.BytesRequest.Put(float64(len(enc.Data())))
+	var results []byte
+	results, err = s.stub.Run(ctx, 0, enc.Data(), shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+	s.firstMetrics.BytesReply.Put(float64(len(results)))
+
+	// Decode the results.
+	dec := codegen.NewDecoder(results)
+	serviceweaver_dec_Option_Shape_34559009(dec, &r0)
+	err = dec.Error()
+	return
+}
+
+type lists_int_7a437bea_client_stub struct {
+	stub           codegen.Stub
+	reverseMetrics *codegen.MethodMetrics
+	firstMetrics   *codegen.MethodMetrics
+}
+
+func (s lists_int_7a437bea_client_stub) Reverse(ctx context.Context, a0 []int) (r0 []int, err error) {
+	// Update metrics.
+	start := time.Now()
+	s.reverseMetrics.Count.Add(1)
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Lists[int].Reverse", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			s.reverseMetrics.ErrorCount.Add(1)
+		}
+		span.End()
+
+		s.reverseMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+	}()
+
+	// Preallocate a buffer of the right size.
+	size := 0
+	size += (4 + (len(a0) * 8))
+	enc := codegen.NewEncoder()
+	enc.Reset(size)
+
+	// Encode arguments.
+	serviceweaver_enc_slice_int_7c8c8866(enc, a0)
+	var shardKey uint64
+
+	// Call the remote method.
+	s.reverseMetrics.BytesRequest.Put(float64(len(enc.Data())))
+	var results []byte
+	results, err = s.stub.Run(ctx, 1, enc.Data(), shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+	s.reverseMetrics.BytesReply.Put(float64(len(results)))
+
+	// Decode the results.
+	dec := codegen.NewDecoder(results)
+	r0 = serviceweaver_dec_slice_int_7c8c8866(dec)
+	err = dec.Error()
+	return
+}
+
+func (s lists_int_7a437bea_client_stub) First(ctx context.Context, a0 []int) (r0 Option[int], err error) {
+	// Update metrics.
+	start := time.Now()
+	s.firstMetrics.Count.Add(1)
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Lists[int].First", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			s.firstMetrics.ErrorCount.Add(1)
+		}
+		span.End()
+
+		s.firstMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+	}()
+
+	// Preallocate a buffer of the right size.
+	size := 0
+	size += (4 + (len(a0) * 8))
+	enc := codegen.NewEncoder()
+	enc.Reset(size)
+
+	// Encode arguments.
+	serviceweaver_enc_slice_int_7c8c8866(enc, a0)
+	var shardKey uint64
+
+	// Call the remote method.
+	s.firstMetrics.BytesRequest.Put(float64(len(enc.Data())))
+	var results []byte
+	results, err = s.stub.Run(ctx, 0, enc.Data(), shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+	s.firstMetrics.BytesReply.Put(float64(len(results)))
+
+	// Decode the results.
+	dec := codegen.NewDecoder(results)
+	serviceweaver_dec_Option_int_b53de1ee(dec, &r0)
+	err = dec.Error()
+	return
+}
+
+type source_client_stub struct {
+	stub               codegen.Stub
+	emitMetrics        *codegen.MethodMetrics
+	criticalityMetrics *codegen.MethodMetrics
+}
+
+func (s source_client_stub) Emit(ctx context.Context, a0 string, a1 string) (err error) {
+	// Update metrics.
+	start := time.Now()
+	s.emitMetrics.Count.Add(1)
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Source.Emit", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			s.emitMetrics.ErrorCount.Add(1)
+		}
+		span.End()
+
+		s.emitMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+	}()
+
+	// Preallocate a buffer of the right size.
+	size := 0
+	size += (4 + len(a0))
+	size += (4 + len(a1))
+	enc := codegen.NewEncoder()
+	enc.Reset(size)
+
+	// Encode arguments.
+	enc.String(a0)
+	enc.String(a1)
+	var shardKey uint64
+
+	// Call the remote method.
+	s.emitMetrics.BytesRequest.Put(float64(len(enc.Data())))
+	var results []byte
+	results, err = s.stub.Run(ctx, 1, enc.Data(), shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+	s.emitMetrics.BytesReply.Put(float64(len(results)))
+
+	// Decode the results.
+	dec := codegen.NewDecoder(results)
+	err = dec.Error()
+	return
+}
+
+func (s source_client_stub) Criticality(ctx context.Context) (r0 string, err error) {
+	// Update metrics.
+	start := time.Now()
+	s.criticalityMetrics.Count.Add(1)
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Source.Criticality", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			s.criticalityMetrics.ErrorCount.Add(1)
+		}
+		span.End()
+
+		s.criticalityMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+	}()
+
+	var shardKey uint64
+
+	// Call the remote method.
+	s.criticalityMetrics.BytesRequest.Put(0)
+	var results []byte
+	results, err = s.stub.Run(ctx, 0, nil, shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+	s.criticalityMetrics.BytesReply.Put(float64(len(results)))
+
+	// Decode the results.
+	dec := codegen.NewDecoder(results)
+	r0 = dec.String()
+	err = dec.Error()
+	return
+}
+
+// Server stub implementations.
+
+type destination_server_stub struct {
+	impl    Destination
+	addLoad func(key uint64, load float64)
+}
+
+// GetStubFn implements the stub.Server interface.
+func (s destination_server_stub) GetStubFn(method string) func(ctx context.Context, args []byte) ([]byte, error) {
+	switch method {
+	case "Getpid":
+		return s.getpid
+	case "Record":
+		return s.record
+	case "GetAll":
+		return s.getAll
+	case "RoutedRecord":
+		return s.routedRecord
+	case "Fail":
+		return s.fail
+	case "Metadata":
+		return s.metadata
+	case "Criticality":
+		return s.criticality
+	case "Scale":
+		return s.scale
+	case "SetUnhealthy":
+		return s.setUnhealthy
+	case "Sleep":
+		return s.sleep
+	default:
+		return nil
+	}
+}
+
+// GetStreamStubFn implements the codegen.StreamServer interface.
+func (s destination_server_stub) GetStreamStubFn(method string) func(ctx context.Context, args []byte, stream codegen.ServerStream) ([]byte, error) {
+	switch method {
+	case "Count":
+		return s.count
+	case "Sum":
+		return s.sum
+	default:
+		return nil
+	}
+}
+
+func (s destination_server_stub) getpid(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.Getpid(ctx)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.Int(r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
+func (s destination_server_stub) record(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 string
+	a0 = dec.String()
+	var a1 string
+	a1 = dec.String()
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	appErr := s.impl.Record(ctx, a0, a1)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
+func (s destination_server_stub) getAll(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 string
+	a0 = dec.String()
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.GetAll(ctx, a0)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	serviceweaver_enc_slice_string_4af10117(enc, r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
+func (s destination_server_stub) routedRecord(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 string
+	a0 = dec.String()
+	var a1 string
+	a1 = dec.String()
+	var r destRouter
+	s.addLoad(_hashDestination(r.RoutedRecord(ctx, a0, a1)), 1.0)
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	appErr := s.impl.RoutedRecord(ctx, a0, a1)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
+func (s destination_server_stub) fail(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 int
+	a0 = dec.Int()
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	appErr := s.impl.Fail(ctx, a0)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
+func (s destination_server_stub) count(ctx context.Context, args []byte, stream codegen.ServerStream) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 int
+	a0 = dec.Int()
+
+	// Create the stream.
+	a1 := codegen.NewStreamWriter(stream.Send, func(enc *codegen.Encoder, v int) { enc.Int(v) })
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	appErr := s.impl.Count(ctx, a0, a1)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
+func (s destination_server_stub) sum(ctx context.Context, args []byte, stream codegen.ServerStream) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Create the stream.
+	a0 := codegen.NewStreamReader(stream.Recv, func(dec *codegen.Decoder) (v int) { v = dec.Int(); return })
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.Getpid(ctx)
+	r0, appErr := s.impl.Sum(ctx, a0)
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -480,7 +1653,47 @@ func (s destination_server_stub) getpid(ctx context.Context, args []byte) (res [
 	return enc.Data(), nil
 }
 
-func (s destination_server_stub) record(ctx context.Context, args []byte) (res []byte, err error) {
+func (s destination_server_stub) metadata(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.Metadata(ctx)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	serviceweaver_enc_map_string_string_219dd46d(enc, r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
+func (s destination_server_stub) criticality(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.Criticality(ctx)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.String(r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
+func (s destination_server_stub) scale(ctx context.Context, args []byte) (res []byte, err error) {
 	// Catch and return any panics detected during encoding/decoding/rpc.
 	defer func() {
 		if err == nil {
@@ -490,23 +1703,24 @@ func (s destination_server_stub) record(ctx context.Context, args []byte) (res [
 
 	// Decode arguments.
 	dec := codegen.NewDecoder(args)
-	var a0 string
-	a0 = dec.String()
-	var a1 string
-	a1 = dec.String()
+	var a0 Shape
+	dec.Interface(&a0)
+	var a1 int
+	a1 = dec.Int()
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	appErr := s.impl.Record(ctx, a0, a1)
+	r0, appErr := s.impl.Scale(ctx, a0, a1)
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
+	enc.Interface(r0)
 	enc.Error(appErr)
 	return enc.Data(), nil
 }
 
-func (s destination_server_stub) getAll(ctx context.Context, args []byte) (res []byte, err error) {
+func (s destination_server_stub) setUnhealthy(ctx context.Context, args []byte) (res []byte, err error) {
 	// Catch and return any panics detected during encoding/decoding/rpc.
 	defer func() {
 		if err == nil {
@@ -522,16 +1736,15 @@ func (s destination_server_stub) getAll(ctx context.Context, args []byte) (res [
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.GetAll(ctx, a0)
+	appErr := s.impl.SetUnhealthy(ctx, a0)
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
-	serviceweaver_enc_slice_string_4af10117(enc, r0)
 	enc.Error(appErr)
 	return enc.Data(), nil
 }
 
-func (s destination_server_stub) routedRecord(ctx context.Context, args []byte) (res []byte, err error) {
+func (s destination_server_stub) sleep(ctx context.Context, args []byte) (res []byte, err error) {
 	// Catch and return any panics detected during encoding/decoding/rpc.
 	defer func() {
 		if err == nil {
@@ -541,20 +1754,150 @@ func (s destination_server_stub) routedRecord(ctx context.Context, args []byte)
 
 	// Decode arguments.
 	dec := codegen.NewDecoder(args)
-	var a0 string
-	a0 = dec.String()
-	var a1 string
-	a1 = dec.String()
-	var r destRouter
-	s.addLoad(_hashDestination(r.RoutedRecord(ctx, a0, a1)), 1.0)
+	var a0 time.Duration
+	*(*int64)(&a0) = dec.Int64()
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	appErr := s.impl.RoutedRecord(ctx, a0, a1)
+	appErr := s.impl.Sleep(ctx, a0)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
+type lists_Shape_4cb892b2_server_stub struct {
+	impl    Lists[Shape]
+	addLoad func(key uint64, load float64)
+}
+
+// GetStubFn implements the stub.Server interface.
+func (s lists_Shape_4cb892b2_server_stub) GetStubFn(method string) func(ctx context.Context, args []byte) ([]byte, error) {
+	switch method {
+	case "Reverse":
+		return s.reverse
+	case "First":
+		return s.first
+	default:
+		return nil
+	}
+}
+
+func (s lists_Shape_4cb892b2_server_stub) reverse(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 []Shape
+	a0 = serviceweaver_dec_slice_Shape_a450fcb0(dec)
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.Reverse(ctx, a0)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	serviceweaver_enc_slice_Shape_a450fcb0(enc, r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
+func (s lists_Shape_4cb892b2_server_stub) first(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 []Shape
+	a0 = serviceweaver_dec_slice_Shape_a450fcb0(dec)
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.First(ctx, a0)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	serviceweaver_enc_Option_Shape_34559009(enc, &r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
+type lists_int_7a437bea_server_stub struct {
+	impl    Lists[int]
+	addLoad func(key uint64, load float64)
+}
+
+// GetStubFn implements the stub.Server interface.
+func (s lists_int_7a437bea_server_stub) GetStubFn(method string) func(ctx context.Context, args []byte) ([]byte, error) {
+	switch method {
+	case "Reverse":
+		return s.reverse
+	case "First":
+		return s.first
+	default:
+		return nil
+	}
+}
+
+func (s lists_int_7a437bea_server_stub) reverse(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 []int
+	a0 = serviceweaver_dec_slice_int_7c8c8866(dec)
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.Reverse(ctx, a0)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	serviceweaver_enc_slice_int_7c8c8866(enc, r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
+func (s lists_int_7a437bea_server_stub) first(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 []int
+	a0 = serviceweaver_dec_slice_int_7c8c8866(dec)
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.First(ctx, a0)
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
+	serviceweaver_enc_Option_int_b53de1ee(enc, &r0)
 	enc.Error(appErr)
 	return enc.Data(), nil
 }
@@ -569,6 +1912,8 @@ func (s source_server_stub) GetStubFn(method string) func(ctx context.Context, a
 	switch method {
 	case "Emit":
 		return s.emit
+	case "Criticality":
+		return s.criticality
 	default:
 		return nil
 	}
@@ -600,6 +1945,80 @@ func (s source_server_stub) emit(ctx context.Context, args []byte) (res []byte,
 	return enc.Data(), nil
 }
 
+func (s source_server_stub) criticality(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.Criticality(ctx)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.String(r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
+// AutoMarshal implementations.
+
+var _ codegen.AutoMarshal = &AppError{}
+
+func init() { codegen.RegisterSerializable[AppError]() }
+
+func (x *AppError) WeaverMarshal(enc *codegen.Encoder) {
+	if x == nil {
+		panic(fmt.Errorf("AppError.WeaverMarshal: nil receiver"))
+	}
+	enc.Int(x.Code)
+}
+
+func (x *AppError) WeaverUnmarshal(dec *codegen.Decoder) {
+	if x == nil {
+		panic(fmt.Errorf("AppError.WeaverUnmarshal: nil receiver"))
+	}
+	x.Code = dec.Int()
+}
+
+var _ codegen.AutoMarshal = &Rect{}
+
+func (x *Rect) WeaverMarshal(enc *codegen.Encoder) {
+	if x == nil {
+		panic(fmt.Errorf("Rect.WeaverMarshal: nil receiver"))
+	}
+	enc.Int(x.Width)
+	enc.Int(x.Height)
+}
+
+func (x *Rect) WeaverUnmarshal(dec *codegen.Decoder) {
+	if x == nil {
+		panic(fmt.Errorf("Rect.WeaverUnmarshal: nil receiver"))
+	}
+	x.Width = dec.Int()
+	x.Height = dec.Int()
+}
+
+var _ codegen.AutoMarshal = &Square{}
+
+func (x *Square) WeaverMarshal(enc *codegen.Encoder) {
+	if x == nil {
+		panic(fmt.Errorf("Square.WeaverMarshal: nil receiver"))
+	}
+	enc.Int(x.Side)
+}
+
+func (x *Square) WeaverUnmarshal(dec *codegen.Decoder) {
+	if x == nil {
+		panic(fmt.Errorf("Square.WeaverUnmarshal: nil receiver"))
+	}
+	x.Side = dec.Int()
+}
+
 // Router methods.
 
 // _hashDestination returns a 64 bit hash of the provided value.
@@ -640,3 +2059,97 @@ func serviceweaver_dec_slice_string_4af10117(dec *codegen.Decoder) []string {
 	}
 	return res
 }
+
+func serviceweaver_enc_map_string_string_219dd46d(enc *codegen.Encoder, arg map[string]string) {
+	if arg == nil {
+		enc.Len(-1)
+		return
+	}
+	enc.Len(len(arg))
+	for k, v := range arg {
+		enc.String(k)
+		enc.String(v)
+	}
+}
+
+func serviceweaver_dec_map_string_string_219dd46d(dec *codegen.Decoder) map[string]string {
+	n := dec.Len()
+	if n == -1 {
+		return nil
+	}
+	res := make(map[string]string, n)
+	var k string
+	var v string
+	for i := 0; i < n; i++ {
+		k = dec.String()
+		v = dec.String()
+		res[k] = v
+	}
+	return res
+}
+
+func serviceweaver_enc_slice_int_7c8c8866(enc *codegen.Encoder, arg []int) {
+	if arg == nil {
+		enc.Len(-1)
+		return
+	}
+	enc.Len(len(arg))
+	for i := 0; i < len(arg); i++ {
+		enc.Int(arg[i])
+	}
+}
+
+func serviceweaver_dec_slice_int_7c8c8866(dec *codegen.Decoder) []int {
+	n := dec.Len()
+	if n == -1 {
+		return nil
+	}
+	res := make([]int, n)
+	for i := 0; i < n; i++ {
+		res[i] = dec.Int()
+	}
+	return res
+}
+
+func serviceweaver_enc_Option_int_b53de1ee(enc *codegen.Encoder, arg *Option[int]) {
+	enc.Int(arg.Value)
+	enc.Bool(arg.Valid)
+}
+
+func serviceweaver_dec_Option_int_b53de1ee(dec *codegen.Decoder, res *Option[int]) {
+	res.Value = dec.Int()
+	res.Valid = dec.Bool()
+}
+
+func serviceweaver_enc_slice_Shape_a450fcb0(enc *codegen.Encoder, arg []Shape) {
+	if arg == nil {
+		enc.Len(-1)
+		return
+	}
+	enc.Len(len(arg))
+	for i := 0; i < len(arg); i++ {
+		enc.Interface(arg[i])
+	}
+}
+
+func serviceweaver_dec_slice_Shape_a450fcb0(dec *codegen.Decoder) []Shape {
+	n := dec.Len()
+	if n == -1 {
+		return nil
+	}
+	res := make([]Shape, n)
+	for i := 0; i < n; i++ {
+		dec.Interface(&res[i])
+	}
+	return res
+}
+
+func serviceweaver_enc_Option_Shape_34559009(enc *codegen.Encoder, arg *Option[Shape]) {
+	enc.Interface(arg.Value)
+	enc.Bool(arg.Valid)
+}
+
+func serviceweaver_dec_Option_Shape_34559009(dec *codegen.Decoder, res *Option[Shape]) {
+	dec.Interface(&res.Value)
+	res.Valid = dec.Bool()
+}